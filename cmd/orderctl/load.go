@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/ners1us/order-service/internal/loadgen"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+)
+
+func runLoad(args []string) error {
+	var opts loadgen.Options
+	var savePath, comparePath string
+	fs := flag.NewFlagSet("orderctl load", flag.ContinueOnError)
+	fs.StringVar(&opts.RESTURL, "rest-url", "http://localhost:8080", "base URL of the REST API")
+	fs.StringVar(&opts.GRPCAddr, "grpc-addr", "localhost:3000", "address of the gRPC API")
+	fs.IntVar(&opts.Workers, "workers", 8, "number of concurrent employees")
+	fs.DurationVar(&opts.Duration, "duration", time.Minute, "duration of the run")
+	fs.IntVar(&opts.ProductsPerReception, "products", 10, "products added to every reception")
+	fs.IntVar(&opts.DeletesPerReception, "deletes", 2, "products deleted from every reception before it is closed")
+	fs.StringVar(&savePath, "save", "", "write the result as JSON to this file")
+	fs.StringVar(&comparePath, "compare", "", "compare the result with one written by -save")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return errors.New("usage: orderctl load [flags]")
+	}
+
+	var baseline *loadgen.Result
+	if comparePath != "" {
+		var err error
+		if baseline, err = loadgen.LoadResult(comparePath); err != nil {
+			return fmt.Errorf("failed to read baseline: %w", err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	result, err := loadgen.Run(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d flows in %s (%.1f flows/s)\n\n", result.Flows, result.Elapsed.Round(time.Millisecond),
+		result.FlowsPerSecond())
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "operation\trequests\terrors\tp50\tp90\tp99\tmax\t")
+	for _, op := range result.Operations {
+		fmt.Fprintf(table, "%s\t%d\t%d\t%s\t%s\t%s\t%s\t\n", op.Name, op.Count, op.Errors,
+			roundLatency(op.P50), roundLatency(op.P90), roundLatency(op.P99), roundLatency(op.Max))
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	if savePath != "" {
+		if err := loadgen.SaveResult(savePath, result); err != nil {
+			return fmt.Errorf("failed to save result: %w", err)
+		}
+	}
+	if baseline != nil {
+		return printComparison(baseline, result)
+	}
+	return nil
+}
+
+// printComparison prints the throughput and the p50 and p99 latencies of
+// every operation before and after, with the relative change.
+func printComparison(before, after *loadgen.Result) error {
+	fmt.Printf("\nthroughput: %.1f -> %.1f flows/s (%s)\n\n", before.FlowsPerSecond(), after.FlowsPerSecond(),
+		relativeChange(before.FlowsPerSecond(), after.FlowsPerSecond()))
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "operation\tp50 before\tp50 after\tchange\tp99 before\tp99 after\tchange\t")
+	for _, op := range after.Operations {
+		prev, ok := before.Operation(op.Name)
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n", op.Name,
+			roundLatency(prev.P50), roundLatency(op.P50), relativeChange(float64(prev.P50), float64(op.P50)),
+			roundLatency(prev.P99), roundLatency(op.P99), relativeChange(float64(prev.P99), float64(op.P99)))
+	}
+	return table.Flush()
+}
+
+func relativeChange(before, after float64) string {
+	if before == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%+.1f%%", (after-before)/before*100)
+}
+
+func roundLatency(latency time.Duration) time.Duration {
+	return latency.Round(10 * time.Microsecond)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/ners1us/order-service/internal/config"
+	"log"
+	"os"
+)
+
+const usage = `Usage: orderctl <command> [arguments] [flags]
+
+Commands:
+  serve rest|grpc|all                      run the REST or gRPC server, or both, with the metrics server
+  migrate up|down [N]|status|force VERSION apply the embedded migrations
+  user create -email E -role ROLE          create a user, e.g. the first moderator
+  seed                                     create demo accounts and generate PVZs, receptions and products
+  load                                     drive the REST and gRPC APIs and report latency percentiles
+
+Every command except load accepts the configuration flags; run "orderctl <command> -h" to list them.`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch command := os.Args[1]; command {
+	case "serve":
+		err = runServe(os.Args[2:])
+	case "migrate":
+		err = runMigrate(os.Args[2:])
+	case "user":
+		err = runUser(os.Args[2:])
+	case "seed":
+		err = runSeed(os.Args[2:])
+	case "load":
+		err = runLoad(os.Args[2:])
+	case "help", "-h", "--help":
+		fmt.Println(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s\n", command, usage)
+		os.Exit(2)
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseArgs registers the configuration flags on fs and parses them, along
+// with the flags of the command, wherever they appear in args, so that
+// "serve rest -rest-port 8081" works. It returns the positional arguments.
+func parseArgs(fs *flag.FlagSet, args []string) (*config.Flags, []string, error) {
+	flags := config.RegisterFlags(fs)
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, nil, err
+		}
+		if fs.NArg() == 0 {
+			return flags, positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+func loadConfig(flags *config.Flags, servers ...config.Server) (*config.Config, error) {
+	cfg, err := flags.Load(servers...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	return cfg, nil
+}
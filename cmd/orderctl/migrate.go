@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/ners1us/order-service/internal/app"
+	"github.com/ners1us/order-service/internal/database"
+	"os"
+)
+
+func runMigrate(args []string) error {
+	fs := flag.NewFlagSet("orderctl migrate up|down [N]|status|force VERSION", flag.ContinueOnError)
+	flags, positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(flags)
+	if err != nil {
+		return err
+	}
+
+	db, err := app.OpenDBWithoutTimeout(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return database.RunMigrateCommand(context.Background(), db, positional, os.Stdout)
+}
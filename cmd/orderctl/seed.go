@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/ners1us/order-service/internal/app"
+	"github.com/ners1us/order-service/internal/event"
+	"github.com/ners1us/order-service/internal/seed"
+	"log/slog"
+	"os"
+	"time"
+)
+
+const seedDateLayout = "2006-01-02"
+
+func runSeed(args []string) error {
+	now := time.Now().UTC().Truncate(24 * time.Hour)
+	opts := seed.Options{
+		From: now.AddDate(0, 0, -30),
+		To:   now,
+	}
+
+	fs := flag.NewFlagSet("orderctl seed", flag.ContinueOnError)
+	fs.StringVar(&opts.Password, "password", "password", "password of the demo accounts")
+	fs.IntVar(&opts.PVZsPerCity, "pvzs-per-city", 10, "number of PVZs to create in every city")
+	fs.IntVar(&opts.ReceptionsPerPVZ, "receptions-per-pvz", 30, "number of closed receptions per PVZ")
+	fs.IntVar(&opts.MinProducts, "min-products", 5, "minimum number of products per reception")
+	fs.IntVar(&opts.MaxProducts, "max-products", 50, "maximum number of products per reception")
+	fs.Func("from", "start of the reception date range, "+seedDateLayout+" (default 30 days ago)", dateFlag(&opts.From))
+	fs.Func("to", "end of the reception date range, "+seedDateLayout+" (default today)", dateFlag(&opts.To))
+	fs.Uint64Var(&opts.RandomSeed, "random-seed", 1, "seed of the generated data")
+	flags, positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return errors.New("usage: orderctl seed [flags]")
+	}
+	cfg, err := loadConfig(flags)
+	if err != nil {
+		return err
+	}
+
+	db, err := app.OpenDBWithoutTimeout(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	services := app.NewServices(cfg, app.NewRepositories(db, db), event.NewBus(), slog.Default())
+	// Running servers drop their cached PVZ lists when the seeded PVZs are announced.
+	publisher := event.NewPGPublisher(db, cfg.NotifyChannel, "orderctl-seed")
+	report, err := seed.Run(context.Background(), db, services.User, publisher, opts)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+func dateFlag(target *time.Time) func(string) error {
+	return func(value string) error {
+		date, err := time.Parse(seedDateLayout, value)
+		if err != nil {
+			return fmt.Errorf("expected a date in the %s format", seedDateLayout)
+		}
+		*target = date
+		return nil
+	}
+}
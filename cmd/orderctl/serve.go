@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"github.com/ners1us/order-service/internal/app"
+	"github.com/ners1us/order-service/internal/config"
+	"os/signal"
+	"syscall"
+)
+
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("orderctl serve rest|grpc|all", flag.ContinueOnError)
+	flags, positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return errors.New("usage: orderctl serve rest|grpc|all [flags]")
+	}
+
+	var servers []config.Server
+	var serviceName string
+	switch positional[0] {
+	case "rest":
+		servers, serviceName = []config.Server{config.ServerREST}, "rest-app"
+	case "grpc":
+		servers, serviceName = []config.Server{config.ServerGRPC}, "grpc-app"
+	case "all":
+		servers, serviceName = []config.Server{config.ServerREST, config.ServerGRPC}, "order-service"
+	default:
+		return errors.New("usage: orderctl serve rest|grpc|all [flags]")
+	}
+	cfg, err := loadConfig(flags, servers...)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	rt, err := app.NewRuntime(ctx, cfg, serviceName)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer cancel()
+		rt.Close(closeCtx)
+	}()
+
+	return app.Serve(ctx, rt, servers...)
+}
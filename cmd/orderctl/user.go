@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/ners1us/order-service/internal/app"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/repository"
+	"github.com/ners1us/order-service/internal/service"
+	"os"
+	"strings"
+)
+
+func runUser(args []string) error {
+	fs := flag.NewFlagSet("orderctl user create", flag.ContinueOnError)
+	email := fs.String("email", "", "email of the user")
+	password := fs.String("password", "", "password of the user, read from stdin when empty")
+	role := fs.String("role", enum.RoleEmployee.String(), "role of the user: employee or moderator")
+	flags, positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || positional[0] != "create" {
+		return errors.New("usage: orderctl user create -email E [-password P] -role employee|moderator [flags]")
+	}
+	cfg, err := loadConfig(flags)
+	if err != nil {
+		return err
+	}
+
+	if *password == "" {
+		fmt.Fprint(os.Stderr, "password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("failed to read password: %w", err)
+		}
+		*password = strings.TrimRight(line, "\r\n")
+	}
+
+	db, err := app.OpenDB(context.Background(), cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// Tokens are not issued here, so the JWT settings do not matter.
+	userService := service.NewUserService(repository.NewUserRepository(db), service.NewJWTService(cfg.JWTSecret, cfg.TokenTTL))
+	user, err := userService.CreateUser(context.Background(), *email, *password, *role)
+	if err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+	fmt.Printf("created %s %s with id %s\n", user.Role, user.Email, user.ID)
+	return nil
+}
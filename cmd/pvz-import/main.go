@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"github.com/ners1us/order-service/internal/app"
+	"github.com/ners1us/order-service/internal/config"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
+	"github.com/ners1us/order-service/internal/repository"
+	"github.com/ners1us/order-service/internal/service"
+	"log"
+	"log/slog"
+	"os"
+)
+
+func main() {
+	filePath := flag.String("file", "", "path to the CSV file with PVZs")
+	dryRun := flag.Bool("dry-run", false, "validate the file without inserting PVZs")
+	configFlags := config.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	if *filePath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		log.Fatalf("failed to open import file: %v", err)
+	}
+	defer file.Close()
+
+	cfg, err := configFlags.Load()
+	if err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
+	}
+
+	db, err := app.OpenDB(context.Background(), cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	pvzImportService := service.NewPVZImportService(repository.NewPVZRepository(db, db), event.NewPGPublisher(db, cfg.NotifyChannel, "pvz-import"), slog.Default())
+
+	// The CLI is run by operators with direct database access, so it acts as a moderator.
+	report, err := pvzImportService.ImportPVZs(context.Background(), file, *dryRun, enum.RoleModerator.String())
+	if err != nil {
+		log.Fatalf("pvz import failed: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Fatalf("failed to write import report: %v", err)
+	}
+	if report.InvalidRows > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,74 @@
+package rest
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/middleware"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/service"
+	"net/http"
+)
+
+type CapacityHandler interface {
+	GetCapacityRules(c *gin.Context)
+	UpdateCapacityRules(c *gin.Context)
+	GetReceptionCapacity(c *gin.Context)
+}
+
+type capacityHandlerImpl struct {
+	capacityService service.CapacityService
+}
+
+func NewCapacityHandler(capacityService service.CapacityService) CapacityHandler {
+	return &capacityHandlerImpl{capacityService}
+}
+
+func (ch *capacityHandlerImpl) GetCapacityRules(c *gin.Context) {
+	pvzID := c.Param("pvzId")
+	rules, err := ch.capacityService.GetCapacityRules(c.Request.Context(), pvzID)
+	if err != nil {
+		c.JSON(middleware.ErrorStatus(err, capacityErrorStatus(err)), middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, rules)
+}
+
+func (ch *capacityHandlerImpl) UpdateCapacityRules(c *gin.Context) {
+	role, _ := c.Get("role")
+	var rules model.PVZCapacityRules
+	if err := c.BindJSON(&rules); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	rules.PVZID = c.Param("pvzId")
+	updatedRules, err := ch.capacityService.UpdateCapacityRules(c.Request.Context(), &rules, role.(string))
+	if err != nil {
+		c.JSON(middleware.ErrorStatus(err, capacityErrorStatus(err)), middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, updatedRules)
+}
+
+func (ch *capacityHandlerImpl) GetReceptionCapacity(c *gin.Context) {
+	pvzID := c.Param("pvzId")
+	capacity, err := ch.capacityService.GetReceptionCapacity(c.Request.Context(), pvzID)
+	if err != nil {
+		c.JSON(middleware.ErrorStatus(err, capacityErrorStatus(err)), middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, capacity)
+}
+
+func capacityErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, enum.ErrNoModeratorRights):
+		return http.StatusForbidden
+	case errors.Is(err, enum.ErrPVZNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, enum.ErrInvalidCapacityRules), errors.Is(err, enum.ErrNoOpenReceptionsToAdd):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
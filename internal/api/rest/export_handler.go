@@ -0,0 +1,78 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/export"
+	"github.com/ners1us/order-service/internal/middleware"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/service"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type ExportHandler interface {
+	ExportReceptions(c *gin.Context)
+}
+
+type exportHandlerImpl struct {
+	exportService service.ExportService
+}
+
+func NewExportHandler(exportService service.ExportService) ExportHandler {
+	return &exportHandlerImpl{exportService}
+}
+
+func (eh *exportHandlerImpl) ExportReceptions(c *gin.Context) {
+	role, _ := c.Get("role")
+	filter := model.ExportFilter{
+		Format: c.DefaultQuery("format", enum.ExportFormatCSV.String()),
+		PVZIDs: c.QueryArray("pvzId"),
+	}
+	var err error
+	if filter.IncludeDeleted, err = includeDeletedQuery(c); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	if filter.StartDate, err = time.Parse(time.RFC3339, c.Query("startDate")); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, enum.ErrInvalidStartDate.Error()))
+		return
+	}
+	if filter.EndDate, err = time.Parse(time.RFC3339, c.Query("endDate")); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, enum.ErrInvalidEndDate.Error()))
+		return
+	}
+
+	format := enum.ExportFormat(filter.Format)
+	fileName := fmt.Sprintf("receptions_%s_%s.%s", filter.StartDate.Format("20060102"), filter.EndDate.Format("20060102"), format)
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+
+	err = eh.exportService.ExportReceptions(c.Request.Context(), &filter, role.(string), c.Writer)
+	if err == nil {
+		return
+	}
+	if c.Writer.Written() {
+		// The body is already partially streamed, so the status can no longer change.
+		slog.ErrorContext(c.Request.Context(), "reception export interrupted", slog.Any("error", err))
+		return
+	}
+	c.Writer.Header().Del("Content-Disposition")
+	c.Writer.Header().Del("Content-Type")
+	c.JSON(middleware.ErrorStatus(err, exportErrorStatus(err)), middleware.ErrorResponse(c, err.Error()))
+}
+
+func exportErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, enum.ErrNoModeratorRights):
+		return http.StatusForbidden
+	case errors.Is(err, enum.ErrInvalidExportFormat), errors.Is(err, enum.ErrInvalidStartDate),
+		errors.Is(err, enum.ErrInvalidEndDate), errors.Is(err, enum.ErrInvalidDateRange), errors.Is(err, enum.ErrInvalidPVZID):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
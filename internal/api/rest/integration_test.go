@@ -2,29 +2,29 @@ package rest
 
 import (
 	"context"
-	"database/sql"
-	"errors"
 	"fmt"
-	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ners1us/order-service/internal/database"
 	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
 	"github.com/ners1us/order-service/internal/model"
 	"github.com/ners1us/order-service/internal/repository"
 	"github.com/ners1us/order-service/internal/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
+	"io"
 	"log"
+	"log/slog"
 	"os"
-	"path/filepath"
 	"testing"
 	"time"
 )
 
 var container testcontainers.Container
-var db *sql.DB
+var db *pgxpool.Pool
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
 
 func TestMain(m *testing.M) {
 	ctx := context.Background()
@@ -61,21 +61,18 @@ func TestMain(m *testing.M) {
 	connStr := fmt.Sprintf("host=%s port=%s user=testuser password=testpassword dbname=test-db sslmode=disable",
 		host, mappedPort.Port())
 
-	db, err = sql.Open("postgres", connStr)
+	db, err = pgxpool.New(ctx, connStr)
 	if err != nil {
 		log.Fatalf("failed to connect to database: %s", err)
 	}
 
-	err = applyMigrations(connStr)
-	if err != nil {
+	if err := database.MigrateUp(ctx, db); err != nil {
 		log.Fatalf("failed to apply migrations: %s", err)
 	}
 
 	code := m.Run()
 
-	if err := db.Close(); err != nil {
-		log.Fatalf("failed to close database connection: %s", err)
-	}
+	db.Close()
 
 	if err := container.Terminate(ctx); err != nil {
 		log.Fatalf("failed to terminate container: %s", err)
@@ -84,65 +81,33 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
-func applyMigrations(connStr string) error {
-	migrationDB, err := sql.Open("postgres", connStr)
-	if err != nil {
-		return fmt.Errorf("failed to open database connection for migrations: %w", err)
-	}
-	defer migrationDB.Close()
-
-	driver, err := postgres.WithInstance(migrationDB, &postgres.Config{})
-	if err != nil {
-		return fmt.Errorf("failed to create postgres driver: %w", err)
-	}
-
-	migrationsPath, err := filepath.Abs("../../../migrations")
-	if err != nil {
-		return fmt.Errorf("failed to get migrations path: %w", err)
-	}
-
-	m, err := migrate.NewWithDatabaseInstance(
-		"file://"+migrationsPath,
-		"postgres",
-		driver,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create migrate instance: %w", err)
-	}
-
-	err = m.Up()
-	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
-		return fmt.Errorf("failed to apply migrations: %w", err)
-	}
-
-	return nil
-}
-
 func TestPVZReceptionProductFlow_Integration(t *testing.T) {
-	pvzRepo := repository.NewPVZRepository(db)
-	receptionRepo := repository.NewReceptionRepository(db)
-	productRepo := repository.NewProductRepository(db)
+	pvzRepo := repository.NewPVZRepository(db, db)
+	receptionRepo := repository.NewReceptionRepository(db, db)
+	productRepo := repository.NewProductRepository(db, db)
+	capacityRuleRepo := repository.NewCapacityRuleRepository(db)
 
-	pvzService := service.NewPVZService(pvzRepo, receptionRepo, productRepo)
-	receptionService := service.NewReceptionService(receptionRepo, pvzRepo)
-	productService := service.NewProductService(receptionRepo, productRepo)
+	pvzService := service.NewPVZService(pvzRepo, receptionRepo, productRepo, event.NewBus(), discardLogger)
+	receptionService := service.NewReceptionService(receptionRepo, pvzRepo, productRepo, event.NewBus(), discardLogger)
+	productService := service.NewProductService(receptionRepo, productRepo, capacityRuleRepo, pvzRepo, event.NewBus(), discardLogger)
 
 	moderatorRole := enum.RoleModerator.String()
 	employeeRole := enum.RoleEmployee.String()
+	employeeID := uuid.NewString()
 	pvz := &model.PVZ{
 		ID:               uuid.New().String(),
 		RegistrationDate: time.Now(),
 		City:             enum.CityMoscow.String(),
 	}
 
-	createdPVZ, err := pvzService.CreatePVZ(pvz, moderatorRole)
+	createdPVZ, err := pvzService.CreatePVZ(context.Background(), pvz, moderatorRole)
 	if err != nil {
 		t.Fatalf("failed to create pvz: %v", err)
 	}
 	assert.Equal(t, pvz.ID, createdPVZ.ID)
 	assert.Equal(t, pvz.City, createdPVZ.City)
 
-	reception, err := receptionService.CreateReception(pvz.ID, employeeRole)
+	reception, err := receptionService.CreateReception(context.Background(), pvz.ID, employeeID, employeeRole)
 	if err != nil {
 		t.Fatalf("failed to create reception: %v", err)
 	}
@@ -154,7 +119,7 @@ func TestPVZReceptionProductFlow_Integration(t *testing.T) {
 		product := &model.Product{
 			Type: enum.ProductElectronics.String(),
 		}
-		createdProduct, err := productService.AddProduct(product, pvz.ID, employeeRole)
+		createdProduct, err := productService.AddProduct(context.Background(), product, pvz.ID, employeeID, employeeRole)
 		if err != nil {
 			t.Fatalf("failed to add product #%d: %v", i+1, err)
 		}
@@ -165,16 +130,207 @@ func TestPVZReceptionProductFlow_Integration(t *testing.T) {
 
 	query := "SELECT COUNT(*) FROM products WHERE reception_id = $1"
 	var count int
-	err = db.QueryRow(query, reception.ID).Scan(&count)
+	err = db.QueryRow(context.Background(), query, reception.ID).Scan(&count)
 	if err != nil {
 		t.Fatalf("failed to count products: %v", err)
 	}
 	assert.Equal(t, 50, count)
 
-	closedReception, err := receptionService.CloseLastReception(pvz.ID, employeeRole)
+	closedReception, err := receptionService.CloseLastReception(context.Background(), pvz.ID, employeeID, employeeRole)
 	if err != nil {
 		t.Fatalf("failed to close reception: %v", err)
 	}
 	assert.Equal(t, reception.ID, closedReception.ID)
 	assert.Equal(t, enum.StatusClosed.String(), closedReception.Status)
 }
+
+func TestNearbyPVZs_Integration(t *testing.T) {
+	pvzRepo := repository.NewPVZRepository(db, db)
+	receptionRepo := repository.NewReceptionRepository(db, db)
+	productRepo := repository.NewProductRepository(db, db)
+
+	pvzService := service.NewPVZService(pvzRepo, receptionRepo, productRepo, event.NewBus(), discardLogger)
+
+	moderatorRole := enum.RoleModerator.String()
+	newPVZ := func(latitude, longitude float64) *model.PVZ {
+		pvz := &model.PVZ{
+			ID:               uuid.New().String(),
+			RegistrationDate: time.Now(),
+			City:             enum.CityKazan.String(),
+			Latitude:         &latitude,
+			Longitude:        &longitude,
+		}
+		if _, err := pvzService.CreatePVZ(context.Background(), pvz, moderatorRole); err != nil {
+			t.Fatalf("failed to create pvz: %v", err)
+		}
+		return pvz
+	}
+
+	near := newPVZ(55.7963, 49.1088)
+	nearest := newPVZ(55.7900, 49.1150)
+	suspended := newPVZ(55.7910, 49.1140)
+	far := newPVZ(55.8600, 49.2300)
+
+	status := enum.PVZStatusSuspended.String()
+	if _, err := pvzService.UpdatePVZ(context.Background(), suspended.ID, &model.PVZUpdate{Status: &status}, moderatorRole); err != nil {
+		t.Fatalf("failed to suspend pvz: %v", err)
+	}
+
+	nearbyPVZs, err := pvzService.GetNearbyPVZs(context.Background(), 55.7887, 49.1221, 3, enum.CityKazan.String(), 10)
+	if err != nil {
+		t.Fatalf("failed to get nearby pvzs: %v", err)
+	}
+
+	ids := make([]string, len(nearbyPVZs))
+	for i, nearbyPVZ := range nearbyPVZs {
+		ids[i] = nearbyPVZ.PVZ.ID
+	}
+	assert.Equal(t, []string{nearest.ID, near.ID}, ids)
+	assert.NotContains(t, ids, far.ID)
+	assert.Less(t, nearbyPVZs[0].Distance, nearbyPVZs[1].Distance)
+	assert.InDelta(t, 0.47, nearbyPVZs[0].Distance, 0.05)
+}
+
+func TestReceptionReopenFlow_Integration(t *testing.T) {
+	pvzRepo := repository.NewPVZRepository(db, db)
+	receptionRepo := repository.NewReceptionRepository(db, db)
+	productRepo := repository.NewProductRepository(db, db)
+	capacityRuleRepo := repository.NewCapacityRuleRepository(db)
+	reopenRequestRepo := repository.NewReopenRequestRepository(db)
+
+	pvzService := service.NewPVZService(pvzRepo, receptionRepo, productRepo, event.NewBus(), discardLogger)
+	receptionService := service.NewReceptionService(receptionRepo, pvzRepo, productRepo, event.NewBus(), discardLogger)
+	productService := service.NewProductService(receptionRepo, productRepo, capacityRuleRepo, pvzRepo, event.NewBus(), discardLogger)
+	reopenRequestService := service.NewReopenRequestService(reopenRequestRepo, receptionRepo, pvzRepo, event.NewBus(), discardLogger)
+
+	moderatorRole := enum.RoleModerator.String()
+	employeeRole := enum.RoleEmployee.String()
+	employeeID := uuid.NewString()
+	pvz := &model.PVZ{
+		ID:               uuid.New().String(),
+		RegistrationDate: time.Now(),
+		City:             enum.CitySaintPetersburg.String(),
+	}
+	if _, err := pvzService.CreatePVZ(context.Background(), pvz, moderatorRole); err != nil {
+		t.Fatalf("failed to create pvz: %v", err)
+	}
+
+	first, err := receptionService.CreateReception(context.Background(), pvz.ID, employeeID, employeeRole)
+	if err != nil {
+		t.Fatalf("failed to create first reception: %v", err)
+	}
+	if _, err := receptionService.CloseLastReception(context.Background(), pvz.ID, employeeID, employeeRole); err != nil {
+		t.Fatalf("failed to close first reception: %v", err)
+	}
+	if _, err := receptionService.CreateReception(context.Background(), pvz.ID, employeeID, employeeRole); err != nil {
+		t.Fatalf("failed to create second reception: %v", err)
+	}
+
+	request, err := reopenRequestService.RequestReopen(context.Background(), first.ID, "forgot two boxes", "employee_1", employeeRole)
+	if err != nil {
+		t.Fatalf("failed to request reopen: %v", err)
+	}
+
+	_, err = reopenRequestService.ApproveReopen(context.Background(), request.ID, "", "moderator_1", moderatorRole)
+	assert.Equal(t, enum.ErrOpenReception, err)
+
+	if _, err := receptionService.CloseLastReception(context.Background(), pvz.ID, employeeID, employeeRole); err != nil {
+		t.Fatalf("failed to close second reception: %v", err)
+	}
+	approved, err := reopenRequestService.ApproveReopen(context.Background(), request.ID, "ok", "moderator_1", moderatorRole)
+	if err != nil {
+		t.Fatalf("failed to approve reopen: %v", err)
+	}
+	assert.Equal(t, enum.ReopenRequestApproved.String(), approved.Status)
+
+	product, err := productService.AddProduct(context.Background(), &model.Product{Type: enum.ProductClothes.String()}, pvz.ID, employeeID, employeeRole)
+	if err != nil {
+		t.Fatalf("failed to add product to reopened reception: %v", err)
+	}
+	assert.Equal(t, first.ID, product.ReceptionID)
+
+	_, err = receptionService.CreateReception(context.Background(), pvz.ID, employeeID, employeeRole)
+	assert.Equal(t, enum.ErrOpenReception, err)
+
+	closed, err := receptionService.CloseLastReception(context.Background(), pvz.ID, employeeID, employeeRole)
+	if err != nil {
+		t.Fatalf("failed to close reopened reception: %v", err)
+	}
+	assert.Equal(t, first.ID, closed.ID)
+
+	history, err := reopenRequestService.GetReceptionReopenHistory(context.Background(), first.ID)
+	if err != nil {
+		t.Fatalf("failed to get reopen history: %v", err)
+	}
+	assert.Len(t, history, 1)
+	assert.Equal(t, "forgot two boxes", history[0].Reason)
+	assert.Equal(t, "moderator_1", history[0].ReviewedBy)
+}
+
+func TestReport_Integration(t *testing.T) {
+	pvzRepo := repository.NewPVZRepository(db, db)
+	receptionRepo := repository.NewReceptionRepository(db, db)
+	productRepo := repository.NewProductRepository(db, db)
+	capacityRuleRepo := repository.NewCapacityRuleRepository(db)
+	reportRepo := repository.NewReportRepository(db)
+
+	pvzService := service.NewPVZService(pvzRepo, receptionRepo, productRepo, event.NewBus(), discardLogger)
+	receptionService := service.NewReceptionService(receptionRepo, pvzRepo, productRepo, event.NewBus(), discardLogger)
+	productService := service.NewProductService(receptionRepo, productRepo, capacityRuleRepo, pvzRepo, event.NewBus(), discardLogger)
+	reportService := service.NewReportService(reportRepo)
+
+	employeeRole := enum.RoleEmployee.String()
+	employeeID := uuid.NewString()
+	pvz := &model.PVZ{
+		ID:               uuid.New().String(),
+		RegistrationDate: time.Now(),
+		City:             enum.CityKazan.String(),
+	}
+	if _, err := pvzService.CreatePVZ(context.Background(), pvz, enum.RoleModerator.String()); err != nil {
+		t.Fatalf("failed to create pvz: %v", err)
+	}
+	if _, err := receptionService.CreateReception(context.Background(), pvz.ID, employeeID, employeeRole); err != nil {
+		t.Fatalf("failed to create reception: %v", err)
+	}
+	productTypes := []string{enum.ProductClothes.String(), enum.ProductClothes.String(), enum.ProductShoes.String()}
+	for _, productType := range productTypes {
+		if _, err := productService.AddProduct(context.Background(), &model.Product{Type: productType}, pvz.ID, employeeID, employeeRole); err != nil {
+			t.Fatalf("failed to add product: %v", err)
+		}
+	}
+	if _, err := receptionService.CloseLastReception(context.Background(), pvz.ID, employeeID, employeeRole); err != nil {
+		t.Fatalf("failed to close reception: %v", err)
+	}
+
+	report, err := reportService.GetReport(context.Background(), &model.ReportFilter{
+		Period:    enum.ReportPeriodMonth.String(),
+		GroupBy:   enum.ReportGroupPVZ.String(),
+		StartDate: time.Now().Add(-time.Hour),
+		EndDate:   time.Now().Add(time.Hour),
+	}, employeeRole)
+	if err != nil {
+		t.Fatalf("failed to get report: %v", err)
+	}
+
+	var opened, closed int
+	productsByType := map[string]int{}
+	for _, row := range report.Rows {
+		if row.Group != pvz.ID {
+			continue
+		}
+		opened += row.ReceptionsOpened
+		closed += row.ReceptionsClosed
+		for productType, count := range row.ProductsByType {
+			productsByType[productType] += count
+		}
+		if row.ReceptionsOpened > 0 {
+			assert.Equal(t, 3.0, row.AvgProductsPerReception)
+		}
+		if row.ReceptionsClosed > 0 {
+			assert.NotNil(t, row.AvgReceptionDurationSeconds)
+		}
+	}
+	assert.Equal(t, 1, opened)
+	assert.Equal(t, 1, closed)
+	assert.Equal(t, map[string]int{enum.ProductClothes.String(): 2, enum.ProductShoes.String(): 1}, productsByType)
+}
@@ -4,7 +4,7 @@ import (
 	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/ners1us/order-service/internal/enum"
-	"github.com/ners1us/order-service/internal/metric"
+	"github.com/ners1us/order-service/internal/middleware"
 	"github.com/ners1us/order-service/internal/model"
 	"github.com/ners1us/order-service/internal/service"
 	"net/http"
@@ -13,6 +13,7 @@ import (
 type ProductHandler interface {
 	AddProduct(c *gin.Context)
 	DeleteLastProduct(c *gin.Context)
+	DeleteProduct(c *gin.Context)
 }
 
 type productHandlerImpl struct {
@@ -25,40 +26,58 @@ func NewProductHandler(productService service.ProductService) ProductHandler {
 
 func (ph *productHandlerImpl) AddProduct(c *gin.Context) {
 	role, _ := c.Get("role")
+	userID, _ := c.Get("userID")
 	var req struct {
 		Type  string `json:"type"`
 		PVZID string `json:"pvzId"`
 	}
 	if err := c.BindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, err.Error()))
 		return
 	}
 	product := model.Product{Type: req.Type}
-	createdProduct, err := ph.productService.AddProduct(&product, req.PVZID, role.(string))
+	createdProduct, err := ph.productService.AddProduct(c.Request.Context(), &product, req.PVZID, userID.(string), role.(string))
 	if err != nil {
 		status := http.StatusBadRequest
 		if errors.Is(err, enum.ErrNoEmployeeRights) {
 			status = http.StatusForbidden
 		}
-		c.JSON(status, gin.H{"error": err.Error()})
+		c.JSON(middleware.ErrorStatus(err, status), middleware.ErrorResponse(c, err.Error()))
 		return
 	}
 
-	metric.ProductsAdded.Inc()
-
 	c.JSON(http.StatusCreated, createdProduct)
 }
 
 func (ph *productHandlerImpl) DeleteLastProduct(c *gin.Context) {
 	pvzID := c.Param("pvzId")
 	role, _ := c.Get("role")
-	if err := ph.productService.DeleteLastProduct(pvzID, role.(string)); err != nil {
+	userID, _ := c.Get("userID")
+	if err := ph.productService.DeleteLastProduct(c.Request.Context(), pvzID, userID.(string), role.(string)); err != nil {
 		status := http.StatusBadRequest
 		if errors.Is(err, enum.ErrNoEmployeeRights) {
 			status = http.StatusForbidden
 		}
-		c.JSON(status, gin.H{"error": err.Error()})
+		c.JSON(middleware.ErrorStatus(err, status), middleware.ErrorResponse(c, err.Error()))
 		return
 	}
 	c.Status(http.StatusOK)
 }
+
+func (ph *productHandlerImpl) DeleteProduct(c *gin.Context) {
+	productID := c.Param("productId")
+	role, _ := c.Get("role")
+	userID, _ := c.Get("userID")
+	deletion, err := ph.productService.DeleteProduct(c.Request.Context(), productID, c.Query("reason"), userID.(string), role.(string))
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, enum.ErrNoEmployeeRights) {
+			status = http.StatusForbidden
+		} else if errors.Is(err, enum.ErrProductNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(middleware.ErrorStatus(err, status), middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, deletion)
+}
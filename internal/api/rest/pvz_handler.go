@@ -4,7 +4,7 @@ import (
 	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/ners1us/order-service/internal/enum"
-	"github.com/ners1us/order-service/internal/metric"
+	"github.com/ners1us/order-service/internal/middleware"
 	"github.com/ners1us/order-service/internal/model"
 	"github.com/ners1us/order-service/internal/service"
 	"net/http"
@@ -15,6 +15,10 @@ import (
 type PVZHandler interface {
 	CreatePVZ(c *gin.Context)
 	GetPVZList(c *gin.Context)
+	GetPVZ(c *gin.Context)
+	UpdatePVZ(c *gin.Context)
+	DecommissionPVZ(c *gin.Context)
+	GetNearbyPVZs(c *gin.Context)
 }
 
 type pvzHandlerImpl struct {
@@ -29,21 +33,19 @@ func (ph *pvzHandlerImpl) CreatePVZ(c *gin.Context) {
 	role, _ := c.Get("role")
 	var pvz model.PVZ
 	if err := c.BindJSON(&pvz); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, err.Error()))
 		return
 	}
-	createdPVZ, err := ph.pvzService.CreatePVZ(&pvz, role.(string))
+	createdPVZ, err := ph.pvzService.CreatePVZ(c.Request.Context(), &pvz, role.(string))
 	if err != nil {
 		status := http.StatusBadRequest
 		if errors.Is(err, enum.ErrNoModeratorRights) {
 			status = http.StatusForbidden
 		}
-		c.JSON(status, gin.H{"error": err.Error()})
+		c.JSON(middleware.ErrorStatus(err, status), middleware.ErrorResponse(c, err.Error()))
 		return
 	}
 
-	metric.PVZCreated.Inc()
-
 	c.JSON(http.StatusCreated, createdPVZ)
 }
 
@@ -58,14 +60,14 @@ func (ph *pvzHandlerImpl) GetPVZList(c *gin.Context) {
 	if startDateStr != "" {
 		startDate, err = time.Parse(time.RFC3339, startDateStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": enum.ErrInvalidStartDate.Error()})
+			c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, enum.ErrInvalidStartDate.Error()))
 			return
 		}
 	}
 	if endDateStr != "" {
 		endDate, err = time.Parse(time.RFC3339, endDateStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": enum.ErrInvalidEndDate.Error()})
+			c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, enum.ErrInvalidEndDate.Error()))
 			return
 		}
 	}
@@ -78,10 +80,128 @@ func (ph *pvzHandlerImpl) GetPVZList(c *gin.Context) {
 		limit = 10
 	}
 
-	pvzList, err := ph.pvzService.GetPVZList(startDate, endDate, page, limit)
+	includeDeleted, err := includeDeletedQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	role, _ := c.Get("role")
+
+	pvzList, err := ph.pvzService.GetPVZList(c.Request.Context(), startDate, endDate, page, limit, includeDeleted, role.(string))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, enum.ErrNoModeratorRights) {
+			status = http.StatusForbidden
+		}
+		c.JSON(middleware.ErrorStatus(err, status), middleware.ErrorResponse(c, err.Error()))
 		return
 	}
 	c.JSON(http.StatusOK, pvzList)
 }
+
+func (ph *pvzHandlerImpl) GetPVZ(c *gin.Context) {
+	pvzID := c.Param("pvzId")
+	pvz, err := ph.pvzService.GetPVZByID(c.Request.Context(), pvzID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, enum.ErrPVZNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(middleware.ErrorStatus(err, status), middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, pvz)
+}
+
+func (ph *pvzHandlerImpl) UpdatePVZ(c *gin.Context) {
+	pvzID := c.Param("pvzId")
+	role, _ := c.Get("role")
+	var update model.PVZUpdate
+	if err := c.BindJSON(&update); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	pvz, err := ph.pvzService.UpdatePVZ(c.Request.Context(), pvzID, &update, role.(string))
+	if err != nil {
+		c.JSON(middleware.ErrorStatus(err, pvzErrorStatus(err)), middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, pvz)
+}
+
+func (ph *pvzHandlerImpl) DecommissionPVZ(c *gin.Context) {
+	pvzID := c.Param("pvzId")
+	role, _ := c.Get("role")
+	pvz, err := ph.pvzService.DecommissionPVZ(c.Request.Context(), pvzID, role.(string))
+	if err != nil {
+		c.JSON(middleware.ErrorStatus(err, pvzErrorStatus(err)), middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, pvz)
+}
+
+func (ph *pvzHandlerImpl) GetNearbyPVZs(c *gin.Context) {
+	latitude, latErr := strconv.ParseFloat(c.Query("lat"), 64)
+	longitude, lonErr := strconv.ParseFloat(c.Query("lon"), 64)
+	if latErr != nil || lonErr != nil {
+		c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, enum.ErrInvalidCoordinates.Error()))
+		return
+	}
+	var radius float64
+	if radiusStr := c.Query("radius"); radiusStr != "" {
+		var err error
+		radius, err = strconv.ParseFloat(radiusStr, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, enum.ErrInvalidRadius.Error()))
+			return
+		}
+	}
+	var limit int
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, enum.ErrInvalidLimit.Error()))
+			return
+		}
+	}
+
+	nearbyPVZs, err := ph.pvzService.GetNearbyPVZs(c.Request.Context(), latitude, longitude, radius, c.Query("city"), limit)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, enum.ErrInvalidCoordinates) || errors.Is(err, enum.ErrInvalidRadius) || errors.Is(err, enum.ErrInvalidCity) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(middleware.ErrorStatus(err, status), middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	if nearbyPVZs == nil {
+		nearbyPVZs = []model.NearbyPVZ{}
+	}
+	c.JSON(http.StatusOK, nearbyPVZs)
+}
+
+func pvzErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, enum.ErrNoModeratorRights):
+		return http.StatusForbidden
+	case errors.Is(err, enum.ErrPVZNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// includeDeletedQuery parses the includeDeleted query parameter, which is
+// false when absent.
+func includeDeletedQuery(c *gin.Context) (bool, error) {
+	value := c.Query("includeDeleted")
+	if value == "" {
+		return false, nil
+	}
+	includeDeleted, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, enum.ErrInvalidIncludeDeleted
+	}
+	return includeDeleted, nil
+}
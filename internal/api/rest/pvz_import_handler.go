@@ -0,0 +1,72 @@
+package rest
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/middleware"
+	"github.com/ners1us/order-service/internal/service"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const maxImportFileSize = 10 << 20
+
+type PVZImportHandler interface {
+	ImportPVZs(c *gin.Context)
+}
+
+type pvzImportHandlerImpl struct {
+	pvzImportService service.PVZImportService
+}
+
+func NewPVZImportHandler(pvzImportService service.PVZImportService) PVZImportHandler {
+	return &pvzImportHandlerImpl{pvzImportService}
+}
+
+// ImportPVZs accepts the CSV either as a multipart "file" field or as the raw request body.
+func (pih *pvzImportHandlerImpl) ImportPVZs(c *gin.Context) {
+	role, _ := c.Get("role")
+	dryRun, _ := strconv.ParseBool(c.Query("dryRun"))
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)
+
+	var body io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, enum.ErrInvalidImportFile.Error()))
+			return
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, enum.ErrInvalidImportFile.Error()))
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	report, err := pih.pvzImportService.ImportPVZs(c.Request.Context(), body, dryRun, role.(string))
+	if err != nil {
+		c.JSON(middleware.ErrorStatus(err, pvzImportErrorStatus(err)), middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func pvzImportErrorStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, enum.ErrNoModeratorRights):
+		return http.StatusForbidden
+	case errors.As(err, &maxBytesErr), errors.Is(err, enum.ErrImportTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, enum.ErrInvalidImportFile):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
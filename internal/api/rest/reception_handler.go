@@ -2,18 +2,26 @@ package rest
 
 import (
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/ners1us/order-service/internal/enum"
-	"github.com/ners1us/order-service/internal/metric"
+	"github.com/ners1us/order-service/internal/export"
+	"github.com/ners1us/order-service/internal/middleware"
 	"github.com/ners1us/order-service/internal/service"
+	"log/slog"
 	"net/http"
 )
 
 type ReceptionHandler interface {
 	CreateReception(c *gin.Context)
 	CloseLastReception(c *gin.Context)
+	GetReceptionTimeline(c *gin.Context)
 }
 
+// timelineFormatJSON is the default format of reception timelines; the export
+// formats are accepted as well.
+const timelineFormatJSON = "json"
+
 type receptionHandlerImpl struct {
 	receptionService service.ReceptionService
 }
@@ -24,39 +32,81 @@ func NewReceptionHandler(receptionService service.ReceptionService) ReceptionHan
 
 func (rh *receptionHandlerImpl) CreateReception(c *gin.Context) {
 	role, _ := c.Get("role")
+	userID, _ := c.Get("userID")
 	var req struct {
 		PVZID string `json:"pvzId"`
 	}
 	if err := c.BindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, err.Error()))
 		return
 	}
-	reception, err := rh.receptionService.CreateReception(req.PVZID, role.(string))
+	reception, err := rh.receptionService.CreateReception(c.Request.Context(), req.PVZID, userID.(string), role.(string))
 	if err != nil {
 		status := http.StatusBadRequest
 		if errors.Is(err, enum.ErrNoEmployeeRights) {
 			status = http.StatusForbidden
 		}
-		c.JSON(status, gin.H{"error": err.Error()})
+		c.JSON(middleware.ErrorStatus(err, status), middleware.ErrorResponse(c, err.Error()))
 		return
 	}
 
-	metric.ReceptionsCreated.Inc()
-
 	c.JSON(http.StatusCreated, reception)
 }
 
 func (rh *receptionHandlerImpl) CloseLastReception(c *gin.Context) {
 	pvzID := c.Param("pvzId")
 	role, _ := c.Get("role")
-	reception, err := rh.receptionService.CloseLastReception(pvzID, role.(string))
+	userID, _ := c.Get("userID")
+	reception, err := rh.receptionService.CloseLastReception(c.Request.Context(), pvzID, userID.(string), role.(string))
 	if err != nil {
 		status := http.StatusBadRequest
 		if errors.Is(err, enum.ErrNoEmployeeRights) {
 			status = http.StatusForbidden
 		}
-		c.JSON(status, gin.H{"error": err.Error()})
+		c.JSON(middleware.ErrorStatus(err, status), middleware.ErrorResponse(c, err.Error()))
 		return
 	}
 	c.JSON(http.StatusOK, reception)
 }
+
+func (rh *receptionHandlerImpl) GetReceptionTimeline(c *gin.Context) {
+	receptionID := c.Param("receptionId")
+	format := c.DefaultQuery("format", timelineFormatJSON)
+	if format == timelineFormatJSON {
+		timeline, err := rh.receptionService.GetReceptionTimeline(c.Request.Context(), receptionID)
+		if err != nil {
+			c.JSON(middleware.ErrorStatus(err, timelineErrorStatus(err)), middleware.ErrorResponse(c, err.Error()))
+			return
+		}
+		c.JSON(http.StatusOK, timeline)
+		return
+	}
+
+	exportFormat := enum.ExportFormat(format)
+	fileName := fmt.Sprintf("reception_%s_timeline.%s", receptionID, exportFormat)
+	c.Header("Content-Type", export.ContentType(exportFormat))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+
+	err := rh.receptionService.ExportReceptionTimeline(c.Request.Context(), receptionID, format, c.Writer)
+	if err == nil {
+		return
+	}
+	if c.Writer.Written() {
+		slog.ErrorContext(c.Request.Context(), "reception timeline export interrupted", slog.Any("error", err))
+		return
+	}
+	c.Writer.Header().Del("Content-Disposition")
+	c.Writer.Header().Del("Content-Type")
+	c.JSON(middleware.ErrorStatus(err, timelineErrorStatus(err)), middleware.ErrorResponse(c, err.Error()))
+}
+
+func timelineErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, enum.ErrReceptionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, enum.ErrInvalidExportFormat):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
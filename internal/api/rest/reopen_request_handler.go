@@ -0,0 +1,121 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/middleware"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/service"
+	"io"
+	"net/http"
+)
+
+type ReopenRequestHandler interface {
+	RequestReopen(c *gin.Context)
+	GetReceptionReopenHistory(c *gin.Context)
+	GetReopenRequests(c *gin.Context)
+	ApproveReopen(c *gin.Context)
+	RejectReopen(c *gin.Context)
+}
+
+type reopenRequestHandlerImpl struct {
+	reopenRequestService service.ReopenRequestService
+}
+
+func NewReopenRequestHandler(reopenRequestService service.ReopenRequestService) ReopenRequestHandler {
+	return &reopenRequestHandlerImpl{reopenRequestService}
+}
+
+func (rrh *reopenRequestHandlerImpl) RequestReopen(c *gin.Context) {
+	receptionID := c.Param("receptionId")
+	role, _ := c.Get("role")
+	userID, _ := c.Get("userID")
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	request, err := rrh.reopenRequestService.RequestReopen(c.Request.Context(), receptionID, req.Reason, userID.(string), role.(string))
+	if err != nil {
+		c.JSON(middleware.ErrorStatus(err, reopenErrorStatus(err)), middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	c.JSON(http.StatusCreated, request)
+}
+
+func (rrh *reopenRequestHandlerImpl) GetReceptionReopenHistory(c *gin.Context) {
+	receptionID := c.Param("receptionId")
+	requests, err := rrh.reopenRequestService.GetReceptionReopenHistory(c.Request.Context(), receptionID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, enum.ErrReceptionNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(middleware.ErrorStatus(err, status), middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	if requests == nil {
+		requests = []model.ReopenRequest{}
+	}
+	c.JSON(http.StatusOK, requests)
+}
+
+func (rrh *reopenRequestHandlerImpl) GetReopenRequests(c *gin.Context) {
+	role, _ := c.Get("role")
+	requests, err := rrh.reopenRequestService.GetReopenRequests(c.Request.Context(), c.Query("status"), role.(string))
+	if err != nil {
+		c.JSON(middleware.ErrorStatus(err, reopenErrorStatus(err)), middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	if requests == nil {
+		requests = []model.ReopenRequest{}
+	}
+	c.JSON(http.StatusOK, requests)
+}
+
+func (rrh *reopenRequestHandlerImpl) ApproveReopen(c *gin.Context) {
+	rrh.resolve(c, rrh.reopenRequestService.ApproveReopen)
+}
+
+func (rrh *reopenRequestHandlerImpl) RejectReopen(c *gin.Context) {
+	rrh.resolve(c, rrh.reopenRequestService.RejectReopen)
+}
+
+func (rrh *reopenRequestHandlerImpl) resolve(
+	c *gin.Context,
+	resolveFunc func(ctx context.Context, requestID string, comment string, userID string, userRole string) (*model.ReopenRequest, error),
+) {
+	requestID := c.Param("requestId")
+	role, _ := c.Get("role")
+	userID, _ := c.Get("userID")
+	var req struct {
+		Comment string `json:"comment"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	request, err := resolveFunc(c.Request.Context(), requestID, req.Comment, userID.(string), role.(string))
+	if err != nil {
+		c.JSON(middleware.ErrorStatus(err, reopenErrorStatus(err)), middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, request)
+}
+
+func reopenErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, enum.ErrNoEmployeeRights), errors.Is(err, enum.ErrNoModeratorRights):
+		return http.StatusForbidden
+	case errors.Is(err, enum.ErrReceptionNotFound), errors.Is(err, enum.ErrReopenRequestNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, enum.ErrReopenRequestResolved), errors.Is(err, enum.ErrOpenReception):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,69 @@
+package rest
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/middleware"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/service"
+	"net/http"
+	"time"
+)
+
+type ReportHandler interface {
+	GetReport(c *gin.Context)
+}
+
+type reportHandlerImpl struct {
+	reportService service.ReportService
+}
+
+func NewReportHandler(reportService service.ReportService) ReportHandler {
+	return &reportHandlerImpl{reportService}
+}
+
+func (rh *reportHandlerImpl) GetReport(c *gin.Context) {
+	role, _ := c.Get("role")
+	filter := model.ReportFilter{
+		Period:  c.Query("period"),
+		GroupBy: c.Query("groupBy"),
+	}
+	var err error
+	if filter.IncludeDeleted, err = includeDeletedQuery(c); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	if startDateStr := c.Query("startDate"); startDateStr != "" {
+		filter.StartDate, err = time.Parse(time.RFC3339, startDateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, enum.ErrInvalidStartDate.Error()))
+			return
+		}
+	}
+	if endDateStr := c.Query("endDate"); endDateStr != "" {
+		filter.EndDate, err = time.Parse(time.RFC3339, endDateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, enum.ErrInvalidEndDate.Error()))
+			return
+		}
+	}
+
+	report, err := rh.reportService.GetReport(c.Request.Context(), &filter, role.(string))
+	if err != nil {
+		c.JSON(middleware.ErrorStatus(err, reportErrorStatus(err)), middleware.ErrorResponse(c, err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+func reportErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, enum.ErrNoModeratorRights):
+		return http.StatusForbidden
+	case errors.Is(err, enum.ErrInvalidReportPeriod), errors.Is(err, enum.ErrInvalidReportGroup), errors.Is(err, enum.ErrInvalidDateRange):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -2,6 +2,7 @@ package rest
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/ners1us/order-service/internal/middleware"
 	"github.com/ners1us/order-service/internal/model"
 	"github.com/ners1us/order-service/internal/service"
 	"net/http"
@@ -26,12 +27,12 @@ func (uh *userHandlerImpl) DummyLogin(c *gin.Context) {
 		Role string `json:"role"`
 	}
 	if err := c.BindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, err.Error()))
 		return
 	}
-	token, err := uh.userService.DummyLogin(req.Role)
+	token, err := uh.userService.DummyLogin(c.Request.Context(), req.Role)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(middleware.ErrorStatus(err, http.StatusBadRequest), middleware.ErrorResponse(c, err.Error()))
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"token": token})
@@ -40,12 +41,12 @@ func (uh *userHandlerImpl) DummyLogin(c *gin.Context) {
 func (uh *userHandlerImpl) Register(c *gin.Context) {
 	var user model.User
 	if err := c.BindJSON(&user); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, err.Error()))
 		return
 	}
-	createdUser, err := uh.userService.Register(&user)
+	createdUser, err := uh.userService.Register(c.Request.Context(), &user)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(middleware.ErrorStatus(err, http.StatusBadRequest), middleware.ErrorResponse(c, err.Error()))
 		return
 	}
 	c.JSON(http.StatusCreated, createdUser)
@@ -57,12 +58,12 @@ func (uh *userHandlerImpl) Login(c *gin.Context) {
 		Password string `json:"password"`
 	}
 	if err := c.BindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, middleware.ErrorResponse(c, err.Error()))
 		return
 	}
-	token, err := uh.userService.Login(req.Email, req.Password)
+	token, err := uh.userService.Login(c.Request.Context(), req.Email, req.Password)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		c.JSON(middleware.ErrorStatus(err, http.StatusUnauthorized), middleware.ErrorResponse(c, err.Error()))
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"token": token})
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"context"
+	"github.com/ners1us/order-service/internal/database"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/repository"
+)
+
+type Repositories struct {
+	User          repository.UserRepository
+	PVZ           repository.PVZRepository
+	Reception     repository.ReceptionRepository
+	Product       repository.ProductRepository
+	ReopenRequest repository.ReopenRequestRepository
+	Escalation    repository.EscalationRepository
+	CapacityRule  repository.CapacityRuleRepository
+	Report        repository.ReportRepository
+	Export        repository.ExportRepository
+}
+
+// NewRepositories builds the Postgres repositories. List pages, reports and
+// exports read from replica; pass db itself when there are no replicas.
+func NewRepositories(db database.DB, replica database.Reader) *Repositories {
+	return &Repositories{
+		User:          repository.NewUserRepository(db),
+		PVZ:           repository.NewPVZRepository(db, replica),
+		Reception:     repository.NewReceptionRepository(db, replica),
+		Product:       repository.NewProductRepository(db, replica),
+		ReopenRequest: repository.NewReopenRequestRepository(db),
+		Escalation:    repository.NewEscalationRepository(db),
+		CapacityRule:  repository.NewCapacityRuleRepository(db),
+		Report:        repository.NewReportRepository(replica),
+		Export:        repository.NewExportRepository(replica),
+	}
+}
+
+// NewMemoryRepositories keeps users, PVZs, receptions, products and capacity
+// rules in process memory. Reopen requests, escalations, reports and exports
+// fail with enum.ErrNotSupportedInMemory.
+func NewMemoryRepositories() *Repositories {
+	store := repository.NewMemoryStore()
+	return &Repositories{
+		User:          repository.NewMemoryUserRepository(store),
+		PVZ:           repository.NewMemoryPVZRepository(store),
+		Reception:     repository.NewMemoryReceptionRepository(store),
+		Product:       repository.NewMemoryProductRepository(store),
+		ReopenRequest: unsupportedRepository{},
+		Escalation:    unsupportedRepository{},
+		CapacityRule:  repository.NewMemoryCapacityRuleRepository(store),
+		Report:        unsupportedRepository{},
+		Export:        unsupportedRepository{},
+	}
+}
+
+type unsupportedRepository struct{}
+
+func (unsupportedRepository) CreateReopenRequest(ctx context.Context, request *model.ReopenRequest, receptionStatus string) error {
+	return enum.ErrNotSupportedInMemory
+}
+
+func (unsupportedRepository) ResolveReopenRequest(ctx context.Context, request *model.ReopenRequest, receptionStatus string) error {
+	return enum.ErrNotSupportedInMemory
+}
+
+func (unsupportedRepository) GetReopenRequestByID(ctx context.Context, id string) (*model.ReopenRequest, error) {
+	return &model.ReopenRequest{}, enum.ErrNotSupportedInMemory
+}
+
+func (unsupportedRepository) GetReopenRequestsByStatus(ctx context.Context, status string) ([]model.ReopenRequest, error) {
+	return nil, enum.ErrNotSupportedInMemory
+}
+
+func (unsupportedRepository) GetReopenRequestsByReceptionID(ctx context.Context, receptionID string) ([]model.ReopenRequest, error) {
+	return nil, enum.ErrNotSupportedInMemory
+}
+
+func (unsupportedRepository) CreateEscalation(ctx context.Context, escalation *model.ReceptionEscalation) error {
+	return enum.ErrNotSupportedInMemory
+}
+
+func (unsupportedRepository) GetReport(ctx context.Context, filter *model.ReportFilter) ([]model.ReportRow, error) {
+	return nil, enum.ErrNotSupportedInMemory
+}
+
+func (unsupportedRepository) StreamReceptionProducts(ctx context.Context, filter *model.ExportFilter, handle func(row *model.ExportRow) error) error {
+	return enum.ErrNotSupportedInMemory
+}
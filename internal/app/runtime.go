@@ -0,0 +1,171 @@
+// Package app wires the dependencies shared by the orderctl subcommands.
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ners1us/order-service/internal/breaker"
+	"github.com/ners1us/order-service/internal/config"
+	"github.com/ners1us/order-service/internal/database"
+	"github.com/ners1us/order-service/internal/logger"
+	"github.com/ners1us/order-service/internal/tracing"
+	"log/slog"
+)
+
+// Runtime holds the logger, tracing and database pools of a process. DB is nil
+// when the process keeps its data in memory. Breaker guards the repositories
+// built on DB and never opens with the memory storage.
+type Runtime struct {
+	Config          *config.Config
+	Log             *slog.Logger
+	DB              *pgxpool.Pool
+	Breaker         *breaker.Breaker
+	replicas        []database.Replica
+	replicaPools    []*pgxpool.Pool
+	shutdownTracing func(context.Context) error
+}
+
+// NewRuntime configures logging and tracing for serviceName and opens the
+// database unless the memory storage is configured. The logger also becomes
+// the slog default.
+func NewRuntime(ctx context.Context, cfg *config.Config, serviceName string) (*Runtime, error) {
+	appLogger, err := logger.New(cfg.LogFormat, cfg.LogLevel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure logger: %w", err)
+	}
+	slog.SetDefault(appLogger)
+
+	shutdownTracing, err := tracing.Setup(ctx, cfg.TracingExporter, serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure tracing: %w", err)
+	}
+
+	rt := &Runtime{
+		Config:          cfg,
+		Log:             appLogger,
+		Breaker:         breaker.New("postgres", cfg.DBBreakerFailures, cfg.DBBreakerOpenTimeout),
+		shutdownTracing: shutdownTracing,
+	}
+	if cfg.Storage != config.StorageMemory {
+		if err = rt.openDatabases(ctx); err != nil {
+			rt.Close(ctx)
+			return nil, err
+		}
+	}
+	return rt, nil
+}
+
+// openDatabases opens the primary and every replica, each replica guarded by
+// a breaker of its own so that a failing replica only sends reads to the
+// others. Only the primary is waited for: a replica that does not answer on
+// start has its breaker opened, so reads go elsewhere until a probe finds it
+// back.
+func (rt *Runtime) openDatabases(ctx context.Context) error {
+	db, err := OpenDB(ctx, rt.Config)
+	if err != nil {
+		return err
+	}
+	rt.DB = db
+	for i, replicaURL := range rt.Config.DBReplicaUrls {
+		replica, err := database.NewLazyPool(ctx, replicaURL, poolConfig(rt.Config))
+		if err != nil {
+			return fmt.Errorf("replica %d configuration failed: %w", i+1, err)
+		}
+		rt.replicaPools = append(rt.replicaPools, replica)
+		replicaBreaker := breaker.New(fmt.Sprintf("postgres-replica-%d", i+1), rt.Config.DBBreakerFailures, rt.Config.DBBreakerOpenTimeout)
+		if err := replica.Ping(ctx); err != nil {
+			rt.Log.Error("replica is unavailable, reading from the others until it recovers", slog.Int("replica", i+1), slog.Any("error", err))
+			replicaBreaker.Open()
+		}
+		rt.replicas = append(rt.replicas, database.Replica{DB: replica, Breaker: replicaBreaker})
+	}
+	return nil
+}
+
+// OpenDB opens the database pool configured by cfg. Commands working on the
+// database directly fail with the memory storage.
+func OpenDB(ctx context.Context, cfg *config.Config) (*pgxpool.Pool, error) {
+	if cfg.Storage == config.StorageMemory {
+		return nil, fmt.Errorf("this command requires the %s storage", config.StoragePostgres)
+	}
+	db, err := database.NewPool(ctx, cfg.DbUrl, poolConfig(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	return db, nil
+}
+
+// OpenDBWithoutTimeout opens the database like OpenDB but without the
+// statement timeout, for commands such as migrate and seed whose statements
+// may legitimately run longer than any request.
+func OpenDBWithoutTimeout(ctx context.Context, cfg *config.Config) (*pgxpool.Pool, error) {
+	bulkCfg := *cfg
+	bulkCfg.DBStatementTimeout = 0
+	return OpenDB(ctx, &bulkCfg)
+}
+
+func poolConfig(cfg *config.Config) database.PoolConfig {
+	return database.PoolConfig{
+		MaxConns:         cfg.DBMaxOpenConns,
+		MinConns:         cfg.DBMinConns,
+		ConnMaxLifetime:  cfg.DBConnMaxLifetime,
+		ConnMaxIdleTime:  cfg.DBConnMaxIdleTime,
+		StatementTimeout: cfg.DBStatementTimeout,
+		ConnectWait:      cfg.DBConnectWait,
+	}
+}
+
+// Repositories builds the repositories of the configured storage. Postgres
+// repositories fail fast with breaker.ErrOpen while the database is down and
+// read list pages and reports from the replicas when there are any.
+func (rt *Runtime) Repositories() *Repositories {
+	if rt.DB == nil {
+		return NewMemoryRepositories()
+	}
+	db := database.NewBreakerDB(rt.DB, rt.Breaker)
+	return NewRepositories(db, database.NewReadRouter(db, rt.replicas))
+}
+
+// PrepareSchema applies pending migrations when auto-migration is enabled and
+// fails if the schema is behind the embedded migrations. It returns the schema
+// version the binary expects. The memory storage has no schema to prepare.
+func (rt *Runtime) PrepareSchema(ctx context.Context) (uint, error) {
+	schemaVersion, err := database.SchemaVersion()
+	if err != nil || rt.DB == nil {
+		return schemaVersion, err
+	}
+	if rt.Config.DBAutoMigrate {
+		if err := rt.migrateUp(ctx); err != nil {
+			return 0, fmt.Errorf("failed to apply migrations: %w", err)
+		}
+	}
+	if err := database.CheckSchemaVersion(ctx, rt.DB, schemaVersion); err != nil {
+		return 0, err
+	}
+	return schemaVersion, nil
+}
+
+// migrateUp applies the migrations through a pool of its own opened without
+// the statement timeout, which a long migration would otherwise hit.
+func (rt *Runtime) migrateUp(ctx context.Context) error {
+	db, err := OpenDBWithoutTimeout(ctx, rt.Config)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return database.MigrateUp(ctx, db)
+}
+
+// Close flushes pending traces and closes the databases.
+func (rt *Runtime) Close(ctx context.Context) {
+	if err := rt.shutdownTracing(ctx); err != nil {
+		rt.Log.Error("failed to flush traces", slog.Any("error", err))
+	}
+	if rt.DB != nil {
+		rt.DB.Close()
+	}
+	for _, replica := range rt.replicaPools {
+		replica.Close()
+	}
+}
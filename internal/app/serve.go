@@ -0,0 +1,186 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/ners1us/order-service/internal/api/rest"
+	"github.com/ners1us/order-service/internal/cache"
+	"github.com/ners1us/order-service/internal/config"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
+	"github.com/ners1us/order-service/internal/health"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/repository"
+	"github.com/ners1us/order-service/internal/scheduler"
+	"github.com/ners1us/order-service/internal/server"
+	"github.com/ners1us/order-service/internal/service"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// Serve runs the given servers and the metrics server in one process until ctx
+// is cancelled, then stops them within the configured shutdown timeout. The REST
+// server also runs the stale reception scheduler. With the memory storage events
+// stay within the process and the scheduler, which needs Postgres locks and
+// escalations, does not run.
+func Serve(ctx context.Context, rt *Runtime, servers ...config.Server) error {
+	schemaVersion, err := rt.PrepareSchema(ctx)
+	if err != nil {
+		return fmt.Errorf("refusing to start: %w", err)
+	}
+
+	cfg := rt.Config
+	serveREST := slices.Contains(servers, config.ServerREST)
+	serveGRPC := slices.Contains(servers, config.ServerGRPC)
+	repos := rt.Repositories()
+
+	// eventBus carries events raised by this process and forwards them to the
+	// other processes; remoteEventBus carries the events received from them.
+	instanceID := uuid.NewString()
+	eventBus := event.NewBus()
+	remoteEventBus := event.NewBus()
+	eventBus.Subscribe(enum.EventReceptionEscalated, event.HandlerFunc(event.NewLogPublisher(rt.Log).Publish))
+	var notificationListener event.Listener
+	if rt.DB != nil {
+		pgPublisher := event.NewPGPublisher(rt.DB, cfg.NotifyChannel, instanceID)
+		event.SubscribeAll(eventBus, event.HandlerFunc(pgPublisher.Publish),
+			enum.EventReceptionChanged,
+			enum.EventProductsChanged,
+			enum.EventReceptionEscalated,
+			enum.EventPVZsChanged,
+		)
+		notificationListener = event.NewPGListener(rt.DB, cfg.NotifyChannel, instanceID, remoteEventBus, rt.Log)
+	}
+	if cfg.PVZCacheSize > 0 {
+		cachedPVZRepo := repository.NewCachedPVZRepository(
+			repos.PVZ,
+			cache.NewLRUCache[model.PVZ]("pvz", cfg.PVZCacheSize, cfg.PVZCacheTTL),
+			cache.NewLRUCache[[]model.PVZ]("pvz_list", cfg.PVZCacheSize, cfg.PVZCacheTTL),
+			cfg.DBReplicaMaxLag,
+		)
+		event.SubscribeAll(remoteEventBus, cachedPVZRepo, enum.EventPVZsChanged, enum.EventListenerReconnected)
+		repos.PVZ = cachedPVZRepo
+	}
+
+	openReceptionCollector := service.NewOpenReceptionCollector(repos.Reception, rt.Log)
+	if err := prometheus.Register(openReceptionCollector); err != nil {
+		return fmt.Errorf("failed to register open reception metrics: %w", err)
+	}
+	defer prometheus.Unregister(openReceptionCollector)
+
+	healthChecker := health.NewChecker(rt.DB, schemaVersion)
+	backendServers := []server.BackendServer{server.NewMetricsServer(cfg.PrometheusPort, rt.Log, healthChecker)}
+	var staleReceptionScheduler scheduler.Scheduler
+
+	// The REST and gRPC servers share the services built by NewServices.
+	services := NewServices(cfg, repos, eventBus, rt.Log)
+
+	if serveREST {
+		if cfg.PVZCacheSize > 0 {
+			cachedPVZService := service.NewCachedPVZService(
+				services.PVZ,
+				cache.NewLRUCache[[]model.PVZWithReceptions]("pvz_pages", cfg.PVZCacheSize, cfg.PVZCacheTTL),
+				cfg.DBReplicaMaxLag,
+			)
+			pageEventTypes := []enum.EventType{
+				enum.EventReceptionChanged,
+				enum.EventProductsChanged,
+				enum.EventReceptionEscalated,
+				enum.EventPVZsChanged,
+			}
+			event.SubscribeAll(eventBus, cachedPVZService, pageEventTypes...)
+			event.SubscribeAll(remoteEventBus, cachedPVZService, append(pageEventTypes, enum.EventListenerReconnected)...)
+			services.PVZ = cachedPVZService
+		}
+
+		backendServers = append(backendServers, server.NewHTTPServer(
+			cfg.RestPort,
+			server.HTTPTimeouts{
+				Read:  cfg.HTTPReadTimeout,
+				Write: cfg.HTTPWriteTimeout,
+				Idle:  cfg.HTTPIdleTimeout,
+			},
+			rt.Log,
+			healthChecker,
+			rt.Breaker,
+			rest.NewUserHandler(services.User),
+			rest.NewPVZHandler(services.PVZ),
+			rest.NewReceptionHandler(services.Reception),
+			rest.NewProductHandler(services.Product),
+			rest.NewReopenRequestHandler(services.ReopenRequest),
+			rest.NewCapacityHandler(services.Capacity),
+			rest.NewReportHandler(services.Report),
+			rest.NewExportHandler(services.Export),
+			rest.NewPVZImportHandler(services.PVZImport),
+			services.JWT,
+		))
+		if rt.DB != nil {
+			staleReceptionScheduler = scheduler.NewStaleReceptionScheduler(rt.DB, services.StaleReception, cfg.StaleReceptionCheckInterval, rt.Log)
+		}
+	}
+
+	if serveGRPC {
+		grpcServer, err := server.NewServer(repos.PVZ, services.Report, services.Reception, cfg.GrpcPort, rt.Log, healthChecker, rt.Breaker)
+		if err != nil {
+			return fmt.Errorf("failed to initialize gRPC server: %w", err)
+		}
+		backendServers = append(backendServers, grpcServer)
+	}
+
+	if notificationListener != nil {
+		if err := notificationListener.Start(); err != nil {
+			return fmt.Errorf("failed to start notification listener: %w", err)
+		}
+	}
+	if staleReceptionScheduler != nil {
+		staleReceptionScheduler.Start()
+	}
+
+	errCh := make(chan error, len(backendServers))
+	for _, backendServer := range backendServers {
+		backendServer.ConfigureRoutes()
+		go func() {
+			errCh <- backendServer.Start()
+		}()
+	}
+
+	var serveErr error
+	select {
+	case <-ctx.Done():
+		rt.Log.Info("shutting down")
+	case serveErr = <-errCh:
+		rt.Log.Error("server failed, shutting down", slog.Any("error", serveErr))
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// Readiness fails from here on, but the servers keep accepting requests for
+	// the drain delay so that load balancers stop routing to this process first.
+	healthChecker.Drain()
+	select {
+	case <-time.After(cfg.DrainDelay):
+	case <-shutdownCtx.Done():
+	}
+
+	if staleReceptionScheduler != nil {
+		staleReceptionScheduler.Stop(shutdownCtx)
+	}
+	if notificationListener != nil {
+		notificationListener.Stop(shutdownCtx)
+	}
+	for _, backendServer := range backendServers {
+		backendServer.Stop(shutdownCtx)
+	}
+
+	if errors.Is(serveErr, http.ErrServerClosed) || errors.Is(serveErr, grpc.ErrServerStopped) {
+		return nil
+	}
+	return serveErr
+}
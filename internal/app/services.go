@@ -0,0 +1,52 @@
+package app
+
+import (
+	"github.com/ners1us/order-service/internal/config"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
+	"github.com/ners1us/order-service/internal/service"
+	"log/slog"
+)
+
+type Services struct {
+	JWT            service.JWTService
+	User           service.UserService
+	PVZ            service.PVZService
+	Reception      service.ReceptionService
+	Product        service.ProductService
+	Capacity       service.CapacityService
+	ReopenRequest  service.ReopenRequestService
+	Report         service.ReportService
+	Export         service.ExportService
+	PVZImport      service.PVZImportService
+	StaleReception service.StaleReceptionService
+}
+
+// NewServices builds the services on top of repos; events they raise are sent
+// to publisher and failures to publish them are logged to log.
+func NewServices(cfg *config.Config, repos *Repositories, publisher event.Publisher, log *slog.Logger) *Services {
+	jwtService := service.NewJWTService(cfg.JWTSecret, cfg.TokenTTL)
+	return &Services{
+		JWT:           jwtService,
+		User:          service.NewUserService(repos.User, jwtService),
+		PVZ:           service.NewPVZService(repos.PVZ, repos.Reception, repos.Product, publisher, log),
+		Reception:     service.NewReceptionService(repos.Reception, repos.PVZ, repos.Product, publisher, log),
+		Product:       service.NewProductService(repos.Reception, repos.Product, repos.CapacityRule, repos.PVZ, publisher, log),
+		Capacity:      service.NewCapacityService(repos.CapacityRule, repos.PVZ, repos.Reception, repos.Product),
+		ReopenRequest: service.NewReopenRequestService(repos.ReopenRequest, repos.Reception, repos.PVZ, publisher, log),
+		Report:        service.NewReportService(repos.Report),
+		Export:        service.NewExportService(repos.Export),
+		PVZImport:     service.NewPVZImportService(repos.PVZ, publisher, log),
+		StaleReception: service.NewStaleReceptionService(
+			repos.Reception,
+			repos.Escalation,
+			publisher,
+			service.StaleReceptionPolicy{
+				Threshold:      cfg.StaleReceptionThreshold,
+				CityThresholds: cfg.StaleReceptionCityThresholds,
+				Action:         enum.EscalationAction(cfg.StaleReceptionAction),
+			},
+			log,
+		),
+	}
+}
@@ -0,0 +1,145 @@
+// Package breaker implements a circuit breaker that stops calls to a failing
+// dependency for a while and lets a single probe through to detect recovery.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"github.com/ners1us/order-service/internal/metric"
+	"sync"
+	"time"
+)
+
+var ErrOpen = errors.New("circuit breaker is open")
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateHalfOpen
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "open"
+	}
+}
+
+// Breaker opens after failureThreshold consecutive failures and rejects calls
+// with ErrOpen for openTimeout. Then it lets one probe call through: a success
+// closes it and a failure opens it again. The state is exported in
+// metric.CircuitBreakerState under the given name.
+type Breaker struct {
+	mu               sync.Mutex
+	name             string
+	failureThreshold int
+	openTimeout      time.Duration
+	state            State
+	failures         int
+	openedAt         time.Time
+	probing          bool
+	now              func() time.Time
+}
+
+func New(name string, failureThreshold int, openTimeout time.Duration) *Breaker {
+	b := &Breaker{
+		name:             name,
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+		now:              time.Now,
+	}
+	metric.CircuitBreakerState.WithLabelValues(name).Set(float64(StateClosed))
+	return b
+}
+
+// Allow reports whether a call may proceed. Every allowed call must be
+// followed by Record with its outcome.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.currentState() {
+	case StateClosed:
+		return nil
+	case StateHalfOpen:
+		if b.probing {
+			return ErrOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return ErrOpen
+	}
+}
+
+// Record reports the outcome of an allowed call. failed tells whether err
+// means the dependency is unavailable; other errors count as successes, since
+// the dependency answered. A call cancelled by its caller says nothing about
+// the dependency and only ends the probe.
+func (b *Breaker) Record(err error, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasProbe := b.probing
+	b.probing = false
+	switch {
+	case errors.Is(err, context.Canceled):
+	case failed:
+		b.failures++
+		if wasProbe || b.failures >= b.failureThreshold {
+			b.openedAt = b.now()
+			b.setState(StateOpen)
+		}
+	default:
+		b.failures = 0
+		b.setState(StateClosed)
+	}
+}
+
+// Open opens the breaker as if the failure threshold was reached, for a
+// dependency already known to be unavailable. A probe goes through after
+// openTimeout as usual.
+func (b *Breaker) Open() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = b.failureThreshold
+	b.probing = false
+	b.openedAt = b.now()
+	b.setState(StateOpen)
+}
+
+// Ready reports whether a call would be allowed now, without taking the probe.
+func (b *Breaker) Ready() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state := b.currentState()
+	return state == StateClosed || state == StateHalfOpen && !b.probing
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.currentState()
+}
+
+// currentState turns an open breaker half-open once openTimeout has passed.
+func (b *Breaker) currentState() State {
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.openTimeout {
+		b.setState(StateHalfOpen)
+	}
+	return b.state
+}
+
+func (b *Breaker) setState(state State) {
+	b.state = state
+	metric.CircuitBreakerState.WithLabelValues(b.name).Set(float64(state))
+}
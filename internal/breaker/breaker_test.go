@@ -0,0 +1,134 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var errUnavailable = errors.New("connection refused")
+
+func newTestBreaker(now *time.Time) *Breaker {
+	b := New("test", 2, 10*time.Second)
+	b.now = func() time.Time { return *now }
+	return b
+}
+
+func TestBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	b := newTestBreaker(&now)
+
+	// Act
+	assert.NoError(t, b.Allow())
+	b.Record(errUnavailable, true)
+	stateAfterOne := b.State()
+	assert.NoError(t, b.Allow())
+	b.Record(errUnavailable, true)
+
+	// Assert
+	assert.Equal(t, StateClosed, stateAfterOne)
+	assert.Equal(t, StateOpen, b.State())
+	assert.ErrorIs(t, b.Allow(), ErrOpen)
+	assert.False(t, b.Ready())
+}
+
+func TestBreaker_OtherErrorsResetFailures(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	b := newTestBreaker(&now)
+
+	// Act
+	_ = b.Allow()
+	b.Record(errUnavailable, true)
+	_ = b.Allow()
+	b.Record(errors.New("duplicate key"), false)
+	_ = b.Allow()
+	b.Record(errUnavailable, true)
+
+	// Assert
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_HalfOpenLetsOneProbeThrough(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	b := newTestBreaker(&now)
+	for i := 0; i < 2; i++ {
+		_ = b.Allow()
+		b.Record(errUnavailable, true)
+	}
+	now = now.Add(10 * time.Second)
+
+	// Act
+	probeErr := b.Allow()
+	concurrentErr := b.Allow()
+
+	// Assert
+	assert.NoError(t, probeErr)
+	assert.ErrorIs(t, concurrentErr, ErrOpen)
+	assert.Equal(t, StateHalfOpen, b.State())
+}
+
+func TestBreaker_ProbeOutcomeDecidesState(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	b := newTestBreaker(&now)
+	for i := 0; i < 2; i++ {
+		_ = b.Allow()
+		b.Record(errUnavailable, true)
+	}
+	now = now.Add(10 * time.Second)
+
+	// Act
+	_ = b.Allow()
+	b.Record(errUnavailable, true)
+	stateAfterFailedProbe := b.State()
+	now = now.Add(10 * time.Second)
+	_ = b.Allow()
+	b.Record(nil, false)
+
+	// Assert
+	assert.Equal(t, StateOpen, stateAfterFailedProbe)
+	assert.Equal(t, StateClosed, b.State())
+	assert.True(t, b.Ready())
+}
+
+func TestBreaker_CancelledProbeKeepsHalfOpen(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	b := newTestBreaker(&now)
+	for i := 0; i < 2; i++ {
+		_ = b.Allow()
+		b.Record(errUnavailable, true)
+	}
+	now = now.Add(10 * time.Second)
+	_ = b.Allow()
+
+	// Act
+	b.Record(context.Canceled, false)
+
+	// Assert
+	assert.Equal(t, StateHalfOpen, b.State())
+	assert.NoError(t, b.Allow())
+}
+
+func TestBreaker_OpenRejectsUntilTheProbe(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	b := newTestBreaker(&now)
+
+	// Act
+	b.Open()
+	errWhileOpen := b.Allow()
+	now = now.Add(10 * time.Second)
+	errAfterTimeout := b.Allow()
+	b.Record(nil, false)
+
+	// Assert
+	assert.ErrorIs(t, errWhileOpen, ErrOpen)
+	assert.NoError(t, errAfterTimeout)
+	assert.Equal(t, StateClosed, b.State())
+}
@@ -0,0 +1,20 @@
+package cache
+
+import "time"
+
+// Cache is a concurrency-safe key-value store with bounded size. Implementations
+// may drop entries at any time, so callers must treat it as an optimisation only.
+//
+// Generation changes on every Delete, DeleteFunc and Clear. A caller filling the
+// cache takes it before loading the value and passes it to Set, which drops the
+// value if the cache was invalidated in the meantime, as it may be stale.
+// InvalidatedAt is the time of the latest such call, zero before the first.
+type Cache[V any] interface {
+	Get(key string) (V, bool)
+	Generation() uint64
+	InvalidatedAt() time.Time
+	Set(key string, value V, generation uint64)
+	Delete(key string)
+	DeleteFunc(del func(key string, value V) bool)
+	Clear()
+}
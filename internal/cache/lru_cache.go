@@ -0,0 +1,146 @@
+package cache
+
+import (
+	"container/list"
+	"github.com/ners1us/order-service/internal/metric"
+	"sync"
+	"time"
+)
+
+type lruEntry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+type lruCache[V any] struct {
+	mu            sync.Mutex
+	name          string
+	capacity      int
+	ttl           time.Duration
+	entries       map[string]*list.Element
+	order         *list.List
+	generation    uint64
+	invalidatedAt time.Time
+	now           func() time.Time
+}
+
+// NewLRUCache returns an in-memory cache that evicts the least recently used
+// entry once capacity is reached. Entries older than ttl are treated as misses;
+// a zero ttl keeps entries until they are evicted or invalidated. Hits and misses
+// are counted in metric.CacheRequests under the given name.
+func NewLRUCache[V any](name string, capacity int, ttl time.Duration) Cache[V] {
+	return &lruCache[V]{
+		name:     name,
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+func (lc *lruCache[V]) Get(key string) (V, bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	element, ok := lc.entries[key]
+	if ok && lc.isExpired(element.Value.(*lruEntry[V])) {
+		lc.remove(element)
+		ok = false
+	}
+	if !ok {
+		metric.CacheRequests.WithLabelValues(lc.name, "miss").Inc()
+		var zero V
+		return zero, false
+	}
+	metric.CacheRequests.WithLabelValues(lc.name, "hit").Inc()
+	lc.order.MoveToFront(element)
+	return element.Value.(*lruEntry[V]).value, true
+}
+
+func (lc *lruCache[V]) Generation() uint64 {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	return lc.generation
+}
+
+func (lc *lruCache[V]) InvalidatedAt() time.Time {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	return lc.invalidatedAt
+}
+
+func (lc *lruCache[V]) Set(key string, value V, generation uint64) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if generation != lc.generation {
+		return
+	}
+	var expiresAt time.Time
+	if lc.ttl > 0 {
+		expiresAt = lc.now().Add(lc.ttl)
+	}
+	if element, ok := lc.entries[key]; ok {
+		entry := element.Value.(*lruEntry[V])
+		entry.value = value
+		entry.expiresAt = expiresAt
+		lc.order.MoveToFront(element)
+		return
+	}
+	lc.entries[key] = lc.order.PushFront(&lruEntry[V]{key: key, value: value, expiresAt: expiresAt})
+	if lc.order.Len() > lc.capacity {
+		lc.remove(lc.order.Back())
+	}
+}
+
+func (lc *lruCache[V]) Delete(key string) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.invalidate()
+	if element, ok := lc.entries[key]; ok {
+		lc.remove(element)
+	}
+}
+
+func (lc *lruCache[V]) DeleteFunc(del func(key string, value V) bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.invalidate()
+	for element := lc.order.Front(); element != nil; {
+		next := element.Next()
+		entry := element.Value.(*lruEntry[V])
+		if del(entry.key, entry.value) {
+			lc.remove(element)
+		}
+		element = next
+	}
+}
+
+func (lc *lruCache[V]) Clear() {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.invalidate()
+	lc.entries = make(map[string]*list.Element)
+	lc.order.Init()
+}
+
+func (lc *lruCache[V]) invalidate() {
+	lc.generation++
+	lc.invalidatedAt = lc.now()
+}
+
+func (lc *lruCache[V]) isExpired(entry *lruEntry[V]) bool {
+	return !entry.expiresAt.IsZero() && lc.now().After(entry.expiresAt)
+}
+
+func (lc *lruCache[V]) remove(element *list.Element) {
+	lc.order.Remove(element)
+	delete(lc.entries, element.Value.(*lruEntry[V]).key)
+}
@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"github.com/ners1us/order-service/internal/metric"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLRUCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	// Arrange
+	cache := NewLRUCache[int]("test_eviction", 2, 0)
+	cache.Set("a", 1, cache.Generation())
+	cache.Set("b", 2, cache.Generation())
+	cache.Get("a")
+
+	// Act
+	cache.Set("c", 3, cache.Generation())
+
+	// Assert
+	_, okA := cache.Get("a")
+	_, okB := cache.Get("b")
+	_, okC := cache.Get("c")
+	assert.True(t, okA)
+	assert.False(t, okB)
+	assert.True(t, okC)
+}
+
+func TestLRUCache_SetExistingKeyMovesItToFront(t *testing.T) {
+	// Arrange
+	cache := NewLRUCache[int]("test_overwrite", 2, 0)
+	cache.Set("a", 1, cache.Generation())
+	cache.Set("b", 2, cache.Generation())
+	cache.Set("a", 10, cache.Generation())
+
+	// Act
+	cache.Set("c", 3, cache.Generation())
+
+	// Assert
+	value, okA := cache.Get("a")
+	_, okB := cache.Get("b")
+	assert.True(t, okA)
+	assert.Equal(t, 10, value)
+	assert.False(t, okB)
+}
+
+func TestLRUCache_ExpiresEntriesAfterTTL(t *testing.T) {
+	// Arrange
+	cache := NewLRUCache[int]("test_ttl", 2, time.Minute).(*lruCache[int])
+	now := time.Date(2025, 4, 10, 9, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+	cache.Set("a", 1, cache.Generation())
+
+	// Act
+	_, okBeforeTTL := cache.Get("a")
+	now = now.Add(time.Minute + time.Second)
+	_, okAfterTTL := cache.Get("a")
+
+	// Assert
+	assert.True(t, okBeforeTTL)
+	assert.False(t, okAfterTTL)
+	assert.Equal(t, 0, cache.order.Len())
+}
+
+func TestLRUCache_CountsHitsAndMisses(t *testing.T) {
+	// Arrange
+	cache := NewLRUCache[int]("test_metrics", 2, 0)
+	cache.Set("a", 1, cache.Generation())
+
+	// Act
+	cache.Get("a")
+	cache.Get("a")
+	cache.Get("b")
+
+	// Assert
+	assert.Equal(t, 2.0, testutil.ToFloat64(metric.CacheRequests.WithLabelValues("test_metrics", "hit")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metric.CacheRequests.WithLabelValues("test_metrics", "miss")))
+}
+
+func TestLRUCache_DropsFillStartedBeforeInvalidation(t *testing.T) {
+	// Arrange
+	cache := NewLRUCache[int]("test_generation", 2, 0)
+	generation := cache.Generation()
+	cache.Clear()
+
+	// Act
+	cache.Set("a", 1, generation)
+
+	// Assert
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+}
+
+func TestLRUCache_InvalidatedAtIsZeroBeforeAnyInvalidation(t *testing.T) {
+	// Arrange
+	cache := NewLRUCache[int]("test_invalidated_at", 2, 0)
+
+	// Act
+	cache.Set("a", 1, cache.Generation())
+
+	// Assert
+	assert.True(t, cache.InvalidatedAt().IsZero())
+}
+
+func TestLRUCache_EveryInvalidationChangesGeneration(t *testing.T) {
+	// Arrange
+	cache := NewLRUCache[int]("test_generation_changes", 2, 0)
+	cache.Set("a", 1, cache.Generation())
+	now := time.Date(2025, 4, 10, 9, 0, 0, 0, time.UTC)
+	cache.(*lruCache[int]).now = func() time.Time { return now }
+	invalidations := map[string]func(){
+		"Delete":     func() { cache.Delete("missing") },
+		"DeleteFunc": func() { cache.DeleteFunc(func(string, int) bool { return false }) },
+		"Clear":      func() { cache.Clear() },
+	}
+
+	for name, invalidate := range invalidations {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			generation := cache.Generation()
+			now = now.Add(time.Second)
+
+			// Act
+			invalidate()
+
+			// Assert
+			assert.NotEqual(t, generation, cache.Generation())
+			assert.Equal(t, now, cache.InvalidatedAt())
+		})
+	}
+}
@@ -1,26 +1,183 @@
 package config
 
-import "os"
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Server names a process whose specific settings are validated by Load.
+type Server string
+
+const (
+	ServerREST Server = "rest"
+	ServerGRPC Server = "grpc"
+)
+
+// Storage names where the services keep their data.
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
+const configFileEnv = "CONFIG_FILE"
 
 type Config struct {
-	DbUrl          string
-	JWTSecret      string
-	RestPort       string
-	GrpcPort       string
-	PrometheusPort string
+	Storage                      string
+	DbUrl                        string
+	DBReplicaUrls                []string
+	DBReplicaMaxLag              time.Duration
+	DBMaxOpenConns               int
+	DBMinConns                   int
+	DBConnMaxLifetime            time.Duration
+	DBConnMaxIdleTime            time.Duration
+	DBStatementTimeout           time.Duration
+	DBConnectWait                time.Duration
+	DBBreakerFailures            int
+	DBBreakerOpenTimeout         time.Duration
+	DBAutoMigrate                bool
+	JWTSecret                    string
+	TokenTTL                     time.Duration
+	RestPort                     string
+	HTTPReadTimeout              time.Duration
+	HTTPWriteTimeout             time.Duration
+	HTTPIdleTimeout              time.Duration
+	GrpcPort                     string
+	PrometheusPort               string
+	ShutdownTimeout              time.Duration
+	DrainDelay                   time.Duration
+	StaleReceptionCheckInterval  time.Duration
+	StaleReceptionThreshold      time.Duration
+	StaleReceptionCityThresholds map[string]time.Duration
+	StaleReceptionAction         string
+	PVZCacheSize                 int
+	PVZCacheTTL                  time.Duration
+	NotifyChannel                string
+	LogFormat                    string
+	LogLevel                     string
+	TracingExporter              string
 }
 
-func NewConfig() *Config {
+// Default returns the configuration used when nothing overrides a setting.
+func Default() *Config {
 	return &Config{
-		DbUrl:          getEnv("DB_URL"),
-		JWTSecret:      getEnv("JWT_SECRET"),
-		RestPort:       getEnv("REST_PORT"),
-		GrpcPort:       getEnv("GRPC_PORT"),
-		PrometheusPort: getEnv("PROMETHEUS_PORT"),
+		Storage:                      StoragePostgres,
+		DBReplicaMaxLag:              5 * time.Second,
+		DBMaxOpenConns:               25,
+		DBMinConns:                   2,
+		DBConnMaxLifetime:            30 * time.Minute,
+		DBConnMaxIdleTime:            5 * time.Minute,
+		DBStatementTimeout:           30 * time.Second,
+		DBConnectWait:                30 * time.Second,
+		DBBreakerFailures:            5,
+		DBBreakerOpenTimeout:         10 * time.Second,
+		TokenTTL:                     time.Hour,
+		RestPort:                     "8080",
+		HTTPReadTimeout:              30 * time.Second,
+		HTTPIdleTimeout:              2 * time.Minute,
+		GrpcPort:                     "3000",
+		PrometheusPort:               "9000",
+		ShutdownTimeout:              10 * time.Second,
+		DrainDelay:                   5 * time.Second,
+		StaleReceptionCheckInterval:  5 * time.Minute,
+		StaleReceptionThreshold:      12 * time.Hour,
+		StaleReceptionCityThresholds: map[string]time.Duration{},
+		StaleReceptionAction:         "flag",
+		PVZCacheSize:                 1000,
+		PVZCacheTTL:                  5 * time.Minute,
+		NotifyChannel:                "order_service_events",
+		LogFormat:                    "json",
+		LogLevel:                     "info",
+		TracingExporter:              "none",
+	}
+}
+
+// Load builds the configuration from, in increasing priority, the defaults, a
+// YAML or TOML file named by the -config flag or CONFIG_FILE, environment
+// variables and the command line flags in args. Every invalid value is
+// reported in the returned error, not only the first one. The settings of the
+// given servers are validated in addition to the shared ones.
+func Load(args []string, servers ...Server) (*Config, error) {
+	fs := flag.NewFlagSet(commandName(), flag.ContinueOnError)
+	flags := RegisterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	return flags.Load(servers...)
+}
+
+// Flags collects the configuration flags of a command that has flags of its
+// own. Load must be called after the flag set is parsed.
+type Flags struct {
+	configFile *string
+	values     []flagValue
+}
+
+type flagValue struct {
+	option *option
+	value  string
+}
+
+// RegisterFlags adds -config and a flag for every setting to fs.
+func RegisterFlags(fs *flag.FlagSet) *Flags {
+	flags := &Flags{
+		configFile: fs.String("config", os.Getenv(configFileEnv), "path to a YAML or TOML configuration file (env "+configFileEnv+")"),
+	}
+	for i := range options {
+		opt := &options[i]
+		record := func(value string) error {
+			flags.values = append(flags.values, flagValue{opt, value})
+			return nil
+		}
+		usage := opt.usage + " (env " + opt.env + ")"
+		if opt.boolean {
+			fs.BoolFunc(opt.flagName(), usage, record)
+		} else {
+			fs.Func(opt.flagName(), usage, record)
+		}
+	}
+	return flags
+}
+
+// Load applies the layers described in the package Load to the parsed flags.
+func (f *Flags) Load(servers ...Server) (*Config, error) {
+	cfg := Default()
+	var errs []error
+	if *f.configFile != "" {
+		errs = append(errs, loadFile(cfg, *f.configFile)...)
+	}
+	for i := range options {
+		opt := &options[i]
+		// An empty variable is treated as unset, as docker-compose and shells
+		// commonly pass them through.
+		if value := os.Getenv(opt.env); value != "" {
+			if err := opt.set(cfg, value); err != nil {
+				errs = append(errs, &valueError{source: "env " + opt.env, value: value, err: err})
+			}
+		}
+	}
+	for _, fv := range f.values {
+		if err := fv.option.set(cfg, fv.value); err != nil {
+			errs = append(errs, &valueError{source: "flag -" + fv.option.flagName(), value: fv.value, err: err})
+		}
 	}
+	errs = append(errs, cfg.validate(servers)...)
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return cfg, nil
 }
 
-func getEnv(key string) string {
-	value, _ := os.LookupEnv(key)
-	return value
+func commandName() string {
+	if len(os.Args) > 0 {
+		return os.Args[0]
+	}
+	return "order-service"
 }
@@ -0,0 +1,327 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearEnv unsets every configuration variable for the test, so the
+// environment of the machine running it does not leak into Load.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv(configFileEnv, "")
+	for _, opt := range options {
+		t.Setenv(opt.env, "")
+	}
+}
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	cases := map[string]struct {
+		file         string
+		env          string
+		flag         string
+		expectedPort string
+	}{
+		"Default": {
+			expectedPort: "8080",
+		},
+		"FileOverridesDefault": {
+			file:         "8081",
+			expectedPort: "8081",
+		},
+		"EnvOverridesFile": {
+			file:         "8081",
+			env:          "8082",
+			expectedPort: "8082",
+		},
+		"FlagOverridesEnv": {
+			file:         "8081",
+			env:          "8082",
+			flag:         "8083",
+			expectedPort: "8083",
+		},
+		"FlagOverridesFile": {
+			file:         "8081",
+			flag:         "8083",
+			expectedPort: "8083",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			clearEnv(t)
+			args := []string{"-storage", StorageMemory}
+			if tc.file != "" {
+				path := writeConfigFile(t, "config.yaml", "rest:\n  port: "+tc.file+"\n")
+				args = append(args, "-config", path)
+			}
+			if tc.env != "" {
+				t.Setenv("REST_PORT", tc.env)
+			}
+			if tc.flag != "" {
+				args = append(args, "-rest-port", tc.flag)
+			}
+
+			// Act
+			cfg, err := Load(args)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPort, cfg.RestPort)
+		})
+	}
+}
+
+func TestLoad_ConfigFileFromEnv(t *testing.T) {
+	// Arrange
+	clearEnv(t)
+	t.Setenv(configFileEnv, writeConfigFile(t, "config.toml", "storage = \"memory\"\n[db]\nmax_open_conns = 7\n"))
+
+	// Act
+	cfg, err := Load(nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, StorageMemory, cfg.Storage)
+	assert.Equal(t, 7, cfg.DBMaxOpenConns)
+}
+
+func TestLoad_ReportsEveryError(t *testing.T) {
+	// Arrange
+	clearEnv(t)
+	path := writeConfigFile(t, "config.yaml", "storage: memory\ncache:\n  pvz_ttl: soon\n")
+	t.Setenv("DB_MAX_OPEN_CONNS", "many")
+	args := []string{"-config", path, "-log-level", "loud"}
+
+	// Act
+	cfg, err := Load(args)
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, cfg)
+	assert.Equal(t, []string{
+		`config file ` + path + ` key cache.pvz_ttl: invalid value "soon": not a duration`,
+		`env DB_MAX_OPEN_CONNS: invalid value "many": not an integer`,
+		`log.level must be debug, info, warn or error, got "loud"`,
+	}, strings.Split(err.Error(), "\n"))
+}
+
+func TestLoad_JWTSecretRequiredPerServer(t *testing.T) {
+	cases := map[string]struct {
+		servers     []Server
+		secret      string
+		expectedErr bool
+	}{
+		"NoServer": {},
+		"GRPCOnly": {
+			servers: []Server{ServerGRPC},
+		},
+		"RESTWithoutSecret": {
+			servers:     []Server{ServerREST},
+			expectedErr: true,
+		},
+		"RESTAndGRPCWithoutSecret": {
+			servers:     []Server{ServerREST, ServerGRPC},
+			expectedErr: true,
+		},
+		"RESTWithSecret": {
+			servers: []Server{ServerREST},
+			secret:  "secret",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			clearEnv(t)
+			t.Setenv("JWT_SECRET", tc.secret)
+
+			// Act
+			_, err := Load([]string{"-storage", StorageMemory}, tc.servers...)
+
+			// Assert
+			if tc.expectedErr {
+				assert.EqualError(t, err, "jwt.secret (JWT_SECRET) is required")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoad_FileErrors(t *testing.T) {
+	cases := map[string]struct {
+		name        string
+		content     string
+		expectedErr string
+	}{
+		"UnknownTopLevelKey": {
+			name:        "config.yaml",
+			content:     "storage: memory\nstorag: postgres\n",
+			expectedErr: `unknown key "storag"`,
+		},
+		"UnknownSectionKey": {
+			name:        "config.yaml",
+			content:     "storage: memory\ndb:\n  urll: postgres://localhost\n",
+			expectedErr: `unknown key "db.urll"`,
+		},
+		"UnknownTOMLKey": {
+			name:        "config.toml",
+			content:     "storage = \"memory\"\n[log]\nformatt = \"text\"\n",
+			expectedErr: `unknown key "log.formatt"`,
+		},
+		"UnsupportedExtension": {
+			name:        "config.json",
+			content:     "{}",
+			expectedErr: "unsupported extension",
+		},
+		"Malformed": {
+			name:        "config.yaml",
+			content:     "storage: [memory\n",
+			expectedErr: "failed to parse config file",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			clearEnv(t)
+			path := writeConfigFile(t, tc.name, tc.content)
+
+			// Act
+			_, err := Load([]string{"-config", path, "-storage", StorageMemory})
+
+			// Assert
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedErr)
+		})
+	}
+}
+
+func TestLoad_CityThresholdsFromFile(t *testing.T) {
+	cases := map[string]struct {
+		name               string
+		content            string
+		expectedThresholds map[string]time.Duration
+		expectedErr        string
+	}{
+		"YAML": {
+			name:               "config.yaml",
+			content:            "stale_reception:\n  city_thresholds:\n    Москва: 10h\n    Казань: 8h\n",
+			expectedThresholds: map[string]time.Duration{"Москва": 10 * time.Hour, "Казань": 8 * time.Hour},
+		},
+		"TOML": {
+			name:               "config.toml",
+			content:            "[stale_reception.city_thresholds]\n\"Москва\" = \"10h\"\n\"Казань\" = \"8h\"\n",
+			expectedThresholds: map[string]time.Duration{"Москва": 10 * time.Hour, "Казань": 8 * time.Hour},
+		},
+		"InvalidDuration": {
+			name:        "config.yaml",
+			content:     "stale_reception:\n  city_thresholds:\n    Москва: soon\n",
+			expectedErr: `entry "Москва=soon" has an invalid duration`,
+		},
+		"NotPositive": {
+			name:        "config.toml",
+			content:     "[stale_reception.city_thresholds]\n\"Казань\" = \"0s\"\n",
+			expectedErr: "stale_reception.city_thresholds for Казань must be positive",
+		},
+		"UnknownCity": {
+			name:        "config.yaml",
+			content:     "stale_reception:\n  city_thresholds:\n    Moskva: 10h\n",
+			expectedErr: "stale_reception.city_thresholds: unknown city Moskva",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			clearEnv(t)
+			t.Setenv("JWT_SECRET", "secret")
+			path := writeConfigFile(t, tc.name, tc.content)
+
+			// Act
+			cfg, err := Load([]string{"-config", path, "-storage", StorageMemory}, ServerREST)
+
+			// Assert
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedThresholds, cfg.StaleReceptionCityThresholds)
+		})
+	}
+}
+
+func TestLoad_DrainDelay(t *testing.T) {
+	cases := map[string]struct {
+		drainDelay  string
+		expectedErr bool
+	}{
+		"Zero":                {drainDelay: "0s"},
+		"ShorterThanShutdown": {drainDelay: "9s"},
+		"Negative":            {drainDelay: "-1s", expectedErr: true},
+		"EqualToShutdown":     {drainDelay: "10s", expectedErr: true},
+		"LongerThanShutdown":  {drainDelay: "1m", expectedErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			clearEnv(t)
+			args := []string{"-storage", StorageMemory, "-shutdown-timeout", "10s", "-drain-delay", tc.drainDelay}
+
+			// Act
+			_, err := Load(args)
+
+			// Assert
+			if tc.expectedErr {
+				assert.EqualError(t, err, "drain_delay must not be negative and must be shorter than shutdown_timeout")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoad_ServerNeedsTwoConnections(t *testing.T) {
+	cases := map[string]struct {
+		servers     []Server
+		expectedErr bool
+	}{
+		"Command": {},
+		"GRPC":    {servers: []Server{ServerGRPC}, expectedErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			clearEnv(t)
+			args := []string{"-storage", StoragePostgres, "-db-url", "postgres://localhost/orders", "-db-max-open-conns", "1", "-db-min-conns", "0"}
+
+			// Act
+			_, err := Load(args, tc.servers...)
+
+			// Assert
+			if tc.expectedErr {
+				assert.EqualError(t, err, "db.max_open_conns must be at least 2 to serve, got 1")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// loadFile applies the settings of a YAML or TOML file, chosen by extension.
+// Sections of the file map to the dotted option keys, e.g. max_open_conns in
+// the db section sets db.max_open_conns.
+func loadFile(cfg *Config, path string) []error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return []error{fmt.Errorf("failed to read config file: %w", err)}
+	}
+
+	values := make(map[string]any)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".toml":
+		err = toml.Unmarshal(data, &values)
+	default:
+		return []error{fmt.Errorf("config file %s: unsupported extension, use .yaml, .yml or .toml", path)}
+	}
+	if err != nil {
+		return []error{fmt.Errorf("failed to parse config file %s: %w", path, err)}
+	}
+
+	var errs []error
+	applyFileValues(cfg, path, "", values, &errs)
+	return errs
+}
+
+func applyFileValues(cfg *Config, path, prefix string, values map[string]any, errs *[]error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fullKey := prefix + key
+		value := values[key]
+		opt := findOption(fullKey)
+
+		section, isSection := value.(map[string]any)
+		list, isList := value.([]any)
+		switch {
+		case opt != nil && isSection:
+			// Only stale_reception.city_thresholds is a map-valued option.
+			applyFileValue(cfg, path, opt, formatDurationMap(section), errs)
+		case opt != nil && isList:
+			// Only db.replica_urls is a list-valued option.
+			applyFileValue(cfg, path, opt, formatList(list), errs)
+		case opt != nil:
+			applyFileValue(cfg, path, opt, fmt.Sprint(value), errs)
+		case isSection:
+			applyFileValues(cfg, path, fullKey+".", section, errs)
+		default:
+			*errs = append(*errs, fmt.Errorf("config file %s: unknown key %q", path, fullKey))
+		}
+	}
+}
+
+func applyFileValue(cfg *Config, path string, opt *option, value string, errs *[]error) {
+	if err := opt.set(cfg, value); err != nil {
+		*errs = append(*errs, &valueError{source: "config file " + path + " key " + opt.key, value: value, err: err})
+	}
+}
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// option binds one setting to its key in the configuration file, its
+// environment variable and its command line flag.
+type option struct {
+	key     string
+	env     string
+	usage   string
+	set     func(cfg *Config, value string) error
+	boolean bool
+}
+
+// flagName turns a file key such as "db.max_open_conns" into "db-max-open-conns".
+func (o *option) flagName() string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(o.key)
+}
+
+var options = []option{
+	{"storage", "STORAGE", "where data is kept: postgres or memory", stringSetter(func(c *Config) *string { return &c.Storage }), false},
+	{"db.url", "DB_URL", "Postgres connection URL", stringSetter(func(c *Config) *string { return &c.DbUrl }), false},
+	{"db.replica_urls", "DB_REPLICA_URLS", "Postgres read replica URLs separated by semicolons", stringListSetter(func(c *Config) *[]string { return &c.DBReplicaUrls }), false},
+	{"db.replica_max_lag", "DB_REPLICA_MAX_LAG", "how long after a PVZ cache invalidation cache fills read from the primary", durationSetter(func(c *Config) *time.Duration { return &c.DBReplicaMaxLag }), false},
+	{"db.max_open_conns", "DB_MAX_OPEN_CONNS", "maximum number of open database connections", intSetter(func(c *Config) *int { return &c.DBMaxOpenConns }), false},
+	{"db.min_conns", "DB_MIN_CONNS", "number of database connections kept open when idle", intSetter(func(c *Config) *int { return &c.DBMinConns }), false},
+	{"db.conn_max_lifetime", "DB_CONN_MAX_LIFETIME", "maximum lifetime of a database connection, 0 for unlimited", durationSetter(func(c *Config) *time.Duration { return &c.DBConnMaxLifetime }), false},
+	{"db.conn_max_idle_time", "DB_CONN_MAX_IDLE_TIME", "maximum idle time of a database connection, 0 for unlimited", durationSetter(func(c *Config) *time.Duration { return &c.DBConnMaxIdleTime }), false},
+	{"db.statement_timeout", "DB_STATEMENT_TIMEOUT", "maximum duration of a database statement, 0 for unlimited", durationSetter(func(c *Config) *time.Duration { return &c.DBStatementTimeout }), false},
+	{"db.connect_wait", "DB_CONNECT_WAIT", "how long to retry connecting to the database on start, 0 for a single attempt", durationSetter(func(c *Config) *time.Duration { return &c.DBConnectWait }), false},
+	{"db.breaker_failures", "DB_BREAKER_FAILURES", "consecutive connection failures that open the database circuit breaker", intSetter(func(c *Config) *int { return &c.DBBreakerFailures }), false},
+	{"db.breaker_open_timeout", "DB_BREAKER_OPEN_TIMEOUT", "how long the open database circuit breaker rejects calls before a probe", durationSetter(func(c *Config) *time.Duration { return &c.DBBreakerOpenTimeout }), false},
+	{"db.auto_migrate", "DB_AUTO_MIGRATE", "apply pending migrations on start", boolSetter(func(c *Config) *bool { return &c.DBAutoMigrate }), true},
+	{"jwt.secret", "JWT_SECRET", "secret used to sign JWT tokens", stringSetter(func(c *Config) *string { return &c.JWTSecret }), false},
+	{"jwt.token_ttl", "TOKEN_TTL", "lifetime of issued JWT tokens", durationSetter(func(c *Config) *time.Duration { return &c.TokenTTL }), false},
+	{"rest.port", "REST_PORT", "HTTP server port", stringSetter(func(c *Config) *string { return &c.RestPort }), false},
+	{"rest.read_timeout", "HTTP_READ_TIMEOUT", "timeout for reading an HTTP request, 0 for none", durationSetter(func(c *Config) *time.Duration { return &c.HTTPReadTimeout }), false},
+	{"rest.write_timeout", "HTTP_WRITE_TIMEOUT", "timeout for writing an HTTP response, 0 for none", durationSetter(func(c *Config) *time.Duration { return &c.HTTPWriteTimeout }), false},
+	{"rest.idle_timeout", "HTTP_IDLE_TIMEOUT", "timeout for idle keep-alive HTTP connections, 0 for none", durationSetter(func(c *Config) *time.Duration { return &c.HTTPIdleTimeout }), false},
+	{"grpc.port", "GRPC_PORT", "gRPC server port", stringSetter(func(c *Config) *string { return &c.GrpcPort }), false},
+	{"metrics.port", "PROMETHEUS_PORT", "metrics server port", stringSetter(func(c *Config) *string { return &c.PrometheusPort }), false},
+	{"shutdown_timeout", "SHUTDOWN_TIMEOUT", "time allowed for graceful shutdown", durationSetter(func(c *Config) *time.Duration { return &c.ShutdownTimeout }), false},
+	{"drain_delay", "DRAIN_DELAY", "time to keep serving after readiness starts failing on shutdown", durationSetter(func(c *Config) *time.Duration { return &c.DrainDelay }), false},
+	{"stale_reception.check_interval", "STALE_RECEPTION_CHECK_INTERVAL", "interval between stale reception checks", durationSetter(func(c *Config) *time.Duration { return &c.StaleReceptionCheckInterval }), false},
+	{"stale_reception.threshold", "STALE_RECEPTION_THRESHOLD", "default age after which a reception is stale", durationSetter(func(c *Config) *time.Duration { return &c.StaleReceptionThreshold }), false},
+	{"stale_reception.city_thresholds", "STALE_RECEPTION_CITY_THRESHOLDS", "stale thresholds by city, e.g. Москва=10h;Казань=8h", durationMapSetter(func(c *Config) *map[string]time.Duration { return &c.StaleReceptionCityThresholds }), false},
+	{"stale_reception.action", "STALE_RECEPTION_ACTION", "action for stale receptions: flag or close", stringSetter(func(c *Config) *string { return &c.StaleReceptionAction }), false},
+	{"cache.pvz_size", "PVZ_CACHE_SIZE", "size of each PVZ cache, 0 disables caching", intSetter(func(c *Config) *int { return &c.PVZCacheSize }), false},
+	{"cache.pvz_ttl", "PVZ_CACHE_TTL", "lifetime of a PVZ cache entry", durationSetter(func(c *Config) *time.Duration { return &c.PVZCacheTTL }), false},
+	{"events.notify_channel", "NOTIFY_CHANNEL", "Postgres LISTEN/NOTIFY channel for events", stringSetter(func(c *Config) *string { return &c.NotifyChannel }), false},
+	{"log.format", "LOG_FORMAT", "log format: json or text", stringSetter(func(c *Config) *string { return &c.LogFormat }), false},
+	{"log.level", "LOG_LEVEL", "log level: debug, info, warn or error", stringSetter(func(c *Config) *string { return &c.LogLevel }), false},
+	{"tracing.exporter", "TRACING_EXPORTER", "trace exporter: none, stdout or otlp", stringSetter(func(c *Config) *string { return &c.TracingExporter }), false},
+}
+
+func findOption(key string) *option {
+	for i := range options {
+		if options[i].key == key {
+			return &options[i]
+		}
+	}
+	return nil
+}
+
+func stringSetter(field func(*Config) *string) func(*Config, string) error {
+	return func(cfg *Config, value string) error {
+		*field(cfg) = value
+		return nil
+	}
+}
+
+func boolSetter(field func(*Config) *bool) func(*Config, string) error {
+	return func(cfg *Config, value string) error {
+		enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("not a boolean")
+		}
+		*field(cfg) = enabled
+		return nil
+	}
+}
+
+func intSetter(field func(*Config) *int) func(*Config, string) error {
+	return func(cfg *Config, value string) error {
+		number, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("not an integer")
+		}
+		*field(cfg) = number
+		return nil
+	}
+}
+
+func durationSetter(field func(*Config) *time.Duration) func(*Config, string) error {
+	return func(cfg *Config, value string) error {
+		duration, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("not a duration")
+		}
+		*field(cfg) = duration
+		return nil
+	}
+}
+
+// stringListSetter parses values separated by semicolons, since commas may
+// appear in Postgres URLs.
+func stringListSetter(field func(*Config) *[]string) func(*Config, string) error {
+	return func(cfg *Config, value string) error {
+		var values []string
+		for _, item := range strings.Split(value, ";") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		*field(cfg) = values
+		return nil
+	}
+}
+
+// durationMapSetter parses "name=duration" pairs separated by semicolons.
+func durationMapSetter(field func(*Config) *map[string]time.Duration) func(*Config, string) error {
+	return func(cfg *Config, value string) error {
+		durations := make(map[string]time.Duration)
+		for _, pair := range strings.Split(value, ";") {
+			if strings.TrimSpace(pair) == "" {
+				continue
+			}
+			name, rawDuration, ok := strings.Cut(pair, "=")
+			if !ok || strings.TrimSpace(name) == "" {
+				return fmt.Errorf("entry %q is not name=duration", pair)
+			}
+			duration, err := time.ParseDuration(strings.TrimSpace(rawDuration))
+			if err != nil {
+				return fmt.Errorf("entry %q has an invalid duration", pair)
+			}
+			durations[strings.TrimSpace(name)] = duration
+		}
+		*field(cfg) = durations
+		return nil
+	}
+}
+
+// formatDurationMap is the inverse of durationMapSetter for maps read from a
+// configuration file.
+func formatDurationMap(values map[string]any) string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", name, values[name]))
+	}
+	return strings.Join(pairs, ";")
+}
+
+// formatList is the inverse of stringListSetter for lists read from a
+// configuration file.
+func formatList(values []any) string {
+	items := make([]string, 0, len(values))
+	for _, value := range values {
+		items = append(items, fmt.Sprint(value))
+	}
+	return strings.Join(items, ";")
+}
+
+type valueError struct {
+	source string
+	value  string
+	err    error
+}
+
+func (ve *valueError) Error() string {
+	return fmt.Sprintf("%s: invalid value %q: %v", ve.source, ve.value, ve.err)
+}
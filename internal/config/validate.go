@@ -0,0 +1,127 @@
+package config
+
+import (
+	"fmt"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/logger"
+	"github.com/ners1us/order-service/internal/tracing"
+	"log/slog"
+	"slices"
+	"strconv"
+)
+
+func (c *Config) validate(servers []Server) []error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	switch c.Storage {
+	case StoragePostgres:
+		if c.DbUrl == "" {
+			fail("db.url (DB_URL) is required")
+		}
+	case StorageMemory:
+	default:
+		fail("storage must be %s or %s, got %q", StoragePostgres, StorageMemory, c.Storage)
+	}
+	if c.DBReplicaMaxLag < 0 {
+		fail("db.replica_max_lag must not be negative")
+	}
+	if c.DBMaxOpenConns < 1 {
+		fail("db.max_open_conns must be at least 1, got %d", c.DBMaxOpenConns)
+	}
+	if len(servers) > 0 && c.Storage == StoragePostgres && c.DBMaxOpenConns < 2 {
+		// The notification listener of a server holds one connection for good.
+		fail("db.max_open_conns must be at least 2 to serve, got %d", c.DBMaxOpenConns)
+	}
+	if c.DBMinConns < 0 || c.DBMinConns > c.DBMaxOpenConns {
+		fail("db.min_conns must be between 0 and db.max_open_conns, got %d", c.DBMinConns)
+	}
+	if c.DBConnMaxLifetime < 0 {
+		fail("db.conn_max_lifetime must not be negative")
+	}
+	if c.DBConnMaxIdleTime < 0 {
+		fail("db.conn_max_idle_time must not be negative")
+	}
+	if c.DBStatementTimeout < 0 {
+		fail("db.statement_timeout must not be negative")
+	}
+	if c.DBConnectWait < 0 {
+		fail("db.connect_wait must not be negative")
+	}
+	if c.DBBreakerFailures < 1 {
+		fail("db.breaker_failures must be at least 1, got %d", c.DBBreakerFailures)
+	}
+	if c.DBBreakerOpenTimeout <= 0 {
+		fail("db.breaker_open_timeout must be positive")
+	}
+	errs = append(errs, validatePort("metrics.port", c.PrometheusPort)...)
+	if c.ShutdownTimeout <= 0 {
+		fail("shutdown_timeout must be positive")
+	}
+	if c.DrainDelay < 0 || c.DrainDelay >= c.ShutdownTimeout {
+		fail("drain_delay must not be negative and must be shorter than shutdown_timeout")
+	}
+	if c.PVZCacheSize < 0 {
+		fail("cache.pvz_size must not be negative, got %d", c.PVZCacheSize)
+	}
+	if c.PVZCacheTTL <= 0 {
+		fail("cache.pvz_ttl must be positive")
+	}
+	if c.NotifyChannel == "" {
+		fail("events.notify_channel must not be empty")
+	}
+	if c.LogFormat != logger.FormatJSON && c.LogFormat != logger.FormatText {
+		fail("log.format must be %s or %s, got %q", logger.FormatJSON, logger.FormatText, c.LogFormat)
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		fail("log.level must be debug, info, warn or error, got %q", c.LogLevel)
+	}
+	if !slices.Contains([]string{tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP}, c.TracingExporter) {
+		fail("tracing.exporter must be %s, %s or %s, got %q", tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP, c.TracingExporter)
+	}
+
+	if slices.Contains(servers, ServerREST) {
+		if c.JWTSecret == "" {
+			fail("jwt.secret (JWT_SECRET) is required")
+		}
+		if c.TokenTTL <= 0 {
+			fail("jwt.token_ttl must be positive")
+		}
+		errs = append(errs, validatePort("rest.port", c.RestPort)...)
+		if c.HTTPReadTimeout < 0 || c.HTTPWriteTimeout < 0 || c.HTTPIdleTimeout < 0 {
+			fail("rest timeouts must not be negative")
+		}
+		if c.StaleReceptionCheckInterval <= 0 {
+			fail("stale_reception.check_interval must be positive")
+		}
+		if c.StaleReceptionThreshold <= 0 {
+			fail("stale_reception.threshold must be positive")
+		}
+		for city, threshold := range c.StaleReceptionCityThresholds {
+			if !enum.IsValidCity(enum.City(city)) {
+				fail("stale_reception.city_thresholds: unknown city %s", city)
+			}
+			if threshold <= 0 {
+				fail("stale_reception.city_thresholds for %s must be positive", city)
+			}
+		}
+		if !enum.IsValidEscalationAction(enum.EscalationAction(c.StaleReceptionAction)) {
+			fail("stale_reception.action must be flag or close, got %q", c.StaleReceptionAction)
+		}
+	}
+	if slices.Contains(servers, ServerGRPC) {
+		errs = append(errs, validatePort("grpc.port", c.GrpcPort)...)
+	}
+	return errs
+}
+
+func validatePort(key, port string) []error {
+	number, err := strconv.Atoi(port)
+	if err != nil || number < 1 || number > 65535 {
+		return []error{fmt.Errorf("%s must be a port number between 1 and 65535, got %q", key, port)}
+	}
+	return nil
+}
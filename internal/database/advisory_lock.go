@@ -0,0 +1,26 @@
+package database
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func WithAdvisoryLock(ctx context.Context, db *pgxpool.Pool, key int64, fn func() error) (bool, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var acquired bool
+	if err := tx.QueryRow(ctx, "SELECT pg_try_advisory_xact_lock($1)", key).Scan(&acquired); err != nil {
+		return false, err
+	}
+	if !acquired {
+		return false, nil
+	}
+	if err := fn(); err != nil {
+		return true, err
+	}
+	return true, tx.Commit(ctx)
+}
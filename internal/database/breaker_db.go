@@ -0,0 +1,156 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ners1us/order-service/internal/breaker"
+	"io"
+	"net"
+)
+
+type breakerDB struct {
+	DB
+	breaker *breaker.Breaker
+}
+
+// NewBreakerDB guards db with b: while the breaker is open every call fails
+// with breaker.ErrOpen without touching the database. Only errors meaning the
+// database is unreachable count as failures.
+func NewBreakerDB(db DB, b *breaker.Breaker) DB {
+	return &breakerDB{db, b}
+}
+
+func (bd *breakerDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if err := bd.breaker.Allow(); err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	tag, err := bd.DB.Exec(ctx, sql, args...)
+	bd.record(err)
+	return tag, err
+}
+
+func (bd *breakerDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if err := bd.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	rows, err := bd.DB.Query(ctx, sql, args...)
+	bd.record(err)
+	return rows, err
+}
+
+func (bd *breakerDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if err := bd.breaker.Allow(); err != nil {
+		return errRow{err}
+	}
+	return &breakerRow{bd.DB.QueryRow(ctx, sql, args...), bd}
+}
+
+func (bd *breakerDB) SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults {
+	if err := bd.breaker.Allow(); err != nil {
+		return errBatchResults{err}
+	}
+	return &breakerBatchResults{bd.DB.SendBatch(ctx, batch), bd, false}
+}
+
+func (bd *breakerDB) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	if err := bd.breaker.Allow(); err != nil {
+		return 0, err
+	}
+	count, err := bd.DB.CopyFrom(ctx, tableName, columnNames, rowSrc)
+	bd.record(err)
+	return count, err
+}
+
+// Begin guards only the start of a transaction: once it began, its statements
+// go to the same connection.
+func (bd *breakerDB) Begin(ctx context.Context) (pgx.Tx, error) {
+	if err := bd.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	tx, err := bd.DB.Begin(ctx)
+	bd.record(err)
+	return tx, err
+}
+
+func (bd *breakerDB) record(err error) {
+	bd.breaker.Record(err, IsUnavailable(err))
+}
+
+// IsUnavailable reports whether err means the database could not be reached
+// or refused to serve, as opposed to rejecting a particular statement.
+func IsUnavailable(err error) bool {
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	var pgErr *pgconn.PgError
+	switch {
+	case err == nil:
+		return false
+	case errors.As(err, &connectErr), errors.As(err, &netErr),
+		errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return true
+	case errors.As(err, &pgErr):
+		// Class 08 is connection exceptions; 53300 is too many connections and
+		// 57P01..57P03 are server shutdown and startup.
+		return pgErr.Code[:2] == "08" || pgErr.Code == "53300" ||
+			pgErr.Code == "57P01" || pgErr.Code == "57P02" || pgErr.Code == "57P03"
+	default:
+		return false
+	}
+}
+
+type breakerRow struct {
+	row pgx.Row
+	bd  *breakerDB
+}
+
+func (br *breakerRow) Scan(dest ...any) error {
+	err := br.row.Scan(dest...)
+	br.bd.record(err)
+	return err
+}
+
+// breakerBatchResults records the outcome of the batch once it is closed.
+type breakerBatchResults struct {
+	pgx.BatchResults
+	bd     *breakerDB
+	closed bool
+}
+
+func (bbr *breakerBatchResults) Close() error {
+	err := bbr.BatchResults.Close()
+	if !bbr.closed {
+		bbr.closed = true
+		bbr.bd.record(err)
+	}
+	return err
+}
+
+type errRow struct {
+	err error
+}
+
+func (er errRow) Scan(dest ...any) error {
+	return er.err
+}
+
+type errBatchResults struct {
+	err error
+}
+
+func (ebr errBatchResults) Exec() (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, ebr.err
+}
+
+func (ebr errBatchResults) Query() (pgx.Rows, error) {
+	return nil, ebr.err
+}
+
+func (ebr errBatchResults) QueryRow() pgx.Row {
+	return errRow{ebr.err}
+}
+
+func (ebr errBatchResults) Close() error {
+	return ebr.err
+}
@@ -0,0 +1,134 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ners1us/order-service/internal/breaker"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// failingDB answers every row and batch with err and counts the calls that
+// reach it.
+type failingDB struct {
+	DB
+	err   error
+	calls *int
+}
+
+func (fd failingDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	*fd.calls++
+	return errRow{fd.err}
+}
+
+func (fd failingDB) SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults {
+	*fd.calls++
+	return errBatchResults{fd.err}
+}
+
+func TestIsUnavailable(t *testing.T) {
+	cases := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"Nil":                 {err: nil, expected: false},
+		"Statement":           {err: errors.New("syntax error"), expected: false},
+		"NoRows":              {err: pgx.ErrNoRows, expected: false},
+		"BreakerOpen":         {err: breaker.ErrOpen, expected: false},
+		"Canceled":            {err: context.Canceled, expected: false},
+		"ConnectError":        {err: &pgconn.ConnectError{}, expected: true},
+		"NetError":            {err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, expected: true},
+		"EOF":                 {err: io.EOF, expected: true},
+		"WrappedEOF":          {err: fmt.Errorf("failed to read: %w", io.ErrUnexpectedEOF), expected: true},
+		"ConnectionFailure":   {err: &pgconn.PgError{Code: "08006"}, expected: true},
+		"TooManyConnections":  {err: &pgconn.PgError{Code: "53300"}, expected: true},
+		"AdminShutdown":       {err: &pgconn.PgError{Code: "57P01"}, expected: true},
+		"CannotConnectNow":    {err: &pgconn.PgError{Code: "57P03"}, expected: true},
+		"UniqueViolation":     {err: &pgconn.PgError{Code: "23505"}, expected: false},
+		"StatementTimeout":    {err: &pgconn.PgError{Code: "57014"}, expected: false},
+		"WrappedUnavailable":  {err: fmt.Errorf("failed to list pvzs: %w", &pgconn.PgError{Code: "08001"}), expected: true},
+		"WrappedStatementErr": {err: fmt.Errorf("failed to list pvzs: %w", &pgconn.PgError{Code: "42P01"}), expected: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			result := IsUnavailable(tc.err)
+
+			// Assert
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestBreakerDB_QueryRowRecordsOnScan(t *testing.T) {
+	cases := map[string]struct {
+		err           error
+		expectedState breaker.State
+	}{
+		"UnavailableOpensBreaker":     {err: io.EOF, expectedState: breaker.StateOpen},
+		"StatementErrorKeepsItClosed": {err: &pgconn.PgError{Code: "23505"}, expectedState: breaker.StateClosed},
+		"NoRowsKeepsItClosed":         {err: pgx.ErrNoRows, expectedState: breaker.StateClosed},
+		"CanceledKeepsItClosed":       {err: context.Canceled, expectedState: breaker.StateClosed},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			var calls int
+			b := breaker.New("test-"+name, 1, time.Minute)
+			db := NewBreakerDB(failingDB{err: tc.err, calls: &calls}, b)
+			row := db.QueryRow(context.Background(), "SELECT 1")
+			assert.Equal(t, breaker.StateClosed, b.State())
+
+			// Act
+			err := row.Scan()
+
+			// Assert
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.expectedState, b.State())
+		})
+	}
+}
+
+func TestBreakerDB_OpenBreakerRejectsWithoutCallingTheDatabase(t *testing.T) {
+	// Arrange
+	var calls int
+	b := breaker.New("test-rejects", 1, time.Minute)
+	db := NewBreakerDB(failingDB{err: io.EOF, calls: &calls}, b)
+	openBreaker(b)
+
+	// Act
+	rowErr := db.QueryRow(context.Background(), "SELECT 1").Scan()
+	batchErr := db.SendBatch(context.Background(), &pgx.Batch{}).Close()
+
+	// Assert
+	assert.ErrorIs(t, rowErr, breaker.ErrOpen)
+	assert.ErrorIs(t, batchErr, breaker.ErrOpen)
+	assert.Zero(t, calls)
+}
+
+func TestBreakerDB_BatchRecordsOnceOnClose(t *testing.T) {
+	// Arrange
+	var calls int
+	b := breaker.New("test-batch", 2, time.Minute)
+	db := NewBreakerDB(failingDB{err: io.EOF, calls: &calls}, b)
+	first := db.SendBatch(context.Background(), &pgx.Batch{})
+
+	// Act
+	_ = first.Close()
+	_ = first.Close()
+	stateAfterFirstBatch := b.State()
+	_ = db.SendBatch(context.Background(), &pgx.Batch{}).Close()
+
+	// Assert
+	assert.Equal(t, breaker.StateClosed, stateAfterFirstBatch)
+	assert.Equal(t, breaker.StateOpen, b.State())
+	assert.Equal(t, 2, calls)
+}
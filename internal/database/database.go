@@ -1,17 +1,117 @@
 package database
 
 import (
-	"database/sql"
-	_ "github.com/lib/pq"
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"log/slog"
+	"strconv"
+	"time"
 )
 
-func NewDB(dataSourceStr string) (*sql.DB, error) {
-	db, err := sql.Open("postgres", dataSourceStr)
+const (
+	connectRetryMinDelay = 250 * time.Millisecond
+	connectRetryMaxDelay = 5 * time.Second
+)
+
+// Reader is the part of DB that only reads, which is all a replica serves.
+type Reader interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
+}
+
+// DB is the part of *pgxpool.Pool the repositories use, so that the pool can
+// be wrapped.
+type DB interface {
+	Reader
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// unlimited stands for a zero lifetime or idle time, which pgx would otherwise
+// take as expiring connections right away.
+const unlimited = 100 * 365 * 24 * time.Hour
+
+// PoolConfig sizes the pool returned by NewPool. A zero ConnMaxLifetime,
+// ConnMaxIdleTime or StatementTimeout means no limit; a zero ConnectWait
+// gives the database a single attempt.
+type PoolConfig struct {
+	MaxConns         int
+	MinConns         int
+	ConnMaxLifetime  time.Duration
+	ConnMaxIdleTime  time.Duration
+	StatementTimeout time.Duration
+	ConnectWait      time.Duration
+}
+
+// NewPool opens a pgx pool whose queries, batches and copies are traced as
+// children of the span in the context passed to them. Statements are prepared
+// on first use and cached per connection. Until the database answers, NewPool
+// retries with exponential backoff for up to pool.ConnectWait, so that the
+// service may start before the database.
+func NewPool(ctx context.Context, dataSourceStr string, pool PoolConfig) (*pgxpool.Pool, error) {
+	db, err := NewLazyPool(ctx, dataSourceStr, pool)
 	if err != nil {
 		return nil, err
 	}
-	if err = db.Ping(); err != nil {
+	if err = waitForDB(ctx, db, pool.ConnectWait); err != nil {
+		db.Close()
 		return nil, err
 	}
 	return db, nil
 }
+
+// NewLazyPool opens a pool like NewPool without waiting for the database:
+// connections are made on first use, and only an invalid configuration fails.
+func NewLazyPool(ctx context.Context, dataSourceStr string, pool PoolConfig) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(dataSourceStr)
+	if err != nil {
+		return nil, err
+	}
+	config.MaxConns = int32(pool.MaxConns)
+	config.MinConns = int32(pool.MinConns)
+	config.MaxConnLifetime = orUnlimited(pool.ConnMaxLifetime)
+	config.MaxConnIdleTime = orUnlimited(pool.ConnMaxIdleTime)
+	if pool.StatementTimeout > 0 {
+		config.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(pool.StatementTimeout.Milliseconds(), 10)
+	}
+	config.ConnConfig.Tracer = queryTracer{}
+	return pgxpool.NewWithConfig(ctx, config)
+}
+
+func waitForDB(ctx context.Context, db *pgxpool.Pool, wait time.Duration) error {
+	deadline := time.Now().Add(wait)
+	delay := connectRetryMinDelay
+	for attempt := 1; ; attempt++ {
+		err := db.Ping(ctx)
+		if err == nil {
+			return nil
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return err
+		}
+		delay = min(delay, remaining)
+		slog.Warn("database is not ready, retrying",
+			slog.Int("attempt", attempt),
+			slog.Duration("retry_in", delay),
+			slog.Any("error", err),
+		)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, connectRetryMaxDelay)
+	}
+}
+
+func orUnlimited(d time.Duration) time.Duration {
+	if d == 0 {
+		return unlimited
+	}
+	return d
+}
@@ -0,0 +1,173 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/ners1us/order-service/migrations"
+	"io"
+	"io/fs"
+	"strconv"
+)
+
+// NewMigrator returns a golang-migrate instance that applies the migrations
+// embedded in the binary to db. golang-migrate works on database/sql, so it
+// gets a wrapper borrowing connections of db; Close releases the wrapper and
+// db itself stays open.
+func NewMigrator(ctx context.Context, db *pgxpool.Pool) (*migrate.Migrate, error) {
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+	sqlDB := stdlib.OpenDBFromPool(db)
+	driver, err := postgres.WithInstance(sqlDB, &postgres.Config{})
+	if err != nil {
+		sqlDB.Close()
+		source.Close()
+		return nil, fmt.Errorf("failed to create postgres driver: %w", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", source, "postgres", driver)
+	if err != nil {
+		driver.Close()
+		sqlDB.Close()
+		source.Close()
+		return nil, fmt.Errorf("failed to create migrator: %w", err)
+	}
+	return m, nil
+}
+
+// SchemaVersion returns the version of the newest embedded migration, which is
+// the schema version this build expects.
+func SchemaVersion() (uint, error) {
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+	defer source.Close()
+
+	version, err := source.First()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+	for {
+		next, err := source.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read embedded migrations: %w", err)
+		}
+		version = next
+	}
+}
+
+// CheckSchemaVersion fails unless the schema of db is clean and migrated at
+// least to expected. A newer schema is accepted so that a rolled back binary
+// keeps serving after a rollout applied additive migrations.
+func CheckSchemaVersion(ctx context.Context, db *pgxpool.Pool, expected uint) error {
+	var version uint
+	var dirty bool
+	err := db.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) || isUndefinedTable(err) {
+		return fmt.Errorf("database schema is not migrated, version %d is required", expected)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("database schema version %d is dirty, fix it and run migrate force", version)
+	}
+	if version < expected {
+		return fmt.Errorf("database schema version %d is behind required version %d, run migrate up", version, expected)
+	}
+	return nil
+}
+
+// RunMigrateCommand executes a migrate subcommand: up, down [N], status or
+// force VERSION. down reverts one migration unless N is given.
+func RunMigrateCommand(ctx context.Context, db *pgxpool.Pool, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: migrate up | down [N] | status | force VERSION")
+	}
+	m, err := NewMigrator(ctx, db)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	switch command := args[0]; command {
+	case "up":
+		err = m.Up()
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of migrations to revert: %q", args[1])
+			}
+		}
+		err = m.Steps(-steps)
+	case "status":
+		return printMigrationStatus(m, out)
+	case "force":
+		if len(args) < 2 {
+			return errors.New("usage: migrate force VERSION")
+		}
+		version, convErr := strconv.Atoi(args[1])
+		if convErr != nil {
+			return fmt.Errorf("invalid version: %q", args[1])
+		}
+		err = m.Force(version)
+	default:
+		return fmt.Errorf("unknown migrate command %q", command)
+	}
+	if errors.Is(err, migrate.ErrNoChange) {
+		fmt.Fprintln(out, "no change")
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+	return printMigrationStatus(m, out)
+}
+
+// MigrateUp applies all pending embedded migrations.
+func MigrateUp(ctx context.Context, db *pgxpool.Pool) error {
+	m, err := NewMigrator(ctx, db)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+func printMigrationStatus(m *migrate.Migrate, out io.Writer) error {
+	expected, err := SchemaVersion()
+	if err != nil {
+		return err
+	}
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		fmt.Fprintf(out, "version: none, expected: %d\n", expected)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "version: %d, dirty: %t, expected: %d\n", version, dirty, expected)
+	return nil
+}
+
+func isUndefinedTable(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "42P01"
+}
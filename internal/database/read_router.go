@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/ners1us/order-service/internal/breaker"
+	"sync/atomic"
+	"time"
+)
+
+// Replica is a read-only database and the breaker guarding it.
+type Replica struct {
+	DB      DB
+	Breaker *breaker.Breaker
+}
+
+type readRouter struct {
+	primary  Reader
+	replicas []Reader
+	breakers []*breaker.Breaker
+	next     atomic.Uint64
+}
+
+type primaryKey struct{}
+
+// WithPrimary returns a context whose reads go to the primary even through a
+// read router, for reads that must not see a lagging replica.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// UsesPrimary reports whether ctx was returned by WithPrimary.
+func UsesPrimary(ctx context.Context) bool {
+	usesPrimary, _ := ctx.Value(primaryKey{}).(bool)
+	return usesPrimary
+}
+
+// WithPrimaryIfRecent returns WithPrimary(ctx) while less than lag has passed
+// since changedAt, as replicas may not have caught up with that change yet,
+// and ctx otherwise.
+func WithPrimaryIfRecent(ctx context.Context, changedAt time.Time, lag time.Duration) context.Context {
+	if time.Since(changedAt) < lag {
+		return WithPrimary(ctx)
+	}
+	return ctx
+}
+
+// NewReadRouter spreads reads over replicas in turn. A replica whose breaker
+// is open is skipped, and reads go to primary while no replica is ready or
+// the context comes from WithPrimary. Without replicas it returns primary
+// itself. Replicas may lag behind the primary, so reads that must see the
+// caller's own writes belong on primary.
+func NewReadRouter(primary Reader, replicas []Replica) Reader {
+	if len(replicas) == 0 {
+		return primary
+	}
+	rr := &readRouter{primary: primary}
+	for _, replica := range replicas {
+		rr.replicas = append(rr.replicas, NewBreakerDB(replica.DB, replica.Breaker))
+		rr.breakers = append(rr.breakers, replica.Breaker)
+	}
+	return rr
+}
+
+func (rr *readRouter) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return rr.pick(ctx).Query(ctx, sql, args...)
+}
+
+func (rr *readRouter) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return rr.pick(ctx).QueryRow(ctx, sql, args...)
+}
+
+func (rr *readRouter) SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults {
+	return rr.pick(ctx).SendBatch(ctx, batch)
+}
+
+// pick returns the first ready replica starting after the one picked last,
+// or primary if none is ready or ctx asks for it.
+func (rr *readRouter) pick(ctx context.Context) Reader {
+	if UsesPrimary(ctx) {
+		return rr.primary
+	}
+	count := uint64(len(rr.replicas))
+	start := rr.next.Add(1) - 1
+	for i := range count {
+		index := (start + i) % count
+		if rr.breakers[index].Ready() {
+			return rr.replicas[index]
+		}
+	}
+	return rr.primary
+}
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/ners1us/order-service/internal/breaker"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// namedDB answers every query with no rows and records its name in calls.
+type namedDB struct {
+	DB
+	name  string
+	calls *[]string
+}
+
+func (nd namedDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	*nd.calls = append(*nd.calls, nd.name)
+	return nil, nil
+}
+
+func newTestReplica(name string, calls *[]string) Replica {
+	return Replica{DB: namedDB{name: name, calls: calls}, Breaker: breaker.New(name, 1, time.Minute)}
+}
+
+func openBreaker(b *breaker.Breaker) {
+	_ = b.Allow()
+	b.Record(errors.New("connection refused"), true)
+}
+
+func TestReadRouter_WithoutReplicasReturnsPrimary(t *testing.T) {
+	// Arrange
+	var calls []string
+	primary := namedDB{name: "primary", calls: &calls}
+
+	// Act
+	router := NewReadRouter(primary, nil)
+
+	// Assert
+	assert.Equal(t, primary, router)
+}
+
+func TestReadRouter_AlternatesReplicas(t *testing.T) {
+	// Arrange
+	var calls []string
+	router := NewReadRouter(namedDB{name: "primary", calls: &calls}, []Replica{
+		newTestReplica("replica-1", &calls),
+		newTestReplica("replica-2", &calls),
+	})
+
+	// Act
+	for range 4 {
+		_, _ = router.Query(context.Background(), "SELECT 1")
+	}
+
+	// Assert
+	assert.Equal(t, []string{"replica-1", "replica-2", "replica-1", "replica-2"}, calls)
+}
+
+func TestReadRouter_SkipsOpenReplicas(t *testing.T) {
+	// Arrange
+	var calls []string
+	first := newTestReplica("replica-1", &calls)
+	second := newTestReplica("replica-2", &calls)
+	router := NewReadRouter(namedDB{name: "primary", calls: &calls}, []Replica{first, second})
+
+	// Act
+	openBreaker(first.Breaker)
+	_, _ = router.Query(context.Background(), "SELECT 1")
+	_, _ = router.Query(context.Background(), "SELECT 1")
+	openBreaker(second.Breaker)
+	_, _ = router.Query(context.Background(), "SELECT 1")
+
+	// Assert
+	assert.Equal(t, []string{"replica-2", "replica-2", "primary"}, calls)
+}
+
+func TestReadRouter_WithPrimaryReadsPrimary(t *testing.T) {
+	// Arrange
+	var calls []string
+	router := NewReadRouter(namedDB{name: "primary", calls: &calls}, []Replica{newTestReplica("replica-1", &calls)})
+
+	// Act
+	_, _ = router.Query(WithPrimary(context.Background()), "SELECT 1")
+	_, _ = router.Query(context.Background(), "SELECT 1")
+
+	// Assert
+	assert.Equal(t, []string{"primary", "replica-1"}, calls)
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/ners1us/order-service/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+)
+
+// queryTracer opens a span for every query, batch and copy. Queries of a batch
+// are recorded as events of the batch span because they share a round-trip.
+type queryTracer struct{}
+
+func (queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, span := tracing.Start(ctx, "db.query")
+	span.SetAttributes(semconv.DBSystemNamePostgreSQL, semconv.DBQueryText(data.SQL))
+	return ctx
+}
+
+func (queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	endSpan(trace.SpanFromContext(ctx), data.Err)
+}
+
+func (queryTracer) TraceBatchStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	ctx, span := tracing.Start(ctx, "db.batch")
+	span.SetAttributes(semconv.DBSystemNamePostgreSQL, semconv.DBOperationBatchSize(data.Batch.Len()))
+	return ctx
+}
+
+func (queryTracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	attributes := []attribute.KeyValue{semconv.DBQueryText(data.SQL)}
+	if data.Err != nil {
+		attributes = append(attributes, attribute.String("error", data.Err.Error()))
+	}
+	trace.SpanFromContext(ctx).AddEvent("query", trace.WithAttributes(attributes...))
+}
+
+func (queryTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	endSpan(trace.SpanFromContext(ctx), data.Err)
+}
+
+func (queryTracer) TraceCopyFromStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
+	ctx, span := tracing.Start(ctx, "db.copy")
+	span.SetAttributes(
+		semconv.DBSystemNamePostgreSQL,
+		semconv.DBCollectionName(data.TableName.Sanitize()),
+		attribute.String("db.copy.columns", strings.Join(data.ColumnNames, ", ")),
+	)
+	return ctx
+}
+
+func (queryTracer) TraceCopyFromEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	endSpan(trace.SpanFromContext(ctx), data.Err)
+}
+
+// endSpan ends span, marking it failed unless err is nil or only reports that
+// a single-row query found nothing.
+func endSpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,27 @@
+package enum
+
+type DeletionReason string
+
+const (
+	DeletionReasonMisScan        DeletionReason = "mis_scan"
+	DeletionReasonDuplicate      DeletionReason = "duplicate"
+	DeletionReasonDamaged        DeletionReason = "damaged"
+	DeletionReasonWrongReception DeletionReason = "wrong_reception"
+	DeletionReasonOther          DeletionReason = "other"
+	// DeletionReasonLastProduct marks products removed with delete_last_product.
+	// Callers cannot give it as a reason.
+	DeletionReasonLastProduct DeletionReason = "last_product"
+)
+
+func IsValidDeletionReason(reason DeletionReason) bool {
+	switch reason {
+	case DeletionReasonMisScan, DeletionReasonDuplicate, DeletionReasonDamaged, DeletionReasonWrongReception, DeletionReasonOther:
+		return true
+	default:
+		return false
+	}
+}
+
+func (dr DeletionReason) String() string {
+	return string(dr)
+}
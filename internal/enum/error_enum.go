@@ -20,6 +20,39 @@ const (
 	ErrNoOpenReceptionToClose  ErrorType = "no open reception to close"
 	ErrInvalidStartDate        ErrorType = "invalid startDate"
 	ErrInvalidEndDate          ErrorType = "invalid endDate"
+	ErrInvalidPVZStatus        ErrorType = "invalid pvz status"
+	ErrInvalidCoordinates      ErrorType = "invalid coordinates"
+	ErrPVZNotActive            ErrorType = "pvz is not accepting receptions"
+	ErrPVZClosed               ErrorType = "pvz is closed"
+	ErrInvalidRadius           ErrorType = "invalid radius"
+	ErrInvalidLimit            ErrorType = "invalid limit"
+	ErrProductNotFound         ErrorType = "product not found"
+	ErrInvalidDeletionReason   ErrorType = "invalid deletion reason"
+	ErrReceptionNotFound       ErrorType = "reception not found"
+	ErrReceptionNotClosed      ErrorType = "only closed receptions can be reopened"
+	ErrEmptyReopenReason       ErrorType = "reopen reason is required"
+	ErrReopenRequestNotFound   ErrorType = "reopen request not found"
+	ErrReopenRequestResolved   ErrorType = "reopen request is already resolved"
+	ErrInvalidReopenStatus     ErrorType = "invalid reopen request status"
+	ErrProductTypeNotAccepted  ErrorType = "pvz does not accept this product type"
+	ErrReceptionCapacityFull   ErrorType = "reception capacity exceeded"
+	ErrProductTypeQuotaFull    ErrorType = "product type quota exceeded"
+	ErrInvalidCapacityRules    ErrorType = "invalid capacity rules"
+	ErrInvalidReportPeriod     ErrorType = "invalid report period"
+	ErrInvalidReportGroup      ErrorType = "invalid report grouping"
+	ErrInvalidIncludeDeleted   ErrorType = "invalid includeDeleted"
+	ErrInvalidDateRange        ErrorType = "startDate must be before endDate"
+	ErrInvalidExportFormat     ErrorType = "invalid export format"
+	ErrInvalidPVZID            ErrorType = "invalid pvz id"
+	ErrInvalidImportFile       ErrorType = "invalid import file"
+	ErrImportTooLarge          ErrorType = "import file has too many rows"
+	ErrInvalidRegistrationDate ErrorType = "invalid registration date"
+	ErrDuplicatePVZID          ErrorType = "duplicate pvz id in import file"
+	ErrPVZAlreadyExists        ErrorType = "pvz already exists"
+	ErrUserAlreadyExists       ErrorType = "user already exists"
+	ErrEmptyCredentials        ErrorType = "email and password are required"
+	ErrRegistrationRole        ErrorType = "only employees can register"
+	ErrNotSupportedInMemory    ErrorType = "not supported by the memory storage"
 )
 
 func (et ErrorType) Error() string {
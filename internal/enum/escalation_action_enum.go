@@ -0,0 +1,21 @@
+package enum
+
+type EscalationAction string
+
+const (
+	EscalationActionFlag  EscalationAction = "flag"
+	EscalationActionClose EscalationAction = "close"
+)
+
+func IsValidEscalationAction(action EscalationAction) bool {
+	switch action {
+	case EscalationActionFlag, EscalationActionClose:
+		return true
+	default:
+		return false
+	}
+}
+
+func (ea EscalationAction) String() string {
+	return string(ea)
+}
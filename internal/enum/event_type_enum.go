@@ -0,0 +1,17 @@
+package enum
+
+type EventType string
+
+const (
+	EventReceptionEscalated EventType = "reception_escalated"
+	EventReceptionChanged   EventType = "reception_changed"
+	EventProductsChanged    EventType = "products_changed"
+	EventPVZsChanged        EventType = "pvzs_changed"
+	// EventListenerReconnected is raised locally after the notification
+	// listener reconnects, since notifications sent meanwhile were lost.
+	EventListenerReconnected EventType = "listener_reconnected"
+)
+
+func (et EventType) String() string {
+	return string(et)
+}
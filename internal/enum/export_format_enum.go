@@ -0,0 +1,21 @@
+package enum
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatXLSX ExportFormat = "xlsx"
+)
+
+func IsValidExportFormat(format ExportFormat) bool {
+	switch format {
+	case ExportFormatCSV, ExportFormatXLSX:
+		return true
+	default:
+		return false
+	}
+}
+
+func (ef ExportFormat) String() string {
+	return string(ef)
+}
@@ -8,6 +8,17 @@ const (
 	ProductShoes       ProductType = "обувь"
 )
 
+var ProductTypes = []ProductType{ProductElectronics, ProductClothes, ProductShoes}
+
+func IsValidProductType(productType ProductType) bool {
+	switch productType {
+	case ProductElectronics, ProductClothes, ProductShoes:
+		return true
+	default:
+		return false
+	}
+}
+
 func (pr ProductType) String() string {
 	return string(pr)
 }
@@ -0,0 +1,22 @@
+package enum
+
+type PVZStatus string
+
+const (
+	PVZStatusActive    PVZStatus = "active"
+	PVZStatusSuspended PVZStatus = "suspended"
+	PVZStatusClosed    PVZStatus = "closed"
+)
+
+func IsValidPVZStatus(status PVZStatus) bool {
+	switch status {
+	case PVZStatusActive, PVZStatusSuspended, PVZStatusClosed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (ps PVZStatus) String() string {
+	return string(ps)
+}
@@ -0,0 +1,19 @@
+package enum
+
+type ReceptionEventType string
+
+const (
+	ReceptionEventOpened         ReceptionEventType = "reception_opened"
+	ReceptionEventProductAdded   ReceptionEventType = "product_added"
+	ReceptionEventProductDeleted ReceptionEventType = "product_deleted"
+	ReceptionEventClosed         ReceptionEventType = "reception_closed"
+	ReceptionEventReopened       ReceptionEventType = "reception_reopened"
+)
+
+// ReceptionEventActorScheduler is the actor of receptions closed by the stale
+// reception scheduler.
+const ReceptionEventActorScheduler = "scheduler"
+
+func (ret ReceptionEventType) String() string {
+	return string(ret)
+}
@@ -0,0 +1,22 @@
+package enum
+
+type ReopenRequestStatus string
+
+const (
+	ReopenRequestPending  ReopenRequestStatus = "pending"
+	ReopenRequestApproved ReopenRequestStatus = "approved"
+	ReopenRequestRejected ReopenRequestStatus = "rejected"
+)
+
+func IsValidReopenRequestStatus(status ReopenRequestStatus) bool {
+	switch status {
+	case ReopenRequestPending, ReopenRequestApproved, ReopenRequestRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+func (rrs ReopenRequestStatus) String() string {
+	return string(rrs)
+}
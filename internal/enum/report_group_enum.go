@@ -0,0 +1,21 @@
+package enum
+
+type ReportGroup string
+
+const (
+	ReportGroupPVZ  ReportGroup = "pvz"
+	ReportGroupCity ReportGroup = "city"
+)
+
+func IsValidReportGroup(group ReportGroup) bool {
+	switch group {
+	case ReportGroupPVZ, ReportGroupCity:
+		return true
+	default:
+		return false
+	}
+}
+
+func (rg ReportGroup) String() string {
+	return string(rg)
+}
@@ -0,0 +1,23 @@
+package enum
+
+type ReportPeriod string
+
+const (
+	ReportPeriodHour  ReportPeriod = "hour"
+	ReportPeriodDay   ReportPeriod = "day"
+	ReportPeriodWeek  ReportPeriod = "week"
+	ReportPeriodMonth ReportPeriod = "month"
+)
+
+func IsValidReportPeriod(period ReportPeriod) bool {
+	switch period {
+	case ReportPeriodHour, ReportPeriodDay, ReportPeriodWeek, ReportPeriodMonth:
+		return true
+	default:
+		return false
+	}
+}
+
+func (rp ReportPeriod) String() string {
+	return string(rp)
+}
@@ -3,8 +3,9 @@ package enum
 type Status string
 
 const (
-	StatusInProgress Status = "in_progress"
-	StatusClosed     Status = "closed"
+	StatusInProgress      Status = "in_progress"
+	StatusClosed          Status = "closed"
+	StatusReopenRequested Status = "reopen_requested"
 )
 
 func (s Status) String() string {
@@ -0,0 +1,61 @@
+package event
+
+import (
+	"errors"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"sync"
+)
+
+type Handler interface {
+	Handle(event *model.Event) error
+}
+
+type HandlerFunc func(event *model.Event) error
+
+func (hf HandlerFunc) Handle(event *model.Event) error {
+	return hf(event)
+}
+
+// Bus is an in-process Publisher that synchronously delivers every event to
+// the handlers subscribed to its type.
+type Bus interface {
+	Publisher
+	Subscribe(eventType enum.EventType, handler Handler)
+}
+
+type bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewBus() Bus {
+	return &bus{handlers: make(map[string][]Handler)}
+}
+
+func (b *bus) Subscribe(eventType enum.EventType, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType.String()] = append(b.handlers[eventType.String()], handler)
+}
+
+// SubscribeAll subscribes handler to every one of eventTypes.
+func SubscribeAll(bus Bus, handler Handler, eventTypes ...enum.EventType) {
+	for _, eventType := range eventTypes {
+		bus.Subscribe(eventType, handler)
+	}
+}
+
+func (b *bus) Publish(event *model.Event) error {
+	b.mu.RLock()
+	handlers := b.handlers[event.Type]
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler.Handle(event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,62 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"time"
+)
+
+// notification is the JSON document sent as a NOTIFY payload. Source identifies
+// the sending process so that it can skip its own notifications.
+type notification struct {
+	Source     string          `json:"source"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+func encodeNotification(source string, event *model.Event) ([]byte, error) {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(notification{
+		Source:     source,
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt,
+		Payload:    payload,
+	})
+}
+
+// decodeNotification restores the payload to the type the publishing service
+// used, so handlers see the same event as subscribers of the local bus.
+func decodeNotification(data []byte) (*notification, *model.Event, error) {
+	var n notification
+	if err := json.Unmarshal(data, &n); err != nil {
+		return nil, nil, err
+	}
+	var payload any
+	var err error
+	switch enum.EventType(n.Type) {
+	case enum.EventReceptionChanged, enum.EventProductsChanged:
+		var change model.ReceptionChange
+		err = json.Unmarshal(n.Payload, &change)
+		payload = change
+	case enum.EventReceptionEscalated:
+		var escalation model.ReceptionEscalation
+		err = json.Unmarshal(n.Payload, &escalation)
+		payload = escalation
+	case enum.EventPVZsChanged:
+		var count int
+		err = json.Unmarshal(n.Payload, &count)
+		payload = count
+	default:
+		return nil, nil, fmt.Errorf("unknown notification type %q", n.Type)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return &n, &model.Event{Type: n.Type, OccurredAt: n.OccurredAt, Payload: payload}, nil
+}
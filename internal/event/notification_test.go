@@ -0,0 +1,83 @@
+package event
+
+import (
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestNotification_RoundTrip(t *testing.T) {
+	occurredAt := time.Date(2025, 4, 10, 9, 0, 0, 0, time.UTC)
+	cases := map[string]model.Event{
+		"ReceptionChanged": {
+			Type:       enum.EventReceptionChanged.String(),
+			OccurredAt: occurredAt,
+			Payload:    model.ReceptionChange{ReceptionID: "rec_1", PVZID: "pvz_1"},
+		},
+		"ProductsChanged": {
+			Type:       enum.EventProductsChanged.String(),
+			OccurredAt: occurredAt,
+			Payload:    model.ReceptionChange{ReceptionID: "rec_1", PVZID: "pvz_1"},
+		},
+		"ReceptionEscalated": {
+			Type:       enum.EventReceptionEscalated.String(),
+			OccurredAt: occurredAt,
+			Payload: model.ReceptionEscalation{
+				ID:          "esc_1",
+				ReceptionID: "rec_1",
+				PVZID:       "pvz_1",
+				City:        enum.CityMoscow.String(),
+				OpenedAt:    occurredAt.Add(-13 * time.Hour),
+				AgeSeconds:  int64((13 * time.Hour).Seconds()),
+				Action:      enum.EscalationActionFlag.String(),
+				CreatedAt:   occurredAt,
+			},
+		},
+		"PVZsChanged": {
+			Type:       enum.EventPVZsChanged.String(),
+			OccurredAt: occurredAt,
+			Payload:    3,
+		},
+	}
+
+	for name, event := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			data, err := encodeNotification("instance_1", &event)
+			require.NoError(t, err)
+
+			// Act
+			header, decoded, err := decodeNotification(data)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, "instance_1", header.Source)
+			assert.Equal(t, &event, decoded)
+		})
+	}
+}
+
+func TestDecodeNotification_UnknownType(t *testing.T) {
+	// Arrange
+	data := []byte(`{"source":"instance_1","type":"unknown","occurredAt":"2025-04-10T09:00:00Z","payload":{}}`)
+
+	// Act
+	_, _, err := decodeNotification(data)
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestDecodeNotification_MalformedPayload(t *testing.T) {
+	// Arrange
+	data := []byte(`{"source":"instance_1","type":"pvzs_changed","occurredAt":"2025-04-10T09:00:00Z","payload":"three"}`)
+
+	// Act
+	_, _, err := decodeNotification(data)
+
+	// Assert
+	assert.Error(t, err)
+}
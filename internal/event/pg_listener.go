@@ -0,0 +1,180 @@
+package event
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"log/slog"
+	"time"
+)
+
+const (
+	listenerMinReconnectInterval = time.Second
+	listenerMaxReconnectInterval = time.Minute
+	listenerPingInterval         = 90 * time.Second
+	listenerCloseTimeout         = 5 * time.Second
+)
+
+type Listener interface {
+	Start() error
+	Stop(ctx context.Context)
+}
+
+// pgListener receives notifications sent by NewPGPublisher in other processes
+// and republishes them to a local Publisher. It holds one connection of the
+// pool for LISTEN and reconnects when that connection fails; after a reconnect
+// an EventListenerReconnected event is published because notifications sent
+// while disconnected are lost.
+type pgListener struct {
+	db        *pgxpool.Pool
+	channel   string
+	source    string
+	publisher Publisher
+	log       *slog.Logger
+	cancel    context.CancelFunc
+	doneCh    chan struct{}
+}
+
+func NewPGListener(db *pgxpool.Pool, channel, source string, publisher Publisher, log *slog.Logger) Listener {
+	return &pgListener{
+		db:        db,
+		channel:   channel,
+		source:    source,
+		publisher: publisher,
+		log:       log,
+		doneCh:    make(chan struct{}),
+	}
+}
+
+func (pl *pgListener) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	conn, err := pl.listen(ctx)
+	if err != nil {
+		cancel()
+		return err
+	}
+	pl.log.Info("listening for notifications", slog.String("channel", pl.channel))
+	pl.cancel = cancel
+
+	go func() {
+		defer close(pl.doneCh)
+		for {
+			err := pl.receive(ctx, conn)
+			closeListenConn(conn)
+			if ctx.Err() != nil {
+				return
+			}
+			pl.log.Warn("notification listener disconnected", slog.Any("error", err))
+			if conn = pl.reconnect(ctx); conn == nil {
+				return
+			}
+			pl.log.Info("notification listener reconnected")
+			pl.handleReconnect()
+		}
+	}()
+	return nil
+}
+
+func (pl *pgListener) Stop(ctx context.Context) {
+	if pl.cancel == nil {
+		return
+	}
+	pl.cancel()
+
+	select {
+	case <-pl.doneCh:
+		pl.log.Info("notification listener stopped gracefully")
+	case <-ctx.Done():
+		pl.log.Warn("notification listener forced to stop")
+	}
+}
+
+// listen acquires a dedicated connection and subscribes it to the channel.
+func (pl *pgListener) listen(ctx context.Context) (*pgxpool.Conn, error) {
+	conn, err := pl.db.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{pl.channel}.Sanitize()); err != nil {
+		closeListenConn(conn)
+		return nil, err
+	}
+	return conn, nil
+}
+
+// reconnect retries listen with a growing delay until it succeeds or ctx is
+// done, in which case it returns nil.
+func (pl *pgListener) reconnect(ctx context.Context) *pgxpool.Conn {
+	delay := listenerMinReconnectInterval
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+		conn, err := pl.listen(ctx)
+		if err == nil {
+			return conn
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		pl.log.Warn("notification listener connection attempt failed", slog.Any("error", err))
+		delay = min(2*delay, listenerMaxReconnectInterval)
+	}
+}
+
+// receive handles the notifications arriving on conn until the connection
+// fails or ctx is done. An idle connection is pinged to detect that it was
+// dropped silently.
+func (pl *pgListener) receive(ctx context.Context, conn *pgxpool.Conn) error {
+	for {
+		waitCtx, cancel := context.WithTimeout(ctx, listenerPingInterval)
+		notification, err := conn.Conn().WaitForNotification(waitCtx)
+		cancel()
+		switch {
+		case err == nil:
+			pl.handle(notification.Payload)
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case pgconn.Timeout(err):
+			if err := conn.Ping(ctx); err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+	}
+}
+
+func (pl *pgListener) handle(payload string) {
+	header, event, err := decodeNotification([]byte(payload))
+	if err != nil {
+		pl.log.Error("failed to decode notification", slog.String("payload", payload), slog.Any("error", err))
+		return
+	}
+	if header.Source == pl.source {
+		return
+	}
+	if err := pl.publisher.Publish(event); err != nil {
+		pl.log.Error("failed to handle event", slog.String("type", event.Type), slog.Any("error", err))
+	}
+}
+
+func (pl *pgListener) handleReconnect() {
+	reconnectEvent := model.Event{Type: enum.EventListenerReconnected.String(), OccurredAt: time.Now()}
+	if err := pl.publisher.Publish(&reconnectEvent); err != nil {
+		pl.log.Error("failed to handle event", slog.String("type", reconnectEvent.Type), slog.Any("error", err))
+	}
+}
+
+// closeListenConn closes conn instead of releasing it, so that a connection
+// still subscribed to the channel never goes back to the pool.
+func closeListenConn(conn *pgxpool.Conn) {
+	ctx, cancel := context.WithTimeout(context.Background(), listenerCloseTimeout)
+	defer cancel()
+	_ = conn.Hijack().Close(ctx)
+}
@@ -0,0 +1,80 @@
+package event
+
+import (
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func newTestPGListener(publisher Publisher) *pgListener {
+	return NewPGListener(nil, "test_channel", "instance_1", publisher, slog.New(slog.NewTextHandler(io.Discard, nil))).(*pgListener)
+}
+
+func newTestNotification(t *testing.T, source string) string {
+	event := model.Event{Type: enum.EventPVZsChanged.String(), OccurredAt: time.Now().UTC(), Payload: 1}
+	data, err := encodeNotification(source, &event)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestPGListener_RepublishesOtherInstances(t *testing.T) {
+	// Arrange
+	mockPublisher := new(MockPublisher)
+	listener := newTestPGListener(mockPublisher)
+	mockPublisher.On("Publish", mock.MatchedBy(func(e *model.Event) bool {
+		return e.Type == enum.EventPVZsChanged.String() && e.Payload == 1
+	})).Return(nil)
+
+	// Act
+	listener.handle(newTestNotification(t, "instance_2"))
+
+	// Assert
+	mockPublisher.AssertNumberOfCalls(t, "Publish", 1)
+}
+
+func TestPGListener_SkipsOwnNotifications(t *testing.T) {
+	// Arrange
+	mockPublisher := new(MockPublisher)
+	listener := newTestPGListener(mockPublisher)
+
+	// Act
+	listener.handle(newTestNotification(t, "instance_1"))
+
+	// Assert
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything)
+}
+
+func TestPGListener_SkipsUndecodableNotifications(t *testing.T) {
+	// Arrange
+	mockPublisher := new(MockPublisher)
+	listener := newTestPGListener(mockPublisher)
+
+	// Act
+	listener.handle("not json")
+
+	// Assert
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything)
+}
+
+func TestPGListener_PublishesReconnectAfterReconnect(t *testing.T) {
+	// Arrange
+	bus := NewBus()
+	listener := newTestPGListener(bus)
+	reconnects := 0
+	bus.Subscribe(enum.EventListenerReconnected, HandlerFunc(func(event *model.Event) error {
+		reconnects++
+		return nil
+	}))
+
+	// Act
+	listener.handleReconnect()
+
+	// Assert
+	assert.Equal(t, 1, reconnects)
+}
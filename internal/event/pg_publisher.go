@@ -0,0 +1,28 @@
+package event
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ners1us/order-service/internal/model"
+)
+
+type pgPublisher struct {
+	db      *pgxpool.Pool
+	channel string
+	source  string
+}
+
+// NewPGPublisher returns a Publisher that sends events to other processes with
+// Postgres NOTIFY on channel.
+func NewPGPublisher(db *pgxpool.Pool, channel, source string) Publisher {
+	return &pgPublisher{db, channel, source}
+}
+
+func (pp *pgPublisher) Publish(event *model.Event) error {
+	payload, err := encodeNotification(pp.source, event)
+	if err != nil {
+		return err
+	}
+	_, err = pp.db.Exec(context.Background(), "SELECT pg_notify($1, $2)", pp.channel, string(payload))
+	return err
+}
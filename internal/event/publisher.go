@@ -0,0 +1,28 @@
+package event
+
+import (
+	"encoding/json"
+	"github.com/ners1us/order-service/internal/model"
+	"log/slog"
+)
+
+type Publisher interface {
+	Publish(event *model.Event) error
+}
+
+type logPublisher struct {
+	log *slog.Logger
+}
+
+func NewLogPublisher(log *slog.Logger) Publisher {
+	return &logPublisher{log}
+}
+
+func (lp *logPublisher) Publish(event *model.Event) error {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return err
+	}
+	lp.log.Info("event published", slog.String("type", event.Type), slog.String("payload", string(payload)))
+	return nil
+}
@@ -0,0 +1,15 @@
+package event
+
+import (
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPublisher struct {
+	mock.Mock
+}
+
+func (mp *MockPublisher) Publish(event *model.Event) error {
+	args := mp.Called(event)
+	return args.Error(0)
+}
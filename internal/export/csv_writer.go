@@ -0,0 +1,39 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+)
+
+// UTF8BOM lets spreadsheet applications detect UTF-8 and show Cyrillic text correctly.
+var UTF8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (Writer, error) {
+	// csv.NewWriter reuses a *bufio.Writer as is, so the BOM stays buffered
+	// together with the first rows and nothing reaches w before they do.
+	buffered := bufio.NewWriter(w)
+	if _, err := buffered.Write(UTF8BOM); err != nil {
+		return nil, err
+	}
+	writer := csv.NewWriter(buffered)
+	writer.Comma = ';'
+	return &csvWriter{writer}, nil
+}
+
+func (cw *csvWriter) WriteRow(values []string) error {
+	return cw.writer.Write(values)
+}
+
+func (cw *csvWriter) Flush() error {
+	cw.writer.Flush()
+	return cw.writer.Error()
+}
+
+func (cw *csvWriter) Close() error {
+	return nil
+}
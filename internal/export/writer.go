@@ -0,0 +1,35 @@
+package export
+
+import (
+	"github.com/ners1us/order-service/internal/enum"
+	"io"
+)
+
+// Writer writes tabular rows to an underlying stream. Flush must be called
+// once all rows are written so buffered output reaches the stream, and Close
+// releases resources held by the writer whether or not Flush succeeded.
+type Writer interface {
+	WriteRow(values []string) error
+	Flush() error
+	Close() error
+}
+
+func NewWriter(format enum.ExportFormat, w io.Writer) (Writer, error) {
+	switch format {
+	case enum.ExportFormatCSV:
+		return newCSVWriter(w)
+	case enum.ExportFormatXLSX:
+		return newXLSXWriter(w)
+	default:
+		return nil, enum.ErrInvalidExportFormat
+	}
+}
+
+func ContentType(format enum.ExportFormat) string {
+	switch format {
+	case enum.ExportFormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
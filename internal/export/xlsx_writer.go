@@ -0,0 +1,51 @@
+package export
+
+import (
+	"github.com/xuri/excelize/v2"
+	"io"
+)
+
+const xlsxSheetName = "Sheet1"
+
+// xlsxWriter relies on the excelize stream writer, which spills rows to a
+// temporary file instead of keeping the whole sheet in memory.
+type xlsxWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	out    io.Writer
+	rowNum int
+}
+
+func newXLSXWriter(w io.Writer) (Writer, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter(xlsxSheetName)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &xlsxWriter{file: file, stream: stream, out: w}, nil
+}
+
+func (xw *xlsxWriter) WriteRow(values []string) error {
+	xw.rowNum++
+	cell, err := excelize.CoordinatesToCellName(1, xw.rowNum)
+	if err != nil {
+		return err
+	}
+	row := make([]any, len(values))
+	for i, value := range values {
+		row[i] = value
+	}
+	return xw.stream.SetRow(cell, row)
+}
+
+func (xw *xlsxWriter) Flush() error {
+	if err := xw.stream.Flush(); err != nil {
+		return err
+	}
+	return xw.file.Write(xw.out)
+}
+
+func (xw *xlsxWriter) Close() error {
+	return xw.file.Close()
+}
@@ -0,0 +1,91 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ners1us/order-service/internal/database"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const readinessTimeout = 2 * time.Second
+
+var ErrDraining = errors.New("server is shutting down")
+
+// Checker reports liveness and readiness of a process. A process is ready when
+// the database answers, its schema is migrated at least to the version the
+// binary was built for and the process is not draining. Without a database,
+// as with the memory storage, only draining is checked.
+type Checker struct {
+	db            *pgxpool.Pool
+	schemaVersion uint
+	drainOnce     sync.Once
+	draining      chan struct{}
+}
+
+func NewChecker(db *pgxpool.Pool, schemaVersion uint) *Checker {
+	return &Checker{db: db, schemaVersion: schemaVersion, draining: make(chan struct{})}
+}
+
+// Drain marks the process as shutting down so that readiness checks fail and
+// load balancers stop sending new requests. Calling it again has no effect.
+func (hc *Checker) Drain() {
+	hc.drainOnce.Do(func() {
+		close(hc.draining)
+	})
+}
+
+// Draining returns a channel that is closed once Drain is called.
+func (hc *Checker) Draining() <-chan struct{} {
+	return hc.draining
+}
+
+func (hc *Checker) Ready(ctx context.Context) error {
+	select {
+	case <-hc.draining:
+		return ErrDraining
+	default:
+	}
+	if hc.db == nil {
+		return nil
+	}
+	if err := hc.db.Ping(ctx); err != nil {
+		return fmt.Errorf("database is unavailable: %w", err)
+	}
+	return database.CheckSchemaVersion(ctx, hc.db, hc.schemaVersion)
+}
+
+// LivenessHandler answers 200 while the process is able to serve HTTP at all.
+func (hc *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, http.StatusOK, "ok", nil)
+	})
+}
+
+// ReadinessHandler answers 503 with the reason while Ready fails.
+func (hc *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		defer cancel()
+
+		if err := hc.Ready(ctx); err != nil {
+			writeStatus(w, http.StatusServiceUnavailable, "unavailable", err)
+			return
+		}
+		writeStatus(w, http.StatusOK, "ok", nil)
+	})
+}
+
+func writeStatus(w http.ResponseWriter, code int, status string, err error) {
+	body := map[string]string{"status": status}
+	if err != nil {
+		body["error"] = err.Error()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
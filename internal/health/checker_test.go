@@ -0,0 +1,95 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestChecker_ReadyWithoutDatabase(t *testing.T) {
+	// Arrange
+	checker := NewChecker(nil, 1)
+
+	// Act
+	err := checker.Ready(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestChecker_NotReadyWhileDraining(t *testing.T) {
+	// Arrange
+	checker := NewChecker(nil, 1)
+
+	// Act
+	checker.Drain()
+	checker.Drain()
+
+	// Assert
+	assert.ErrorIs(t, checker.Ready(context.Background()), ErrDraining)
+	select {
+	case <-checker.Draining():
+	default:
+		t.Fatal("Draining channel is not closed after Drain")
+	}
+}
+
+func TestChecker_Handlers(t *testing.T) {
+	cases := map[string]struct {
+		drain          bool
+		handler        func(checker *Checker) http.Handler
+		expectedCode   int
+		expectedStatus string
+		expectedError  string
+	}{
+		"LivenessOK": {
+			handler:        (*Checker).LivenessHandler,
+			expectedCode:   http.StatusOK,
+			expectedStatus: "ok",
+		},
+		"LivenessWhileDraining": {
+			drain:          true,
+			handler:        (*Checker).LivenessHandler,
+			expectedCode:   http.StatusOK,
+			expectedStatus: "ok",
+		},
+		"ReadinessOK": {
+			handler:        (*Checker).ReadinessHandler,
+			expectedCode:   http.StatusOK,
+			expectedStatus: "ok",
+		},
+		"ReadinessWhileDraining": {
+			drain:          true,
+			handler:        (*Checker).ReadinessHandler,
+			expectedCode:   http.StatusServiceUnavailable,
+			expectedStatus: "unavailable",
+			expectedError:  ErrDraining.Error(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			checker := NewChecker(nil, 1)
+			if tc.drain {
+				checker.Drain()
+			}
+			recorder := httptest.NewRecorder()
+
+			// Act
+			tc.handler(checker).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			// Assert
+			assert.Equal(t, tc.expectedCode, recorder.Code)
+			assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			var body map[string]string
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+			assert.Equal(t, tc.expectedStatus, body["status"])
+			assert.Equal(t, tc.expectedError, body["error"])
+		})
+	}
+}
@@ -0,0 +1,219 @@
+// Package loadgen drives the REST and gRPC APIs with the flow of an employee
+// handling a delivery and reports request latencies.
+package loadgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/pkg/generated/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	opCreateReception   = "rest create_reception"
+	opAddProduct        = "rest add_product"
+	opDeleteLastProduct = "rest delete_last_product"
+	opCloseReception    = "rest close_reception"
+	opListPVZs          = "rest get_pvz_list"
+	opGRPCListPVZs      = "grpc get_pvz_list"
+
+	listPageLimit = 10
+	listPeriod    = 30 * 24 * time.Hour
+
+	failureMinDelay = 10 * time.Millisecond
+	failureMaxDelay = time.Second
+)
+
+type Options struct {
+	RESTURL  string
+	GRPCAddr string
+	Workers  int
+	Duration time.Duration
+	// ProductsPerReception products are added in every reception, and then
+	// DeletesPerReception of them are removed before it is closed.
+	ProductsPerReception int
+	DeletesPerReception  int
+}
+
+type Result struct {
+	Elapsed    time.Duration    `json:"elapsed"`
+	Flows      int              `json:"flows"`
+	Operations []OperationStats `json:"operations"`
+}
+
+// Run drives the APIs until opts.Duration passes or ctx is cancelled. Every
+// worker owns a disjoint set of active PVZs and repeatedly opens a reception in
+// one of them, adds and deletes products, closes it and reads the PVZ list
+// through both APIs.
+func Run(ctx context.Context, opts Options) (*Result, error) {
+	if opts.Workers < 1 || opts.ProductsPerReception < 0 || opts.DeletesPerReception > opts.ProductsPerReception {
+		return nil, errors.New("workers must be positive and deletes must not exceed products")
+	}
+
+	conn, err := grpc.NewClient(opts.GRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to gRPC server: %w", err)
+	}
+	defer conn.Close()
+	pvzClient := proto.NewPVZServiceClient(conn)
+
+	pvzIDs, err := activePVZIDs(ctx, pvzClient)
+	if err != nil {
+		return nil, err
+	}
+	if len(pvzIDs) == 0 {
+		return nil, errors.New("there are no active pvzs, run orderctl seed first")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Duration)
+	defer cancel()
+
+	stats := newRecorder()
+	workers := min(opts.Workers, len(pvzIDs))
+	flows := make([]int, workers)
+	startedAt := time.Now()
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		var owned []string
+		for j := i; j < len(pvzIDs); j += workers {
+			owned = append(owned, pvzIDs[j])
+		}
+		w := &worker{
+			opts:   opts,
+			stats:  stats,
+			rest:   &restClient{baseURL: opts.RESTURL, http: &http.Client{Timeout: 30 * time.Second}},
+			grpc:   pvzClient,
+			pvzIDs: owned,
+			pages:  max(1, len(pvzIDs)/listPageLimit),
+			random: rand.New(rand.NewPCG(uint64(i), uint64(startedAt.UnixNano()))),
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := w.rest.login(ctx, enum.RoleEmployee.String()); err != nil {
+				errCh <- fmt.Errorf("failed to log in: %w", err)
+				return
+			}
+			flows[i] = w.run(ctx)
+		}()
+	}
+	wg.Wait()
+	close(errCh)
+	if err := <-errCh; err != nil {
+		return nil, err
+	}
+
+	result := &Result{Elapsed: time.Since(startedAt), Operations: stats.stats()}
+	for _, count := range flows {
+		result.Flows += count
+	}
+	return result, nil
+}
+
+func activePVZIDs(ctx context.Context, client proto.PVZServiceClient) ([]string, error) {
+	response, err := client.GetPVZList(ctx, &proto.GetPVZListRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pvzs: %w", err)
+	}
+	var ids []string
+	for _, pvz := range response.GetPvzs() {
+		if pvz.GetStatus() == enum.PVZStatusActive.String() {
+			ids = append(ids, pvz.GetId())
+		}
+	}
+	return ids, nil
+}
+
+type worker struct {
+	opts   Options
+	stats  *recorder
+	rest   *restClient
+	grpc   proto.PVZServiceClient
+	pvzIDs []string
+	pages  int
+	random *rand.Rand
+}
+
+// run performs flows until ctx is done and returns the number of completed ones.
+// After a failed flow it waits with exponential backoff, so that an
+// unavailable server is not hammered by a busy loop.
+func (w *worker) run(ctx context.Context) int {
+	completed := 0
+	delay := failureMinDelay
+	for i := 0; ctx.Err() == nil; i++ {
+		if err := w.flow(ctx, w.pvzIDs[i%len(w.pvzIDs)]); err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			slog.Debug("load flow failed", slog.Duration("retry_in", delay), slog.Any("error", err))
+			select {
+			case <-ctx.Done():
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, failureMaxDelay)
+			continue
+		}
+		delay = failureMinDelay
+		completed++
+	}
+	return completed
+}
+
+func (w *worker) flow(ctx context.Context, pvzID string) error {
+	err := w.stats.measure(ctx, opCreateReception, func() error {
+		return w.rest.do(ctx, http.MethodPost, "/receptions", map[string]string{"pvzId": pvzID}, nil)
+	})
+	if err != nil {
+		// A reception left open by an interrupted run blocks new ones.
+		_ = w.rest.do(ctx, http.MethodPost, "/pvz/"+pvzID+"/close_last_reception", nil, nil)
+		return err
+	}
+
+	for i := 0; i < w.opts.ProductsPerReception; i++ {
+		productType := enum.ProductTypes[w.random.IntN(len(enum.ProductTypes))]
+		err := w.stats.measure(ctx, opAddProduct, func() error {
+			return w.rest.do(ctx, http.MethodPost, "/products", map[string]string{"type": productType.String(), "pvzId": pvzID}, nil)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for i := 0; i < w.opts.DeletesPerReception; i++ {
+		err := w.stats.measure(ctx, opDeleteLastProduct, func() error {
+			return w.rest.do(ctx, http.MethodPost, "/pvz/"+pvzID+"/delete_last_product", nil, nil)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	err = w.stats.measure(ctx, opCloseReception, func() error {
+		return w.rest.do(ctx, http.MethodPost, "/pvz/"+pvzID+"/close_last_reception", nil, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	endDate := time.Now().UTC()
+	path := fmt.Sprintf("/pvz?startDate=%s&endDate=%s&page=%d&limit=%d",
+		endDate.Add(-listPeriod).Format(time.RFC3339), endDate.Format(time.RFC3339), 1+w.random.IntN(w.pages), listPageLimit)
+	err = w.stats.measure(ctx, opListPVZs, func() error {
+		return w.rest.do(ctx, http.MethodGet, path, nil, nil)
+	})
+	if err != nil {
+		return err
+	}
+	return w.stats.measure(ctx, opGRPCListPVZs, func() error {
+		_, err := w.grpc.GetPVZList(ctx, &proto.GetPVZListRequest{})
+		return err
+	})
+}
@@ -0,0 +1,38 @@
+package loadgen
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkerRun_BacksOffOnPersistentFailure(t *testing.T) {
+	// Arrange
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	w := &worker{
+		stats:  newRecorder(),
+		rest:   &restClient{baseURL: server.URL, http: server.Client()},
+		pvzIDs: []string{"pvz"},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	// Act
+	completed := w.run(ctx)
+
+	// Assert
+	assert.Zero(t, completed)
+	// Every failed flow sends two requests, and the delays of 10ms, 20ms, 40ms,
+	// 80ms and 160ms allow at most six flows in 300ms.
+	assert.Positive(t, requests.Load())
+	assert.LessOrEqual(t, requests.Load(), int64(12))
+}
@@ -0,0 +1,44 @@
+package loadgen
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// FlowsPerSecond is the throughput of the run.
+func (r *Result) FlowsPerSecond() float64 {
+	return float64(r.Flows) / r.Elapsed.Seconds()
+}
+
+// Operation returns the stats of the operation called name, if it ran.
+func (r *Result) Operation(name string) (OperationStats, bool) {
+	for _, op := range r.Operations {
+		if op.Name == name {
+			return op, true
+		}
+	}
+	return OperationStats{}, false
+}
+
+// SaveResult writes result to path as JSON, so that a later run can be
+// compared with it.
+func SaveResult(path string, result *Result) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// LoadResult reads a result written by SaveResult.
+func LoadResult(path string) (*Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var result Result
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,82 @@
+package loadgen
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// OperationStats summarizes the latencies of one kind of request. Failed
+// requests are counted in Errors and included in the latencies.
+type OperationStats struct {
+	Name   string        `json:"name"`
+	Count  int           `json:"count"`
+	Errors int           `json:"errors"`
+	P50    time.Duration `json:"p50"`
+	P90    time.Duration `json:"p90"`
+	P99    time.Duration `json:"p99"`
+	Max    time.Duration `json:"max"`
+}
+
+type recorder struct {
+	mu         sync.Mutex
+	latencies  map[string][]time.Duration
+	errorCount map[string]int
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		latencies:  make(map[string][]time.Duration),
+		errorCount: make(map[string]int),
+	}
+}
+
+// measure runs call and records its latency under name. Calls interrupted by
+// the end of the run are not recorded.
+func (r *recorder) measure(ctx context.Context, name string, call func() error) error {
+	startedAt := time.Now()
+	err := call()
+	latency := time.Since(startedAt)
+	if ctx.Err() != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.latencies[name] = append(r.latencies[name], latency)
+	if err != nil {
+		r.errorCount[name]++
+	}
+	return err
+}
+
+func (r *recorder) stats() []OperationStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := make([]OperationStats, 0, len(r.latencies))
+	for name, latencies := range r.latencies {
+		sorted := slices.Clone(latencies)
+		slices.Sort(sorted)
+		stats = append(stats, OperationStats{
+			Name:   name,
+			Count:  len(sorted),
+			Errors: r.errorCount[name],
+			P50:    percentile(sorted, 50),
+			P90:    percentile(sorted, 90),
+			P99:    percentile(sorted, 99),
+			Max:    sorted[len(sorted)-1],
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+// percentile uses the nearest-rank method on sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
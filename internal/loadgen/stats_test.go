@@ -0,0 +1,78 @@
+package loadgen
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	latencies := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	cases := map[string]struct {
+		sorted   []time.Duration
+		p        float64
+		expected time.Duration
+	}{
+		"Single":       {sorted: []time.Duration{7}, p: 99, expected: 7},
+		"Zero":         {sorted: latencies, p: 0, expected: 1},
+		"Median":       {sorted: latencies, p: 50, expected: 5},
+		"RoundsUp":     {sorted: latencies, p: 91, expected: 10},
+		"P90":          {sorted: latencies, p: 90, expected: 9},
+		"P100":         {sorted: latencies, p: 100, expected: 10},
+		"OddMedian":    {sorted: []time.Duration{1, 2, 3}, p: 50, expected: 2},
+		"SmallSetTail": {sorted: []time.Duration{1, 2, 3}, p: 99, expected: 3},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			result := percentile(tc.sorted, tc.p)
+
+			// Assert
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestRecorder_StatsCountErrorsAndSortByName(t *testing.T) {
+	// Arrange
+	r := newRecorder()
+	ctx := context.Background()
+	failure := errors.New("failed")
+
+	// Act
+	_ = r.measure(ctx, opListPVZs, func() error { return nil })
+	err := r.measure(ctx, opAddProduct, func() error { return failure })
+	_ = r.measure(ctx, opAddProduct, func() error { return nil })
+
+	// Assert
+	assert.ErrorIs(t, err, failure)
+	stats := r.stats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, opAddProduct, stats[0].Name)
+	assert.Equal(t, 2, stats[0].Count)
+	assert.Equal(t, 1, stats[0].Errors)
+	assert.Equal(t, opListPVZs, stats[1].Name)
+	assert.Equal(t, 1, stats[1].Count)
+	assert.Zero(t, stats[1].Errors)
+	assert.LessOrEqual(t, stats[0].P50, stats[0].Max)
+}
+
+func TestRecorder_SkipsCallsInterruptedByTheEndOfTheRun(t *testing.T) {
+	// Arrange
+	r := newRecorder()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Act
+	err := r.measure(ctx, opCloseReception, func() error {
+		cancel()
+		return context.Canceled
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, r.stats())
+}
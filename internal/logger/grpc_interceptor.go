@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"time"
+)
+
+// GrpcRequestID accepts the x-request-id metadata of the call or generates
+// one, stores it in the context and echoes it back in the response header.
+func GrpcRequestID(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDMetadata); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	requestID = ResolveRequestID(requestID)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadata, requestID))
+	return handler(WithRequestID(ctx, requestID), req)
+}
+
+// GrpcLogger logs every unary call with its status code and duration.
+func GrpcLogger(log *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+		statusCode := codes.OK
+		if err != nil {
+			statusCode = status.Code(err)
+		}
+
+		level := slog.LevelInfo
+		if statusCode == codes.Internal || statusCode == codes.Unknown {
+			level = slog.LevelError
+		}
+		attrs := []slog.Attr{
+			slog.String("method", info.FullMethod),
+			slog.String("code", statusCode.String()),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+		log.LogAttrs(ctx, level, "grpc request", attrs...)
+
+		return resp, err
+	}
+}
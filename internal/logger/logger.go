@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"os"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// New builds the application logger. JSON output is meant for production and
+// text output for local development; every record logged with a context that
+// carries a request id or a recording span gets request_id and trace_id
+// attributes.
+func New(format, level string) (*slog.Logger, error) {
+	var logLevel slog.Level
+	if err := logLevel.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q", level)
+	}
+	options := &slog.HandlerOptions{Level: logLevel}
+
+	var handler slog.Handler
+	switch format {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(os.Stdout, options)
+	case FormatText:
+		handler = slog.NewTextHandler(os.Stdout, options)
+	default:
+		return nil, fmt.Errorf("invalid log format %q", format)
+	}
+	return slog.New(&contextHandler{handler}), nil
+}
+
+type contextHandler struct {
+	slog.Handler
+}
+
+func (ch *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(slog.String("trace_id", spanContext.TraceID().String()))
+	}
+	return ch.Handler.Handle(ctx, record)
+}
+
+func (ch *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{ch.Handler.WithAttrs(attrs)}
+}
+
+func (ch *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{ch.Handler.WithGroup(name)}
+}
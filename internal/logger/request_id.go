@@ -0,0 +1,32 @@
+package logger
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+const (
+	RequestIDHeader   = "X-Request-ID"
+	requestIDMetadata = "x-request-id"
+	maxRequestIDLen   = 128
+)
+
+type requestIDKey struct{}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// ResolveRequestID keeps a request id supplied by the client unless it is
+// empty or suspiciously long, and generates a new one otherwise.
+func ResolveRequestID(requestID string) string {
+	if requestID == "" || len(requestID) > maxRequestIDLen {
+		return uuid.NewString()
+	}
+	return requestID
+}
@@ -4,34 +4,125 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// ReceptionsOpen and OpenReceptionAge are read from the database on every
+// scrape by the open reception collector, so each instance reports them, not
+// only the one running the scheduler.
 var (
-	PVZCreated        prometheus.Counter
-	ReceptionsCreated prometheus.Counter
-	ProductsAdded     prometheus.Counter
+	ReceptionsOpen = prometheus.NewDesc(
+		"receptions_open",
+		"number of receptions in progress per city",
+		[]string{"city"}, nil,
+	)
+	OpenReceptionAge = prometheus.NewDesc(
+		"open_reception_age_seconds",
+		"age of the oldest open reception per city",
+		[]string{"city"}, nil,
+	)
+)
+
+var (
+	PVZCreated           *prometheus.CounterVec
+	ReceptionsCreated    *prometheus.CounterVec
+	ReceptionsClosed     *prometheus.CounterVec
+	ProductsAdded        *prometheus.CounterVec
+	ProductsDeleted      *prometheus.CounterVec
+	ReceptionDuration    *prometheus.HistogramVec
+	ProductsPerReception *prometheus.HistogramVec
+	ReceptionsStale      *prometheus.CounterVec
+	CacheRequests        *prometheus.CounterVec
+	CircuitBreakerState  *prometheus.GaugeVec
 )
 
 func init() {
-	PVZCreated = prometheus.NewCounter(
+	PVZCreated = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "pvz_created_total",
 			Help: "total number of pvz created",
 		},
+		[]string{"city"},
 	)
 
-	ReceptionsCreated = prometheus.NewCounter(
+	ReceptionsCreated = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "receptions_created_total",
 			Help: "total number of receptions created",
 		},
+		[]string{"city"},
 	)
 
-	ProductsAdded = prometheus.NewCounter(
+	ReceptionsClosed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "receptions_closed_total",
+			Help: "total number of receptions closed by employees or by the stale reception scheduler",
+		},
+		[]string{"city", "closed_by"},
+	)
+
+	ProductsAdded = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "products_added_total",
 			Help: "total number of products added",
 		},
+		[]string{"city", "type"},
+	)
+
+	ProductsDeleted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "products_deleted_total",
+			Help: "total number of products deleted from open receptions",
+		},
+		[]string{"city", "type"},
+	)
+
+	ReceptionDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "reception_duration_seconds",
+			Help:    "time from opening to closing a reception",
+			Buckets: prometheus.ExponentialBuckets(300, 2, 10),
+		},
+		[]string{"city"},
+	)
+
+	ProductsPerReception = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "products_per_reception",
+			Help:    "number of products in a reception when it is closed",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 10),
+		},
+		[]string{"city"},
+	)
+
+	ReceptionsStale = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "receptions_stale_total",
+			Help: "total number of stale receptions escalated by the scheduler",
+		},
+		[]string{"city", "action"},
+	)
+
+	CacheRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_requests_total",
+			Help: "total number of cache lookups by cache and result (hit or miss)",
+		},
+		[]string{"cache", "result"},
+	)
+
+	CircuitBreakerState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "circuit_breaker_state",
+			Help: "state of a circuit breaker: 0 closed, 1 half-open, 2 open",
+		},
+		[]string{"name"},
 	)
 	prometheus.MustRegister(PVZCreated)
 	prometheus.MustRegister(ReceptionsCreated)
+	prometheus.MustRegister(ReceptionsClosed)
 	prometheus.MustRegister(ProductsAdded)
+	prometheus.MustRegister(ProductsDeleted)
+	prometheus.MustRegister(ReceptionDuration)
+	prometheus.MustRegister(ProductsPerReception)
+	prometheus.MustRegister(ReceptionsStale)
+	prometheus.MustRegister(CacheRequests)
+	prometheus.MustRegister(CircuitBreakerState)
 }
@@ -12,20 +12,20 @@ func AuthMiddleware(jwtService auth.JWTService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": enum.ErrNoAuthToken.Error()})
+			c.JSON(http.StatusUnauthorized, ErrorResponse(c, enum.ErrNoAuthToken.Error()))
 			c.Abort()
 			return
 		}
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": enum.ErrWrongTokenFormat.Error()})
+			c.JSON(http.StatusUnauthorized, ErrorResponse(c, enum.ErrWrongTokenFormat.Error()))
 			c.Abort()
 			return
 		}
 		tokenStr := parts[1]
 		claims, err := jwtService.ValidateToken(tokenStr)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": enum.ErrInvalidToken.Error()})
+			c.JSON(http.StatusUnauthorized, ErrorResponse(c, enum.ErrInvalidToken.Error()))
 			c.Abort()
 			return
 		}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/ners1us/order-service/internal/breaker"
+	"github.com/ners1us/order-service/internal/database"
+	"net/http"
+)
+
+// CircuitBreakerMiddleware answers 503 without calling the handler while b
+// rejects calls, so that clients fail fast instead of waiting for the database.
+func CircuitBreakerMiddleware(b *breaker.Breaker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !b.Ready() {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, ErrorResponse(c, breaker.ErrOpen.Error()))
+			return
+		}
+		c.Next()
+	}
+}
+
+// ErrorStatus returns 503 when err means the database is unavailable, because
+// a breaker opened during the request or the connection failed, and status
+// otherwise. Handlers pass every service error through it, so that such
+// failures are not reported as a bad request.
+func ErrorStatus(err error, status int) int {
+	if errors.Is(err, breaker.ErrOpen) || database.IsUnavailable(err) {
+		return http.StatusServiceUnavailable
+	}
+	return status
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ners1us/order-service/internal/breaker"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestErrorStatus(t *testing.T) {
+	cases := map[string]struct {
+		err      error
+		status   int
+		expected int
+	}{
+		"BreakerOpen":        {err: breaker.ErrOpen, status: http.StatusBadRequest, expected: http.StatusServiceUnavailable},
+		"WrappedBreakerOpen": {err: fmt.Errorf("failed to get pvz: %w", breaker.ErrOpen), status: http.StatusInternalServerError, expected: http.StatusServiceUnavailable},
+		"ConnectionLost":     {err: io.ErrUnexpectedEOF, status: http.StatusBadRequest, expected: http.StatusServiceUnavailable},
+		"ServerShutdown":     {err: &pgconn.PgError{Code: "57P01"}, status: http.StatusInternalServerError, expected: http.StatusServiceUnavailable},
+		"DomainError":        {err: enum.ErrPVZNotFound, status: http.StatusNotFound, expected: http.StatusNotFound},
+		"StatementError":     {err: &pgconn.PgError{Code: "23505"}, status: http.StatusBadRequest, expected: http.StatusBadRequest},
+		"Other":              {err: errors.New("failed"), status: http.StatusInternalServerError, expected: http.StatusInternalServerError},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			result := ErrorStatus(tc.err, tc.status)
+
+			// Assert
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestCircuitBreakerMiddleware(t *testing.T) {
+	cases := map[string]struct {
+		open          bool
+		expectedCode  int
+		expectedCalls int
+	}{
+		"Closed": {expectedCode: http.StatusOK, expectedCalls: 1},
+		"Open":   {open: true, expectedCode: http.StatusServiceUnavailable},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			gin.SetMode(gin.TestMode)
+			b := breaker.New("test-middleware-"+name, 1, time.Minute)
+			if tc.open {
+				_ = b.Allow()
+				b.Record(io.EOF, true)
+			}
+			calls := 0
+			router := gin.New()
+			router.GET("/", CircuitBreakerMiddleware(b), func(c *gin.Context) {
+				calls++
+				c.Status(http.StatusOK)
+			})
+			recorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			// Assert
+			assert.Equal(t, tc.expectedCode, recorder.Code)
+			assert.Equal(t, tc.expectedCalls, calls)
+		})
+	}
+}
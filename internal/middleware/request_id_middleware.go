@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ners1us/order-service/internal/logger"
+)
+
+// RequestIDMiddleware accepts the X-Request-ID header of the request or
+// generates one, stores it in the request context and echoes it back.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := logger.ResolveRequestID(c.GetHeader(logger.RequestIDHeader))
+		c.Request = c.Request.WithContext(logger.WithRequestID(c.Request.Context(), requestID))
+		c.Set("requestID", requestID)
+		c.Header(logger.RequestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// ErrorResponse is the body of every error response. It carries the request
+// id so that a client report can be matched with the server logs.
+func ErrorResponse(c *gin.Context, message string) gin.H {
+	return gin.H{"error": message, "requestId": c.GetString("requestID")}
+}
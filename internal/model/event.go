@@ -0,0 +1,9 @@
+package model
+
+import "time"
+
+type Event struct {
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurredAt"`
+	Payload    any       `json:"payload"`
+}
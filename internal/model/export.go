@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+type ExportFilter struct {
+	Format         string
+	StartDate      time.Time
+	EndDate        time.Time
+	PVZIDs         []string
+	IncludeDeleted bool
+}
+
+type ExportRow struct {
+	PVZID                 string
+	City                  string
+	ReceptionID           string
+	ReceptionDateTime     time.Time
+	ReceptionStatus       string
+	ReceptionClosedAt     *time.Time
+	ProductID             string
+	ProductDateTime       *time.Time
+	ProductType           string
+	ProductDeletedAt      *time.Time
+	ProductDeletedBy      string
+	ProductDeletionReason string
+}
@@ -0,0 +1,6 @@
+package model
+
+type NearbyPVZ struct {
+	PVZ      PVZ     `json:"pvz"`
+	Distance float64 `json:"distance"`
+}
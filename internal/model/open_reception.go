@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+type OpenReception struct {
+	Reception   Reception  `json:"reception"`
+	City        string     `json:"city"`
+	OpenedAt    time.Time  `json:"openedAt"`
+	EscalatedAt *time.Time `json:"escalatedAt,omitempty"`
+}
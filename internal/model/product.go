@@ -2,9 +2,15 @@ package model
 
 import "time"
 
+// Product is soft-deleted: DeletedAt, DeletedBy and DeletionReason are set
+// once it is deleted, and lists show it only when asked to include deleted
+// products.
 type Product struct {
-	ID          string    `json:"id"`
-	DateTime    time.Time `json:"dateTime"`
-	Type        string    `json:"type"`
-	ReceptionID string    `json:"receptionId"`
+	ID             string     `json:"id"`
+	DateTime       time.Time  `json:"dateTime"`
+	Type           string     `json:"type"`
+	ReceptionID    string     `json:"receptionId"`
+	DeletedAt      *time.Time `json:"deletedAt,omitempty"`
+	DeletedBy      string     `json:"deletedBy,omitempty"`
+	DeletionReason string     `json:"deletionReason,omitempty"`
 }
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+type ProductDeletion struct {
+	ID              string    `json:"id"`
+	ProductID       string    `json:"productId"`
+	ReceptionID     string    `json:"receptionId"`
+	ProductType     string    `json:"productType"`
+	ProductDateTime time.Time `json:"productDateTime"`
+	Reason          string    `json:"reason"`
+	DeletedBy       string    `json:"deletedBy"`
+	DeletedAt       time.Time `json:"deletedAt"`
+}
@@ -6,4 +6,10 @@ type PVZ struct {
 	ID               string    `json:"id"`
 	RegistrationDate time.Time `json:"registrationDate"`
 	City             string    `json:"city"`
+	Address          string    `json:"address"`
+	Latitude         *float64  `json:"latitude,omitempty"`
+	Longitude        *float64  `json:"longitude,omitempty"`
+	WorkingHours     string    `json:"workingHours"`
+	Phone            string    `json:"phone"`
+	Status           string    `json:"status"`
 }
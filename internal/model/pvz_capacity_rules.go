@@ -0,0 +1,8 @@
+package model
+
+type PVZCapacityRules struct {
+	PVZID         string         `json:"pvzId"`
+	MaxProducts   *int           `json:"maxProducts"`
+	AcceptedTypes []string       `json:"acceptedTypes"`
+	TypeQuotas    map[string]int `json:"typeQuotas"`
+}
@@ -0,0 +1,16 @@
+package model
+
+type PVZImportReport struct {
+	DryRun      bool                 `json:"dryRun"`
+	TotalRows   int                  `json:"totalRows"`
+	ValidRows   int                  `json:"validRows"`
+	InvalidRows int                  `json:"invalidRows"`
+	Imported    int                  `json:"imported"`
+	Rows        []PVZImportRowResult `json:"rows"`
+}
+
+type PVZImportRowResult struct {
+	Row   int    `json:"row"`
+	ID    string `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
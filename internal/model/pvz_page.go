@@ -0,0 +1,9 @@
+package model
+
+// PVZPage is a page of PVZs with their receptions in a date range and the
+// products of those receptions.
+type PVZPage struct {
+	PVZs       []PVZ
+	Receptions []Reception
+	Products   []Product
+}
@@ -0,0 +1,10 @@
+package model
+
+type PVZUpdate struct {
+	Address      *string  `json:"address"`
+	Latitude     *float64 `json:"latitude"`
+	Longitude    *float64 `json:"longitude"`
+	WorkingHours *string  `json:"workingHours"`
+	Phone        *string  `json:"phone"`
+	Status       *string  `json:"status"`
+}
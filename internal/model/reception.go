@@ -3,8 +3,9 @@ package model
 import "time"
 
 type Reception struct {
-	ID       string    `json:"id"`
-	DateTime time.Time `json:"dateTime"`
-	PVZID    string    `json:"pvzId"`
-	Status   string    `json:"status"`
+	ID       string     `json:"id"`
+	DateTime time.Time  `json:"dateTime"`
+	PVZID    string     `json:"pvzId"`
+	Status   string     `json:"status"`
+	ClosedAt *time.Time `json:"closedAt,omitempty"`
 }
@@ -0,0 +1,18 @@
+package model
+
+type ReceptionCapacity struct {
+	ReceptionID   string                `json:"receptionId"`
+	PVZID         string                `json:"pvzId"`
+	MaxProducts   *int                  `json:"maxProducts"`
+	TotalProducts int                   `json:"totalProducts"`
+	Remaining     *int                  `json:"remaining"`
+	Types         []ProductTypeCapacity `json:"types"`
+}
+
+type ProductTypeCapacity struct {
+	Type      string `json:"type"`
+	Accepted  bool   `json:"accepted"`
+	Quota     *int   `json:"quota"`
+	Count     int    `json:"count"`
+	Remaining *int   `json:"remaining"`
+}
@@ -0,0 +1,6 @@
+package model
+
+type ReceptionChange struct {
+	ReceptionID string `json:"receptionId"`
+	PVZID       string `json:"pvzId"`
+}
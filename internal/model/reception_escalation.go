@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+type ReceptionEscalation struct {
+	ID          string    `json:"id"`
+	ReceptionID string    `json:"receptionId"`
+	PVZID       string    `json:"pvzId"`
+	City        string    `json:"city"`
+	OpenedAt    time.Time `json:"openedAt"`
+	AgeSeconds  int64     `json:"ageSeconds"`
+	Action      string    `json:"action"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
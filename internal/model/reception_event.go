@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// ReceptionEvent is one entry of the event log of a reception. Actor is the ID
+// of the user who caused the event, enum.ReceptionEventActorScheduler, or
+// empty when unknown. The product fields are set for product events only.
+type ReceptionEvent struct {
+	ID          int64     `json:"id"`
+	ReceptionID string    `json:"receptionId"`
+	Type        string    `json:"type"`
+	Actor       string    `json:"actor,omitempty"`
+	OccurredAt  time.Time `json:"occurredAt"`
+	ProductID   string    `json:"productId,omitempty"`
+	ProductType string    `json:"productType,omitempty"`
+	Reason      string    `json:"reason,omitempty"`
+}
+
+type ReceptionTimeline struct {
+	Reception Reception        `json:"reception"`
+	Events    []ReceptionEvent `json:"events"`
+}
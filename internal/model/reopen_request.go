@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+type ReopenRequest struct {
+	ID            string     `json:"id"`
+	ReceptionID   string     `json:"receptionId"`
+	Reason        string     `json:"reason"`
+	RequestedBy   string     `json:"requestedBy"`
+	RequestedAt   time.Time  `json:"requestedAt"`
+	Status        string     `json:"status"`
+	ReviewedBy    string     `json:"reviewedBy,omitempty"`
+	ReviewComment string     `json:"reviewComment,omitempty"`
+	ReviewedAt    *time.Time `json:"reviewedAt,omitempty"`
+}
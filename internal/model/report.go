@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+type ReportFilter struct {
+	Period         string    `json:"period"`
+	GroupBy        string    `json:"groupBy"`
+	StartDate      time.Time `json:"startDate"`
+	EndDate        time.Time `json:"endDate"`
+	IncludeDeleted bool      `json:"includeDeleted"`
+}
+
+type Report struct {
+	ReportFilter
+	Rows []ReportRow `json:"rows"`
+}
+
+type ReportRow struct {
+	PeriodStart                 time.Time      `json:"periodStart"`
+	Group                       string         `json:"group"`
+	ReceptionsOpened            int            `json:"receptionsOpened"`
+	ReceptionsClosed            int            `json:"receptionsClosed"`
+	ProductsByType              map[string]int `json:"productsByType"`
+	TotalProducts               int            `json:"totalProducts"`
+	AvgReceptionDurationSeconds *float64       `json:"avgReceptionDurationSeconds"`
+	AvgProductsPerReception     float64        `json:"avgProductsPerReception"`
+}
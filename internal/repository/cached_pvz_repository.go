@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/ners1us/order-service/internal/cache"
+	"github.com/ners1us/order-service/internal/database"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"time"
+)
+
+const allPVZsCacheKey = "all"
+
+// CachedPVZRepository is a read-through decorator for PVZRepository. Lookups by
+// id and list pages are served from the caches; every write that goes through it
+// drops the affected id and all cached lists. Misses are not cached. As an
+// event.Handler it drops everything on PVZ changes made by other processes.
+// GetPVZPage is passed through, as receptions and products change too often.
+// For replicaLag after an invalidation lists are filled from the primary, so
+// that a replica which has not caught up yet does not put stale data back.
+type CachedPVZRepository struct {
+	PVZRepository
+	pvzCache   cache.Cache[model.PVZ]
+	listCache  cache.Cache[[]model.PVZ]
+	replicaLag time.Duration
+}
+
+func NewCachedPVZRepository(pvzRepo PVZRepository, pvzCache cache.Cache[model.PVZ], listCache cache.Cache[[]model.PVZ], replicaLag time.Duration) *CachedPVZRepository {
+	return &CachedPVZRepository{
+		PVZRepository: pvzRepo,
+		pvzCache:      pvzCache,
+		listCache:     listCache,
+		replicaLag:    replicaLag,
+	}
+}
+
+func (cpr *CachedPVZRepository) GetPVZByID(ctx context.Context, id string) (*model.PVZ, error) {
+	if pvz, ok := cpr.pvzCache.Get(id); ok {
+		return &pvz, nil
+	}
+	generation := cpr.pvzCache.Generation()
+	pvz, err := cpr.PVZRepository.GetPVZByID(ctx, id)
+	if err != nil || pvz.ID == "" {
+		return pvz, err
+	}
+	cpr.pvzCache.Set(id, *pvz, generation)
+	return pvz, nil
+}
+
+func (cpr *CachedPVZRepository) GetPVZs(ctx context.Context, page, limit int) ([]model.PVZ, error) {
+	return cpr.getList(ctx, fmt.Sprintf("page:%d:%d", page, limit), func(ctx context.Context) ([]model.PVZ, error) {
+		return cpr.PVZRepository.GetPVZs(ctx, page, limit)
+	})
+}
+
+func (cpr *CachedPVZRepository) GetAllPVZs(ctx context.Context) ([]model.PVZ, error) {
+	return cpr.getList(ctx, allPVZsCacheKey, func(ctx context.Context) ([]model.PVZ, error) {
+		return cpr.PVZRepository.GetAllPVZs(ctx)
+	})
+}
+
+func (cpr *CachedPVZRepository) CreatePVZ(ctx context.Context, pvz *model.PVZ) error {
+	defer cpr.listCache.Clear()
+	return cpr.PVZRepository.CreatePVZ(ctx, pvz)
+}
+
+func (cpr *CachedPVZRepository) CreatePVZs(ctx context.Context, pvzs []model.PVZ) error {
+	defer cpr.listCache.Clear()
+	return cpr.PVZRepository.CreatePVZs(ctx, pvzs)
+}
+
+func (cpr *CachedPVZRepository) UpdatePVZ(ctx context.Context, pvz *model.PVZ) error {
+	defer cpr.invalidate(pvz.ID)
+	return cpr.PVZRepository.UpdatePVZ(ctx, pvz)
+}
+
+func (cpr *CachedPVZRepository) UpdatePVZStatus(ctx context.Context, id string, status string) error {
+	defer cpr.invalidate(id)
+	return cpr.PVZRepository.UpdatePVZStatus(ctx, id, status)
+}
+
+func (cpr *CachedPVZRepository) Handle(event *model.Event) error {
+	switch enum.EventType(event.Type) {
+	case enum.EventPVZsChanged, enum.EventListenerReconnected:
+		cpr.pvzCache.Clear()
+		cpr.listCache.Clear()
+	}
+	return nil
+}
+
+func (cpr *CachedPVZRepository) getList(ctx context.Context, key string, load func(ctx context.Context) ([]model.PVZ, error)) ([]model.PVZ, error) {
+	if pvzs, ok := cpr.listCache.Get(key); ok {
+		return pvzs, nil
+	}
+	generation := cpr.listCache.Generation()
+	pvzs, err := load(database.WithPrimaryIfRecent(ctx, cpr.listCache.InvalidatedAt(), cpr.replicaLag))
+	if err != nil {
+		return nil, err
+	}
+	cpr.listCache.Set(key, pvzs, generation)
+	return pvzs, nil
+}
+
+func (cpr *CachedPVZRepository) invalidate(id string) {
+	cpr.pvzCache.Delete(id)
+	cpr.listCache.Clear()
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"github.com/ners1us/order-service/internal/cache"
+	"github.com/ners1us/order-service/internal/database"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func newTestCachedPVZRepository(mockPVZRepo *MockPVZRepository) *CachedPVZRepository {
+	return NewCachedPVZRepository(
+		mockPVZRepo,
+		cache.NewLRUCache[model.PVZ]("test_pvz", 10, time.Minute),
+		cache.NewLRUCache[[]model.PVZ]("test_pvz_list", 10, time.Minute),
+		0,
+	)
+}
+
+func TestCachedPVZRepository_ListenerReconnectedClearsCaches(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(MockPVZRepository)
+	cachedPVZRepo := newTestCachedPVZRepository(mockPVZRepo)
+	mockPVZRepo.On("GetPVZByID", "pvz_1").Return(&model.PVZ{ID: "pvz_1"}, nil)
+	mockPVZRepo.On("GetAllPVZs").Return([]model.PVZ{{ID: "pvz_1"}}, nil)
+	_, _ = cachedPVZRepo.GetPVZByID(context.Background(), "pvz_1")
+	_, _ = cachedPVZRepo.GetAllPVZs(context.Background())
+
+	// Act
+	err := cachedPVZRepo.Handle(&model.Event{Type: enum.EventListenerReconnected.String()})
+	_, _ = cachedPVZRepo.GetPVZByID(context.Background(), "pvz_1")
+	_, _ = cachedPVZRepo.GetAllPVZs(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	mockPVZRepo.AssertNumberOfCalls(t, "GetPVZByID", 2)
+	mockPVZRepo.AssertNumberOfCalls(t, "GetAllPVZs", 2)
+}
+
+func TestCachedPVZRepository_ServesRepeatedReadsFromCache(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(MockPVZRepository)
+	cachedPVZRepo := newTestCachedPVZRepository(mockPVZRepo)
+	mockPVZRepo.On("GetPVZByID", "pvz_1").Return(&model.PVZ{ID: "pvz_1"}, nil)
+
+	// Act
+	first, err1 := cachedPVZRepo.GetPVZByID(context.Background(), "pvz_1")
+	second, err2 := cachedPVZRepo.GetPVZByID(context.Background(), "pvz_1")
+
+	// Assert
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, first, second)
+	mockPVZRepo.AssertNumberOfCalls(t, "GetPVZByID", 1)
+}
+
+// primaryRecordingPVZRepository records whether each GetAllPVZs call was sent
+// to the primary.
+type primaryRecordingPVZRepository struct {
+	PVZRepository
+	usedPrimary []bool
+}
+
+func (prr *primaryRecordingPVZRepository) GetAllPVZs(ctx context.Context) ([]model.PVZ, error) {
+	prr.usedPrimary = append(prr.usedPrimary, database.UsesPrimary(ctx))
+	return []model.PVZ{{ID: "pvz_1"}}, nil
+}
+
+func TestCachedPVZRepository_FillsFromPrimaryRightAfterInvalidation(t *testing.T) {
+	cases := map[string]struct {
+		invalidate          bool
+		replicaLag          time.Duration
+		expectedUsedPrimary bool
+	}{
+		"NeverInvalidated":    {replicaLag: time.Minute, expectedUsedPrimary: false},
+		"RecentlyInvalidated": {invalidate: true, replicaLag: time.Minute, expectedUsedPrimary: true},
+		"LagDisabled":         {invalidate: true, replicaLag: 0, expectedUsedPrimary: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			pvzRepo := &primaryRecordingPVZRepository{}
+			cachedPVZRepo := NewCachedPVZRepository(
+				pvzRepo,
+				cache.NewLRUCache[model.PVZ]("test_pvz_lag", 10, time.Minute),
+				cache.NewLRUCache[[]model.PVZ]("test_pvz_list_lag", 10, time.Minute),
+				tc.replicaLag,
+			)
+			if tc.invalidate {
+				_ = cachedPVZRepo.Handle(&model.Event{Type: enum.EventPVZsChanged.String()})
+			}
+
+			// Act
+			_, err := cachedPVZRepo.GetAllPVZs(context.Background())
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, []bool{tc.expectedUsedPrimary}, pvzRepo.usedPrimary)
+		})
+	}
+}
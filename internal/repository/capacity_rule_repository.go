@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/ners1us/order-service/internal/database"
+	"github.com/ners1us/order-service/internal/model"
+)
+
+type CapacityRuleRepository interface {
+	GetCapacityRules(ctx context.Context, pvzID string) (*model.PVZCapacityRules, error)
+	SaveCapacityRules(ctx context.Context, rules *model.PVZCapacityRules) error
+}
+
+type capacityRuleRepositoryImpl struct {
+	db database.DB
+}
+
+func NewCapacityRuleRepository(db database.DB) CapacityRuleRepository {
+	return &capacityRuleRepositoryImpl{db}
+}
+
+func (crr *capacityRuleRepositoryImpl) GetCapacityRules(ctx context.Context, pvzID string) (*model.PVZCapacityRules, error) {
+	rules := model.PVZCapacityRules{PVZID: pvzID}
+	var typeQuotas []byte
+	query := "SELECT max_products, accepted_types, type_quotas FROM pvz_capacity_rules WHERE pvz_id = $1"
+	err := crr.db.QueryRow(ctx, query, pvzID).Scan(&rules.MaxProducts, &rules.AcceptedTypes, &typeQuotas)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &rules, nil
+	}
+	if err != nil {
+		return &model.PVZCapacityRules{}, err
+	}
+	if err := json.Unmarshal(typeQuotas, &rules.TypeQuotas); err != nil {
+		return &model.PVZCapacityRules{}, err
+	}
+	return &rules, nil
+}
+
+func (crr *capacityRuleRepositoryImpl) SaveCapacityRules(ctx context.Context, rules *model.PVZCapacityRules) error {
+	typeQuotas, err := json.Marshal(rules.TypeQuotas)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO pvz_capacity_rules (pvz_id, max_products, accepted_types, type_quotas)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (pvz_id) DO UPDATE
+		SET max_products = EXCLUDED.max_products, accepted_types = EXCLUDED.accepted_types, type_quotas = EXCLUDED.type_quotas`
+	_, err = crr.db.Exec(ctx, query, rules.PVZID, rules.MaxProducts, rules.AcceptedTypes, typeQuotas)
+	return err
+}
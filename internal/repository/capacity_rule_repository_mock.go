@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCapacityRuleRepository struct {
+	mock.Mock
+}
+
+func (mcr *MockCapacityRuleRepository) GetCapacityRules(ctx context.Context, pvzID string) (*model.PVZCapacityRules, error) {
+	args := mcr.Called(pvzID)
+	return args.Get(0).(*model.PVZCapacityRules), args.Error(1)
+}
+
+func (mcr *MockCapacityRuleRepository) SaveCapacityRules(ctx context.Context, rules *model.PVZCapacityRules) error {
+	args := mcr.Called(rules)
+	return args.Error(0)
+}
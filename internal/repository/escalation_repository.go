@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"github.com/ners1us/order-service/internal/database"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+)
+
+type EscalationRepository interface {
+	CreateEscalation(ctx context.Context, escalation *model.ReceptionEscalation) error
+}
+
+type escalationRepositoryImpl struct {
+	db database.DB
+}
+
+func NewEscalationRepository(db database.DB) EscalationRepository {
+	return &escalationRepositoryImpl{db}
+}
+
+func (er *escalationRepositoryImpl) CreateEscalation(ctx context.Context, escalation *model.ReceptionEscalation) error {
+	tx, err := er.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// A reception closed after it was read must not get a close escalation, so
+	// the close runs first and the escalation is only recorded when it matched.
+	if escalation.Action == enum.EscalationActionClose.String() {
+		updateQuery := `WITH closed AS (
+				UPDATE receptions SET status = $1, closed_at = $2 WHERE id = $3 AND status = $4 RETURNING id, closed_at
+			)
+			INSERT INTO reception_events (reception_id, type, actor, occurred_at)
+			SELECT id, $5, $6, closed_at FROM closed`
+		tag, err := tx.Exec(ctx, updateQuery, enum.StatusClosed.String(), escalation.CreatedAt, escalation.ReceptionID,
+			enum.StatusInProgress.String(), enum.ReceptionEventClosed.String(), enum.ReceptionEventActorScheduler)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return enum.ErrNoOpenReceptionToClose
+		}
+	}
+	query := `INSERT INTO reception_escalations (id, reception_id, pvz_id, city, opened_at, age_seconds, action, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err = tx.Exec(ctx, query, escalation.ID, escalation.ReceptionID, escalation.PVZID, escalation.City,
+		escalation.OpenedAt, escalation.AgeSeconds, escalation.Action, escalation.CreatedAt)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEscalationRepository struct {
+	mock.Mock
+}
+
+func (mer *MockEscalationRepository) CreateEscalation(ctx context.Context, escalation *model.ReceptionEscalation) error {
+	args := mer.Called(escalation)
+	return args.Error(0)
+}
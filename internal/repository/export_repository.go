@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"github.com/ners1us/order-service/internal/database"
+	"github.com/ners1us/order-service/internal/model"
+)
+
+type ExportRepository interface {
+	StreamReceptionProducts(ctx context.Context, filter *model.ExportFilter, handle func(row *model.ExportRow) error) error
+}
+
+type exportRepositoryImpl struct {
+	db database.Reader
+}
+
+func NewExportRepository(db database.Reader) ExportRepository {
+	return &exportRepositoryImpl{db}
+}
+
+func (er *exportRepositoryImpl) StreamReceptionProducts(ctx context.Context, filter *model.ExportFilter, handle func(row *model.ExportRow) error) error {
+	query := `SELECT p.id, p.city, r.id, r.date_time, r.status, r.closed_at, pr.id, pr.date_time, pr.type,
+			pr.deleted_at, pr.deleted_by, pr.deletion_reason
+		FROM receptions r
+		JOIN pvzs p ON p.id = r.pvz_id
+		LEFT JOIN products pr ON pr.reception_id = r.id AND (pr.deleted_at IS NULL OR $4)
+		WHERE r.date_time BETWEEN $1 AND $2
+			AND (cardinality($3::text[]) = 0 OR r.pvz_id = ANY($3::text[]::uuid[]))
+		ORDER BY p.city, p.id, r.date_time, pr.date_time`
+	rows, err := er.db.Query(ctx, query, filter.StartDate, filter.EndDate, filter.PVZIDs, filter.IncludeDeleted)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var row model.ExportRow
+		var productID, productType, deletedBy, deletionReason *string
+		err := rows.Scan(&row.PVZID, &row.City, &row.ReceptionID, &row.ReceptionDateTime, &row.ReceptionStatus,
+			&row.ReceptionClosedAt, &productID, &row.ProductDateTime, &productType,
+			&row.ProductDeletedAt, &deletedBy, &deletionReason)
+		if err != nil {
+			return err
+		}
+		if productID != nil {
+			row.ProductID, row.ProductType = *productID, *productType
+		}
+		if deletedBy != nil {
+			row.ProductDeletedBy, row.ProductDeletionReason = *deletedBy, *deletionReason
+		}
+		if err := handle(&row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
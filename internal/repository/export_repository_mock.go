@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockExportRepository struct {
+	mock.Mock
+}
+
+func (mer *MockExportRepository) StreamReceptionProducts(ctx context.Context, filter *model.ExportFilter, handle func(row *model.ExportRow) error) error {
+	args := mer.Called(filter, handle)
+	rows, _ := args.Get(0).([]model.ExportRow)
+	for i := range rows {
+		if err := handle(&rows[i]); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
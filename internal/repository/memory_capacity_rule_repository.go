@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/ners1us/order-service/internal/model"
+	"maps"
+	"slices"
+)
+
+type memoryCapacityRuleRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryCapacityRuleRepository(store *MemoryStore) CapacityRuleRepository {
+	return &memoryCapacityRuleRepository{store}
+}
+
+func (mcr *memoryCapacityRuleRepository) GetCapacityRules(ctx context.Context, pvzID string) (*model.PVZCapacityRules, error) {
+	id, err := parseID(pvzID)
+	if err != nil {
+		return &model.PVZCapacityRules{}, err
+	}
+	mcr.store.mu.RLock()
+	defer mcr.store.mu.RUnlock()
+
+	rules, ok := mcr.store.capacityRules[id]
+	if !ok {
+		return &model.PVZCapacityRules{PVZID: pvzID}, nil
+	}
+	rules = cloneCapacityRules(rules)
+	return &rules, nil
+}
+
+func (mcr *memoryCapacityRuleRepository) SaveCapacityRules(ctx context.Context, rules *model.PVZCapacityRules) error {
+	id, err := parseID(rules.PVZID)
+	if err != nil {
+		return err
+	}
+	mcr.store.mu.Lock()
+	defer mcr.store.mu.Unlock()
+
+	if _, exists := mcr.store.pvzs[id]; !exists {
+		return fmt.Errorf("%w: pvz %s", errForeignKeyViolation, id)
+	}
+	stored := cloneCapacityRules(*rules)
+	stored.PVZID = id
+	mcr.store.capacityRules[id] = stored
+	return nil
+}
+
+func cloneCapacityRules(rules model.PVZCapacityRules) model.PVZCapacityRules {
+	if rules.MaxProducts != nil {
+		maxProducts := *rules.MaxProducts
+		rules.MaxProducts = &maxProducts
+	}
+	rules.AcceptedTypes = slices.Clone(rules.AcceptedTypes)
+	rules.TypeQuotas = maps.Clone(rules.TypeQuotas)
+	return rules
+}
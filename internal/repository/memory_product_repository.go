@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"slices"
+	"time"
+)
+
+type memoryProductRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryProductRepository(store *MemoryStore) ProductRepository {
+	return &memoryProductRepository{store}
+}
+
+func (mpr *memoryProductRepository) CreateProduct(ctx context.Context, product *model.Product, actor string) error {
+	id, err := parseID(product.ID)
+	if err != nil {
+		return err
+	}
+	receptionID, err := parseID(product.ReceptionID)
+	if err != nil {
+		return err
+	}
+	mpr.store.mu.Lock()
+	defer mpr.store.mu.Unlock()
+
+	return mpr.store.createProduct(id, receptionID, product, actor)
+}
+
+func (mpr *memoryProductRepository) CreateProductWithinCapacity(ctx context.Context, product *model.Product, actor string, check func(counts map[string]int) error) error {
+	id, err := parseID(product.ID)
+	if err != nil {
+		return err
+	}
+	receptionID, err := parseID(product.ReceptionID)
+	if err != nil {
+		return err
+	}
+	mpr.store.mu.Lock()
+	defer mpr.store.mu.Unlock()
+
+	reception, exists := mpr.store.receptions[receptionID]
+	if !exists || reception.Status != enum.StatusInProgress.String() {
+		return enum.ErrNoOpenReceptionsToAdd
+	}
+	if err := check(mpr.store.countProductsByType(receptionID)); err != nil {
+		return err
+	}
+	return mpr.store.createProduct(id, receptionID, product, actor)
+}
+
+func (ms *MemoryStore) createProduct(id, receptionID string, product *model.Product, actor string) error {
+	if _, exists := ms.products[id]; exists {
+		return fmt.Errorf("%w: product %s", errDuplicateKey, id)
+	}
+	if _, exists := ms.receptions[receptionID]; !exists {
+		return fmt.Errorf("%w: reception %s", errForeignKeyViolation, receptionID)
+	}
+	ms.products[id] = model.Product{
+		ID:          id,
+		DateTime:    storedTime(product.DateTime),
+		Type:        product.Type,
+		ReceptionID: receptionID,
+	}
+	ms.appendReceptionEvent(model.ReceptionEvent{
+		ReceptionID: receptionID,
+		Type:        enum.ReceptionEventProductAdded.String(),
+		Actor:       actor,
+		OccurredAt:  product.DateTime,
+		ProductID:   id,
+		ProductType: product.Type,
+	})
+	return nil
+}
+
+func (mpr *memoryProductRepository) GetLastProductByReceptionID(ctx context.Context, receptionID string) (*model.Product, error) {
+	receptionID, err := parseID(receptionID)
+	if err != nil {
+		return &model.Product{}, err
+	}
+	mpr.store.mu.RLock()
+	defer mpr.store.mu.RUnlock()
+
+	last := model.Product{}
+	for _, product := range mpr.store.products {
+		if product.ReceptionID != receptionID || product.DeletedAt != nil {
+			continue
+		}
+		if last.ID == "" || cmp.Or(product.DateTime.Compare(last.DateTime), cmp.Compare(product.ID, last.ID)) > 0 {
+			last = product
+		}
+	}
+	return &last, nil
+}
+
+func (mpr *memoryProductRepository) DeleteProduct(ctx context.Context, id, deletedBy, reason string, deletedAt time.Time) error {
+	id, err := parseID(id)
+	if err != nil {
+		return err
+	}
+	mpr.store.mu.Lock()
+	defer mpr.store.mu.Unlock()
+
+	mpr.store.softDeleteProduct(id, deletedBy, reason, deletedAt)
+	return nil
+}
+
+// softDeleteProduct marks a product that is not deleted yet as deleted and
+// records the deletion as a reception event. It reports whether the product
+// was deleted.
+func (ms *MemoryStore) softDeleteProduct(id, deletedBy, reason string, deletedAt time.Time) bool {
+	product, exists := ms.products[id]
+	if !exists || product.DeletedAt != nil {
+		return false
+	}
+	deletedAt = storedTime(deletedAt)
+	product.DeletedAt = &deletedAt
+	product.DeletedBy = deletedBy
+	product.DeletionReason = reason
+	ms.products[id] = product
+	ms.appendReceptionEvent(model.ReceptionEvent{
+		ReceptionID: product.ReceptionID,
+		Type:        enum.ReceptionEventProductDeleted.String(),
+		Actor:       deletedBy,
+		OccurredAt:  deletedAt,
+		ProductID:   id,
+		ProductType: product.Type,
+		Reason:      reason,
+	})
+	return true
+}
+
+// GetProductsByReceptionIDs returns the products ordered by date. The Postgres
+// implementation does not order them, so callers must not rely on the order.
+func (mpr *memoryProductRepository) GetProductsByReceptionIDs(ctx context.Context, receptionIDs []string, includeDeleted bool) ([]model.Product, error) {
+	receptionIDs, err := parseIDs(receptionIDs)
+	if err != nil {
+		return nil, err
+	}
+	mpr.store.mu.RLock()
+	defer mpr.store.mu.RUnlock()
+
+	return mpr.store.productsByReceptionIDs(receptionIDs, includeDeleted), nil
+}
+
+func (ms *MemoryStore) productsByReceptionIDs(receptionIDs []string, includeDeleted bool) []model.Product {
+	var products []model.Product
+	for _, product := range ms.products {
+		if slices.Contains(receptionIDs, product.ReceptionID) && (includeDeleted || product.DeletedAt == nil) {
+			products = append(products, product)
+		}
+	}
+	slices.SortFunc(products, func(a, b model.Product) int {
+		return cmp.Or(a.DateTime.Compare(b.DateTime), cmp.Compare(a.ID, b.ID))
+	})
+	return products
+}
+
+func (mpr *memoryProductRepository) GetProductByID(ctx context.Context, id string) (*model.Product, error) {
+	id, err := parseID(id)
+	if err != nil {
+		return &model.Product{}, err
+	}
+	mpr.store.mu.RLock()
+	defer mpr.store.mu.RUnlock()
+
+	product := mpr.store.products[id]
+	if product.DeletedAt != nil {
+		return &model.Product{}, nil
+	}
+	return &product, nil
+}
+
+func (mpr *memoryProductRepository) DeleteProductWithReason(ctx context.Context, deletion *model.ProductDeletion) error {
+	productID, err := parseID(deletion.ProductID)
+	if err != nil {
+		return err
+	}
+	receptionID, err := parseID(deletion.ReceptionID)
+	if err != nil {
+		return err
+	}
+	mpr.store.mu.Lock()
+	defer mpr.store.mu.Unlock()
+
+	recorded := *deletion
+	recorded.ProductID = productID
+	recorded.ReceptionID = receptionID
+	recorded.ProductDateTime = storedTime(recorded.ProductDateTime)
+	recorded.DeletedAt = storedTime(recorded.DeletedAt)
+	if _, exists := mpr.store.receptions[recorded.ReceptionID]; !exists {
+		return fmt.Errorf("%w: reception %s", errForeignKeyViolation, recorded.ReceptionID)
+	}
+	if !mpr.store.softDeleteProduct(productID, recorded.DeletedBy, recorded.Reason, recorded.DeletedAt) {
+		return enum.ErrProductNotFound
+	}
+	mpr.store.productDeletions = append(mpr.store.productDeletions, recorded)
+	return nil
+}
+
+func (mpr *memoryProductRepository) CountProductsByType(ctx context.Context, receptionID string) (map[string]int, error) {
+	receptionID, err := parseID(receptionID)
+	if err != nil {
+		return nil, err
+	}
+	mpr.store.mu.RLock()
+	defer mpr.store.mu.RUnlock()
+
+	return mpr.store.countProductsByType(receptionID), nil
+}
+
+func (ms *MemoryStore) countProductsByType(receptionID string) map[string]int {
+	counts := make(map[string]int)
+	for _, product := range ms.products {
+		if product.ReceptionID == receptionID && product.DeletedAt == nil {
+			counts[product.Type]++
+		}
+	}
+	return counts
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"math"
+	"slices"
+	"time"
+)
+
+type memoryPVZRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryPVZRepository(store *MemoryStore) PVZRepository {
+	return &memoryPVZRepository{store}
+}
+
+func (mpr *memoryPVZRepository) CreatePVZ(ctx context.Context, pvz *model.PVZ) error {
+	return mpr.CreatePVZs(ctx, []model.PVZ{*pvz})
+}
+
+func (mpr *memoryPVZRepository) CreatePVZs(ctx context.Context, pvzs []model.PVZ) error {
+	mpr.store.mu.Lock()
+	defer mpr.store.mu.Unlock()
+
+	created := make(map[string]model.PVZ, len(pvzs))
+	for _, pvz := range pvzs {
+		id, err := parseID(pvz.ID)
+		if err != nil {
+			return err
+		}
+		_, exists := mpr.store.pvzs[id]
+		if _, duplicate := created[id]; exists || duplicate {
+			return fmt.Errorf("%w: pvz %s", errDuplicateKey, id)
+		}
+		pvz.ID = id
+		pvz.RegistrationDate = storedTime(pvz.RegistrationDate)
+		created[id] = clonePVZ(pvz)
+	}
+	for id, pvz := range created {
+		mpr.store.pvzs[id] = pvz
+	}
+	return nil
+}
+
+func (mpr *memoryPVZRepository) GetExistingPVZIDs(ctx context.Context, ids []string) ([]string, error) {
+	ids, err := parseIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	mpr.store.mu.RLock()
+	defer mpr.store.mu.RUnlock()
+
+	var existingIDs []string
+	for _, id := range ids {
+		if _, ok := mpr.store.pvzs[id]; ok && !slices.Contains(existingIDs, id) {
+			existingIDs = append(existingIDs, id)
+		}
+	}
+	return existingIDs, nil
+}
+
+func (mpr *memoryPVZRepository) GetPVZs(ctx context.Context, page, limit int) ([]model.PVZ, error) {
+	mpr.store.mu.RLock()
+	defer mpr.store.mu.RUnlock()
+
+	return mpr.store.pvzPage(page, limit)
+}
+
+// GetPVZPage reads the page, receptions and products under one lock, so they
+// are consistent with each other.
+func (mpr *memoryPVZRepository) GetPVZPage(ctx context.Context, page, limit int, startDate, endDate time.Time, includeDeleted bool) (*model.PVZPage, error) {
+	mpr.store.mu.RLock()
+	defer mpr.store.mu.RUnlock()
+
+	pvzs, err := mpr.store.pvzPage(page, limit)
+	if err != nil {
+		return nil, err
+	}
+	pvzIDs := make([]string, len(pvzs))
+	for i, pvz := range pvzs {
+		pvzIDs[i] = pvz.ID
+	}
+	receptions := mpr.store.receptionsByPVZIDsAndDate(pvzIDs, storedTime(startDate), storedTime(endDate))
+	receptionIDs := make([]string, len(receptions))
+	for i, reception := range receptions {
+		receptionIDs[i] = reception.ID
+	}
+	return &model.PVZPage{
+		PVZs:       pvzs,
+		Receptions: receptions,
+		Products:   mpr.store.productsByReceptionIDs(receptionIDs, includeDeleted),
+	}, nil
+}
+
+// GetAllPVZs returns the PVZs ordered by id. The Postgres implementation does
+// not order them, so callers must not rely on the order.
+func (mpr *memoryPVZRepository) GetAllPVZs(ctx context.Context) ([]model.PVZ, error) {
+	mpr.store.mu.RLock()
+	defer mpr.store.mu.RUnlock()
+
+	return mpr.store.allPVZs(), nil
+}
+
+func (ms *MemoryStore) pvzPage(page, limit int) ([]model.PVZ, error) {
+	offset := (page - 1) * limit
+	if offset < 0 || limit < 0 {
+		return nil, errors.New("limit and offset must not be negative")
+	}
+	pvzs := ms.allPVZs()
+	if offset >= len(pvzs) || limit == 0 {
+		return nil, nil
+	}
+	return pvzs[offset:min(offset+limit, len(pvzs))], nil
+}
+
+func (ms *MemoryStore) allPVZs() []model.PVZ {
+	var pvzs []model.PVZ
+	for _, pvz := range ms.pvzs {
+		pvzs = append(pvzs, clonePVZ(pvz))
+	}
+	slices.SortFunc(pvzs, func(a, b model.PVZ) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return pvzs
+}
+
+func (mpr *memoryPVZRepository) GetPVZByID(ctx context.Context, id string) (*model.PVZ, error) {
+	id, err := parseID(id)
+	if err != nil {
+		return &model.PVZ{}, err
+	}
+	mpr.store.mu.RLock()
+	defer mpr.store.mu.RUnlock()
+
+	pvz, ok := mpr.store.pvzs[id]
+	if !ok {
+		return &model.PVZ{}, nil
+	}
+	pvz = clonePVZ(pvz)
+	return &pvz, nil
+}
+
+func (mpr *memoryPVZRepository) UpdatePVZ(ctx context.Context, pvz *model.PVZ) error {
+	id, err := parseID(pvz.ID)
+	if err != nil {
+		return err
+	}
+	mpr.store.mu.Lock()
+	defer mpr.store.mu.Unlock()
+
+	stored, ok := mpr.store.pvzs[id]
+	if !ok {
+		return nil
+	}
+	stored.Address = pvz.Address
+	stored.Latitude, stored.Longitude = pvz.Latitude, pvz.Longitude
+	stored.WorkingHours = pvz.WorkingHours
+	stored.Phone = pvz.Phone
+	stored.Status = pvz.Status
+	mpr.store.pvzs[id] = clonePVZ(stored)
+	return nil
+}
+
+func (mpr *memoryPVZRepository) UpdatePVZStatus(ctx context.Context, id string, status string) error {
+	id, err := parseID(id)
+	if err != nil {
+		return err
+	}
+	mpr.store.mu.Lock()
+	defer mpr.store.mu.Unlock()
+
+	if pvz, ok := mpr.store.pvzs[id]; ok {
+		pvz.Status = status
+		mpr.store.pvzs[id] = pvz
+	}
+	return nil
+}
+
+func (mpr *memoryPVZRepository) GetNearbyPVZs(ctx context.Context, latitude, longitude, radius float64, city string, limit int) ([]model.NearbyPVZ, error) {
+	if limit < 0 {
+		return nil, errors.New("limit must not be negative")
+	}
+	mpr.store.mu.RLock()
+	defer mpr.store.mu.RUnlock()
+
+	var nearbyPVZs []model.NearbyPVZ
+	for _, pvz := range mpr.store.pvzs {
+		if pvz.Status != enum.PVZStatusActive.String() || pvz.Latitude == nil || pvz.Longitude == nil {
+			continue
+		}
+		if city != "" && pvz.City != city {
+			continue
+		}
+		distance := haversineDistance(latitude, longitude, *pvz.Latitude, *pvz.Longitude)
+		if distance <= radius {
+			nearbyPVZs = append(nearbyPVZs, model.NearbyPVZ{PVZ: clonePVZ(pvz), Distance: distance})
+		}
+	}
+	slices.SortFunc(nearbyPVZs, func(a, b model.NearbyPVZ) int {
+		return cmp.Compare(a.Distance, b.Distance)
+	})
+	return nearbyPVZs[:min(limit, len(nearbyPVZs))], nil
+}
+
+// haversineDistance is the great-circle distance in kilometers computed by the
+// same formula as the Postgres query.
+func haversineDistance(fromLatitude, fromLongitude, toLatitude, toLongitude float64) float64 {
+	radians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	sinLatitude := math.Sin(radians(toLatitude-fromLatitude) / 2)
+	sinLongitude := math.Sin(radians(toLongitude-fromLongitude) / 2)
+	return earthRadiusKm * 2 * math.Asin(math.Min(1, math.Sqrt(
+		sinLatitude*sinLatitude+math.Cos(radians(fromLatitude))*math.Cos(radians(toLatitude))*sinLongitude*sinLongitude,
+	)))
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"slices"
+	"time"
+)
+
+type memoryReceptionRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryReceptionRepository(store *MemoryStore) ReceptionRepository {
+	return &memoryReceptionRepository{store}
+}
+
+func (mrr *memoryReceptionRepository) CreateReception(ctx context.Context, reception *model.Reception, actor string) error {
+	id, err := parseID(reception.ID)
+	if err != nil {
+		return err
+	}
+	pvzID, err := parseID(reception.PVZID)
+	if err != nil {
+		return err
+	}
+	mrr.store.mu.Lock()
+	defer mrr.store.mu.Unlock()
+
+	if _, exists := mrr.store.receptions[id]; exists {
+		return fmt.Errorf("%w: reception %s", errDuplicateKey, id)
+	}
+	if _, exists := mrr.store.pvzs[pvzID]; !exists {
+		return fmt.Errorf("%w: pvz %s", errForeignKeyViolation, pvzID)
+	}
+	if reception.Status == enum.StatusInProgress.String() {
+		for _, other := range mrr.store.receptions {
+			if other.PVZID == pvzID && other.Status == enum.StatusInProgress.String() {
+				return fmt.Errorf("%w: pvz %s already has a reception in progress", errDuplicateKey, pvzID)
+			}
+		}
+	}
+	mrr.store.receptions[id] = model.Reception{
+		ID:       id,
+		DateTime: storedTime(reception.DateTime),
+		PVZID:    pvzID,
+		Status:   reception.Status,
+	}
+	mrr.store.appendReceptionEvent(model.ReceptionEvent{
+		ReceptionID: id,
+		Type:        enum.ReceptionEventOpened.String(),
+		Actor:       actor,
+		OccurredAt:  reception.DateTime,
+	})
+	return nil
+}
+
+// GetLastReceptionByPVZID prefers a reception in progress over the most recent
+// one, as the Postgres implementation does.
+func (mrr *memoryReceptionRepository) GetLastReceptionByPVZID(ctx context.Context, pvzID string) (*model.Reception, error) {
+	pvzID, err := parseID(pvzID)
+	if err != nil {
+		return &model.Reception{}, err
+	}
+	mrr.store.mu.RLock()
+	defer mrr.store.mu.RUnlock()
+
+	var last *model.Reception
+	for _, reception := range mrr.store.receptions {
+		if reception.PVZID != pvzID {
+			continue
+		}
+		if last == nil || compareReceptionRecency(reception, *last) > 0 {
+			candidate := reception
+			last = &candidate
+		}
+	}
+	if last == nil {
+		return &model.Reception{}, nil
+	}
+	reception := cloneReception(*last)
+	return &reception, nil
+}
+
+func compareReceptionRecency(a, b model.Reception) int {
+	aInProgress := a.Status == enum.StatusInProgress.String()
+	bInProgress := b.Status == enum.StatusInProgress.String()
+	if aInProgress != bInProgress {
+		if aInProgress {
+			return 1
+		}
+		return -1
+	}
+	return cmp.Or(a.DateTime.Compare(b.DateTime), cmp.Compare(a.ID, b.ID))
+}
+
+func (mrr *memoryReceptionRepository) CloseReception(ctx context.Context, id string, closedAt time.Time, actor string) error {
+	id, err := parseID(id)
+	if err != nil {
+		return err
+	}
+	mrr.store.mu.Lock()
+	defer mrr.store.mu.Unlock()
+
+	if reception, ok := mrr.store.receptions[id]; ok {
+		reception.Status = enum.StatusClosed.String()
+		reception.ClosedAt = storedTimePtr(&closedAt)
+		mrr.store.receptions[id] = reception
+		mrr.store.appendReceptionEvent(model.ReceptionEvent{
+			ReceptionID: id,
+			Type:        enum.ReceptionEventClosed.String(),
+			Actor:       actor,
+			OccurredAt:  closedAt,
+		})
+	}
+	return nil
+}
+
+// GetReceptionsByPVZIDsAndDate returns the receptions ordered by date. The
+// Postgres implementation does not order them, so callers must not rely on
+// the order.
+func (mrr *memoryReceptionRepository) GetReceptionsByPVZIDsAndDate(ctx context.Context, pvzIDs []string, startDate, endDate time.Time) ([]model.Reception, error) {
+	pvzIDs, err := parseIDs(pvzIDs)
+	if err != nil {
+		return nil, err
+	}
+	mrr.store.mu.RLock()
+	defer mrr.store.mu.RUnlock()
+
+	return mrr.store.receptionsByPVZIDsAndDate(pvzIDs, storedTime(startDate), storedTime(endDate)), nil
+}
+
+func (ms *MemoryStore) receptionsByPVZIDsAndDate(pvzIDs []string, startDate, endDate time.Time) []model.Reception {
+	var receptions []model.Reception
+	for _, reception := range ms.receptions {
+		if !slices.Contains(pvzIDs, reception.PVZID) ||
+			reception.DateTime.Before(startDate) || reception.DateTime.After(endDate) {
+			continue
+		}
+		receptions = append(receptions, cloneReception(reception))
+	}
+	slices.SortFunc(receptions, func(a, b model.Reception) int {
+		return cmp.Or(a.DateTime.Compare(b.DateTime), cmp.Compare(a.ID, b.ID))
+	})
+	return receptions
+}
+
+func (mrr *memoryReceptionRepository) GetReceptionByID(ctx context.Context, id string) (*model.Reception, error) {
+	id, err := parseID(id)
+	if err != nil {
+		return &model.Reception{}, err
+	}
+	mrr.store.mu.RLock()
+	defer mrr.store.mu.RUnlock()
+
+	reception, ok := mrr.store.receptions[id]
+	if !ok {
+		return &model.Reception{}, nil
+	}
+	reception = cloneReception(reception)
+	return &reception, nil
+}
+
+// GetOpenReceptions reports every reception as open since its creation and
+// never escalated: reopen requests and escalations are not kept in memory.
+func (mrr *memoryReceptionRepository) GetOpenReceptions(ctx context.Context) ([]model.OpenReception, error) {
+	mrr.store.mu.RLock()
+	defer mrr.store.mu.RUnlock()
+
+	var openReceptions []model.OpenReception
+	for _, reception := range mrr.store.receptions {
+		if reception.Status != enum.StatusInProgress.String() {
+			continue
+		}
+		openReceptions = append(openReceptions, model.OpenReception{
+			Reception: cloneReception(reception),
+			City:      mrr.store.pvzs[reception.PVZID].City,
+			OpenedAt:  reception.DateTime,
+		})
+	}
+	return openReceptions, nil
+}
+
+func (mrr *memoryReceptionRepository) GetReceptionEvents(ctx context.Context, receptionID string) ([]model.ReceptionEvent, error) {
+	receptionID, err := parseID(receptionID)
+	if err != nil {
+		return nil, err
+	}
+	mrr.store.mu.RLock()
+	defer mrr.store.mu.RUnlock()
+
+	var events []model.ReceptionEvent
+	for _, event := range mrr.store.receptionEvents {
+		if event.ReceptionID == receptionID {
+			events = append(events, event)
+		}
+	}
+	slices.SortFunc(events, func(a, b model.ReceptionEvent) int {
+		return cmp.Or(a.OccurredAt.Compare(b.OccurredAt), cmp.Compare(a.ID, b.ID))
+	})
+	return events, nil
+}
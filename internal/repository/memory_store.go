@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/ners1us/order-service/internal/model"
+	"sync"
+	"time"
+)
+
+var (
+	errDuplicateKey        = errors.New("duplicate key value violates unique constraint")
+	errForeignKeyViolation = errors.New("insert violates foreign key constraint")
+)
+
+// MemoryStore holds the rows of the in-memory repositories. Repositories built
+// on one store see each other's rows like tables of one database, which is
+// needed to check references and to join receptions to their PVZs.
+type MemoryStore struct {
+	mu               sync.RWMutex
+	users            map[string]model.User
+	pvzs             map[string]model.PVZ
+	receptions       map[string]model.Reception
+	products         map[string]model.Product
+	productDeletions []model.ProductDeletion
+	receptionEvents  []model.ReceptionEvent
+	capacityRules    map[string]model.PVZCapacityRules
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		users:         make(map[string]model.User),
+		pvzs:          make(map[string]model.PVZ),
+		receptions:    make(map[string]model.Reception),
+		products:      make(map[string]model.Product),
+		capacityRules: make(map[string]model.PVZCapacityRules),
+	}
+}
+
+// parseID normalizes id the way a Postgres UUID column does and rejects
+// malformed ids with an error, as queries against such a column fail.
+func parseID(id string) (string, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return "", fmt.Errorf("invalid input syntax for type uuid: %q", id)
+	}
+	return parsed.String(), nil
+}
+
+func parseIDs(ids []string) ([]string, error) {
+	parsed := make([]string, 0, len(ids))
+	for _, id := range ids {
+		normalized, err := parseID(id)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, normalized)
+	}
+	return parsed, nil
+}
+
+// storedTime mirrors a TIMESTAMP column: the offset is dropped, the wall clock
+// reading is kept as UTC and the value is rounded to microseconds.
+func storedTime(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC).
+		Round(time.Microsecond)
+}
+
+func storedTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	stored := storedTime(*t)
+	return &stored
+}
+
+// appendReceptionEvent numbers event like the BIGSERIAL id of reception_events.
+func (ms *MemoryStore) appendReceptionEvent(event model.ReceptionEvent) {
+	event.ID = int64(len(ms.receptionEvents) + 1)
+	event.OccurredAt = storedTime(event.OccurredAt)
+	ms.receptionEvents = append(ms.receptionEvents, event)
+}
+
+func clonePVZ(pvz model.PVZ) model.PVZ {
+	if pvz.Latitude != nil && pvz.Longitude != nil {
+		latitude, longitude := *pvz.Latitude, *pvz.Longitude
+		pvz.Latitude, pvz.Longitude = &latitude, &longitude
+	} else {
+		pvz.Latitude, pvz.Longitude = nil, nil
+	}
+	return pvz
+}
+
+func cloneReception(reception model.Reception) model.Reception {
+	if reception.ClosedAt != nil {
+		closedAt := *reception.ClosedAt
+		reception.ClosedAt = &closedAt
+	}
+	return reception
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/ners1us/order-service/internal/model"
+)
+
+type memoryUserRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryUserRepository(store *MemoryStore) UserRepository {
+	return &memoryUserRepository{store}
+}
+
+func (mur *memoryUserRepository) CreateUser(ctx context.Context, user *model.User) error {
+	id, err := parseID(user.ID)
+	if err != nil {
+		return err
+	}
+	mur.store.mu.Lock()
+	defer mur.store.mu.Unlock()
+
+	if _, exists := mur.store.users[id]; exists {
+		return fmt.Errorf("%w: user %s", errDuplicateKey, id)
+	}
+	for _, other := range mur.store.users {
+		if other.Email == user.Email {
+			return fmt.Errorf("%w: email %s", errDuplicateKey, user.Email)
+		}
+	}
+	stored := *user
+	stored.ID = id
+	mur.store.users[id] = stored
+	return nil
+}
+
+func (mur *memoryUserRepository) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	mur.store.mu.RLock()
+	defer mur.store.mu.RUnlock()
+
+	for _, user := range mur.store.users {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+	return &model.User{}, nil
+}
@@ -1,64 +1,206 @@
 package repository
 
 import (
-	"database/sql"
+	"context"
 	"errors"
-	"github.com/lib/pq"
-	_ "github.com/lib/pq"
+	"github.com/jackc/pgx/v5"
+	"github.com/ners1us/order-service/internal/database"
+	"github.com/ners1us/order-service/internal/enum"
 	"github.com/ners1us/order-service/internal/model"
+	"time"
 )
 
 type ProductRepository interface {
-	CreateProduct(product *model.Product) error
-	GetLastProductByReceptionID(receptionID string) (*model.Product, error)
-	DeleteProduct(id string) error
-	GetProductsByReceptionIDs(receptionIDs []string) ([]model.Product, error)
+	CreateProduct(ctx context.Context, product *model.Product, actor string) error
+	GetLastProductByReceptionID(ctx context.Context, receptionID string) (*model.Product, error)
+	DeleteProduct(ctx context.Context, id, deletedBy, reason string, deletedAt time.Time) error
+	GetProductsByReceptionIDs(ctx context.Context, receptionIDs []string, includeDeleted bool) ([]model.Product, error)
+	GetProductByID(ctx context.Context, id string) (*model.Product, error)
+	DeleteProductWithReason(ctx context.Context, deletion *model.ProductDeletion) error
+	CountProductsByType(ctx context.Context, receptionID string) (map[string]int, error)
+	CreateProductWithinCapacity(ctx context.Context, product *model.Product, actor string, check func(counts map[string]int) error) error
 }
 
+// Deleted products stay in the table with deleted_at set. Only
+// GetProductsByReceptionIDs and GetPVZPage return them, and only when asked to.
+const productColumns = "id, date_time, type, reception_id, deleted_at, deleted_by, deletion_reason"
+
 type productRepositoryImpl struct {
-	db *sql.DB
+	db      database.DB
+	replica database.Reader
 }
 
-func NewProductRepository(db *sql.DB) ProductRepository {
-	return &productRepositoryImpl{db}
+// NewProductRepository reads the products of several receptions from replica,
+// which may lag behind db. Everything else goes to db.
+func NewProductRepository(db database.DB, replica database.Reader) ProductRepository {
+	return &productRepositoryImpl{db, replica}
 }
 
-func (pr *productRepositoryImpl) CreateProduct(product *model.Product) error {
-	query := "INSERT INTO products (id, date_time, type, reception_id) VALUES ($1, $2, $3, $4)"
-	_, err := pr.db.Exec(query, product.ID, product.DateTime, product.Type, product.ReceptionID)
+func (pr *productRepositoryImpl) CreateProduct(ctx context.Context, product *model.Product, actor string) error {
+	_, err := pr.db.Exec(ctx, createProductQuery, product.ID, product.DateTime, product.Type, product.ReceptionID,
+		enum.ReceptionEventProductAdded.String(), actor)
 	return err
 }
 
-func (pr *productRepositoryImpl) GetLastProductByReceptionID(receptionID string) (*model.Product, error) {
-	var product model.Product
-	query := "SELECT id, date_time, type, reception_id FROM products WHERE reception_id = $1 ORDER BY date_time DESC LIMIT 1"
-	err := pr.db.QueryRow(query, receptionID).Scan(&product.ID, &product.DateTime, &product.Type, &product.ReceptionID)
-	if errors.Is(err, sql.ErrNoRows) {
-		return &model.Product{}, err
+const createProductQuery = `WITH created AS (
+		INSERT INTO products (id, date_time, type, reception_id) VALUES ($1, $2, $3, $4)
+		RETURNING id, date_time, type, reception_id
+	)
+	INSERT INTO reception_events (reception_id, type, actor, occurred_at, product_id, product_type)
+	SELECT reception_id, $5, NULLIF($6, ''), date_time, id, type FROM created`
+
+// CreateProductWithinCapacity locks the reception row before counting its
+// products, so concurrent additions to one reception are checked one after
+// another and a reception closed meanwhile gets no product.
+func (pr *productRepositoryImpl) CreateProductWithinCapacity(ctx context.Context, product *model.Product, actor string, check func(counts map[string]int) error) error {
+	tx, err := pr.db.Begin(ctx)
+	if err != nil {
+		return err
 	}
-	return &product, nil
+	defer tx.Rollback(ctx)
+
+	var status string
+	err = tx.QueryRow(ctx, "SELECT status FROM receptions WHERE id = $1 FOR UPDATE", product.ReceptionID).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return enum.ErrNoOpenReceptionsToAdd
+	}
+	if err != nil {
+		return err
+	}
+	if status != enum.StatusInProgress.String() {
+		return enum.ErrNoOpenReceptionsToAdd
+	}
+	counts, err := countProductsByType(ctx, tx, product.ReceptionID)
+	if err != nil {
+		return err
+	}
+	if err := check(counts); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, createProductQuery, product.ID, product.DateTime, product.Type, product.ReceptionID,
+		enum.ReceptionEventProductAdded.String(), actor)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (pr *productRepositoryImpl) GetLastProductByReceptionID(ctx context.Context, receptionID string) (*model.Product, error) {
+	query := "SELECT " + productColumns + ` FROM products WHERE reception_id = $1 AND deleted_at IS NULL
+		ORDER BY date_time DESC LIMIT 1`
+	product, err := scanProduct(pr.db.QueryRow(ctx, query, receptionID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &model.Product{}, nil
+	}
+	return product, err
 }
 
-func (pr *productRepositoryImpl) DeleteProduct(id string) error {
-	query := "DELETE FROM products WHERE id = $1"
-	_, err := pr.db.Exec(query, id)
+func (pr *productRepositoryImpl) DeleteProduct(ctx context.Context, id, deletedBy, reason string, deletedAt time.Time) error {
+	_, err := pr.db.Exec(ctx, softDeleteProductQuery, deletedAt, deletedBy, reason, id, enum.ReceptionEventProductDeleted.String())
 	return err
 }
 
-func (pr *productRepositoryImpl) GetProductsByReceptionIDs(receptionIDs []string) ([]model.Product, error) {
-	query := "SELECT id, date_time, type, reception_id FROM products WHERE reception_id = ANY($1)"
-	rows, err := pr.db.Query(query, pq.Array(receptionIDs))
+// softDeleteProductQuery records the deletion as a reception event only when
+// the product was not deleted yet.
+const softDeleteProductQuery = `WITH deleted AS (
+		UPDATE products SET deleted_at = $1, deleted_by = $2, deletion_reason = $3 WHERE id = $4 AND deleted_at IS NULL
+		RETURNING id, type, reception_id, deleted_at, deleted_by, deletion_reason
+	)
+	INSERT INTO reception_events (reception_id, type, actor, occurred_at, product_id, product_type, reason)
+	SELECT reception_id, $5, deleted_by, deleted_at, id, type, deletion_reason FROM deleted`
+
+func (pr *productRepositoryImpl) GetProductsByReceptionIDs(ctx context.Context, receptionIDs []string, includeDeleted bool) ([]model.Product, error) {
+	query := "SELECT " + productColumns + " FROM products WHERE reception_id = ANY($1::text[]::uuid[]) AND (deleted_at IS NULL OR $2)"
+	rows, err := pr.replica.Query(ctx, query, receptionIDs, includeDeleted)
 	if err != nil {
 		return nil, err
 	}
+	return scanProducts(rows)
+}
+
+func (pr *productRepositoryImpl) GetProductByID(ctx context.Context, id string) (*model.Product, error) {
+	query := "SELECT " + productColumns + " FROM products WHERE id = $1 AND deleted_at IS NULL"
+	product, err := scanProduct(pr.db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &model.Product{}, nil
+	}
+	return product, err
+}
+
+func (pr *productRepositoryImpl) DeleteProductWithReason(ctx context.Context, deletion *model.ProductDeletion) error {
+	tx, err := pr.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, softDeleteProductQuery, deletion.DeletedAt, deletion.DeletedBy, deletion.Reason, deletion.ProductID,
+		enum.ReceptionEventProductDeleted.String())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return enum.ErrProductNotFound
+	}
+	insertQuery := `INSERT INTO product_deletions
+		(id, product_id, reception_id, product_type, product_date_time, reason, deleted_by, deleted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err = tx.Exec(ctx, insertQuery, deletion.ID, deletion.ProductID, deletion.ReceptionID, deletion.ProductType,
+		deletion.ProductDateTime, deletion.Reason, deletion.DeletedBy, deletion.DeletedAt)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (pr *productRepositoryImpl) CountProductsByType(ctx context.Context, receptionID string) (map[string]int, error) {
+	return countProductsByType(ctx, pr.db, receptionID)
+}
+
+func countProductsByType(ctx context.Context, reader database.Reader, receptionID string) (map[string]int, error) {
+	query := "SELECT type, COUNT(*) FROM products WHERE reception_id = $1 AND deleted_at IS NULL GROUP BY type"
+	rows, err := reader.Query(ctx, query, receptionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	counts := make(map[string]int)
+	for rows.Next() {
+		var productType string
+		var count int
+		if err := rows.Scan(&productType, &count); err != nil {
+			return nil, err
+		}
+		counts[productType] = count
+	}
+	return counts, rows.Err()
+}
+
+func scanProduct(row pgx.Row) (*model.Product, error) {
+	var product model.Product
+	var deletedBy, deletionReason *string
+	err := row.Scan(&product.ID, &product.DateTime, &product.Type, &product.ReceptionID, &product.DeletedAt, &deletedBy, &deletionReason)
+	if err != nil {
+		return nil, err
+	}
+	if deletedBy != nil {
+		product.DeletedBy = *deletedBy
+	}
+	if deletionReason != nil {
+		product.DeletionReason = *deletionReason
+	}
+	return &product, nil
+}
+
+func scanProducts(rows pgx.Rows) ([]model.Product, error) {
 	defer rows.Close()
 	var products []model.Product
 	for rows.Next() {
-		var product model.Product
-		if err := rows.Scan(&product.ID, &product.DateTime, &product.Type, &product.ReceptionID); err != nil {
+		product, err := scanProduct(rows)
+		if err != nil {
 			return nil, err
 		}
-		products = append(products, product)
+		products = append(products, *product)
 	}
-	return products, nil
+	return products, rows.Err()
 }
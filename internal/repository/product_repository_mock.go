@@ -1,30 +1,57 @@
 package repository
 
 import (
+	"context"
 	"github.com/ners1us/order-service/internal/model"
 	"github.com/stretchr/testify/mock"
+	"time"
 )
 
 type MockProductRepository struct {
 	mock.Mock
 }
 
-func (mpr *MockProductRepository) CreateProduct(product *model.Product) error {
-	args := mpr.Called(product)
+func (mpr *MockProductRepository) CreateProduct(ctx context.Context, product *model.Product, actor string) error {
+	args := mpr.Called(product, actor)
 	return args.Error(0)
 }
 
-func (mpr *MockProductRepository) GetLastProductByReceptionID(receptionID string) (*model.Product, error) {
+func (mpr *MockProductRepository) GetLastProductByReceptionID(ctx context.Context, receptionID string) (*model.Product, error) {
 	args := mpr.Called(receptionID)
 	return args.Get(0).(*model.Product), args.Error(1)
 }
 
-func (mpr *MockProductRepository) DeleteProduct(id string) error {
-	args := mpr.Called(id)
+func (mpr *MockProductRepository) DeleteProduct(ctx context.Context, id, deletedBy, reason string, deletedAt time.Time) error {
+	args := mpr.Called(id, deletedBy, reason)
 	return args.Error(0)
 }
 
-func (mpr *MockProductRepository) GetProductsByReceptionIDs(receptionIDs []string) ([]model.Product, error) {
-	args := mpr.Called(receptionIDs)
+func (mpr *MockProductRepository) GetProductsByReceptionIDs(ctx context.Context, receptionIDs []string, includeDeleted bool) ([]model.Product, error) {
+	args := mpr.Called(receptionIDs, includeDeleted)
 	return args.Get(0).([]model.Product), args.Error(1)
 }
+
+func (mpr *MockProductRepository) GetProductByID(ctx context.Context, id string) (*model.Product, error) {
+	args := mpr.Called(id)
+	return args.Get(0).(*model.Product), args.Error(1)
+}
+
+func (mpr *MockProductRepository) DeleteProductWithReason(ctx context.Context, deletion *model.ProductDeletion) error {
+	args := mpr.Called(deletion)
+	return args.Error(0)
+}
+
+func (mpr *MockProductRepository) CountProductsByType(ctx context.Context, receptionID string) (map[string]int, error) {
+	args := mpr.Called(receptionID)
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+// CreateProductWithinCapacity runs check against the counts given to Return
+// before returning the error given to it.
+func (mpr *MockProductRepository) CreateProductWithinCapacity(ctx context.Context, product *model.Product, actor string, check func(counts map[string]int) error) error {
+	args := mpr.Called(product, actor)
+	if err := check(args.Get(0).(map[string]int)); err != nil {
+		return err
+	}
+	return args.Error(1)
+}
@@ -1,79 +1,222 @@
 package repository
 
 import (
-	"database/sql"
+	"context"
 	"errors"
-	_ "github.com/lib/pq"
+	"github.com/jackc/pgx/v5"
+	"github.com/ners1us/order-service/internal/database"
+	"github.com/ners1us/order-service/internal/enum"
 	"github.com/ners1us/order-service/internal/model"
+	"math"
+	"time"
 )
 
 type PVZRepository interface {
-	CreatePVZ(pvz *model.PVZ) error
-	GetPVZs(page, limit int) ([]model.PVZ, error)
-	GetAllPVZs() ([]model.PVZ, error)
-	GetPVZByID(id string) (*model.PVZ, error)
+	CreatePVZ(ctx context.Context, pvz *model.PVZ) error
+	CreatePVZs(ctx context.Context, pvzs []model.PVZ) error
+	GetExistingPVZIDs(ctx context.Context, ids []string) ([]string, error)
+	GetPVZs(ctx context.Context, page, limit int) ([]model.PVZ, error)
+	GetPVZPage(ctx context.Context, page, limit int, startDate, endDate time.Time, includeDeleted bool) (*model.PVZPage, error)
+	GetAllPVZs(ctx context.Context) ([]model.PVZ, error)
+	GetPVZByID(ctx context.Context, id string) (*model.PVZ, error)
+	UpdatePVZ(ctx context.Context, pvz *model.PVZ) error
+	UpdatePVZStatus(ctx context.Context, id string, status string) error
+	GetNearbyPVZs(ctx context.Context, latitude, longitude, radius float64, city string, limit int) ([]model.NearbyPVZ, error)
 }
 
+var pvzColumnNames = []string{"id", "registration_date", "city", "address", "latitude", "longitude", "working_hours", "phone", "status"}
+
+const (
+	pvzColumns    = "id, registration_date, city, address, latitude, longitude, working_hours, phone, status"
+	earthRadiusKm = 6371.0
+	kmPerDegree   = 111.045
+)
+
 type pvzRepositoryImpl struct {
-	db *sql.DB
+	db      database.DB
+	replica database.Reader
 }
 
-func NewPVZRepository(db *sql.DB) PVZRepository {
-	return &pvzRepositoryImpl{db}
+// NewPVZRepository reads list pages and the full PVZ list from replica, which
+// may lag behind db. Everything else goes to db.
+func NewPVZRepository(db database.DB, replica database.Reader) PVZRepository {
+	return &pvzRepositoryImpl{db, replica}
 }
 
-func (pr *pvzRepositoryImpl) CreatePVZ(pvz *model.PVZ) error {
-	query := "INSERT INTO pvzs (id, registration_date, city) VALUES ($1, $2, $3)"
-	_, err := pr.db.Exec(query, pvz.ID, pvz.RegistrationDate, pvz.City)
+func (pr *pvzRepositoryImpl) CreatePVZ(ctx context.Context, pvz *model.PVZ) error {
+	query := "INSERT INTO pvzs (" + pvzColumns + ") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)"
+	_, err := pr.db.Exec(ctx, query, pvz.ID, pvz.RegistrationDate, pvz.City, pvz.Address, pvz.Latitude, pvz.Longitude,
+		pvz.WorkingHours, pvz.Phone, pvz.Status)
 	return err
 }
 
-func (pr *pvzRepositoryImpl) GetPVZs(page, limit int) ([]model.PVZ, error) {
+// CreatePVZs copies pvzs into the table in one transaction, so that either all
+// of them are created or none.
+func (pr *pvzRepositoryImpl) CreatePVZs(ctx context.Context, pvzs []model.PVZ) error {
+	_, err := pr.db.CopyFrom(ctx, pgx.Identifier{"pvzs"}, pvzColumnNames, pgx.CopyFromSlice(len(pvzs), func(i int) ([]any, error) {
+		pvz := pvzs[i]
+		return []any{pvz.ID, pvz.RegistrationDate, pvz.City, pvz.Address, pvz.Latitude, pvz.Longitude,
+			pvz.WorkingHours, pvz.Phone, pvz.Status}, nil
+	}))
+	return err
+}
+
+func (pr *pvzRepositoryImpl) GetExistingPVZIDs(ctx context.Context, ids []string) ([]string, error) {
+	rows, err := pr.db.Query(ctx, "SELECT id FROM pvzs WHERE id = ANY($1::text[]::uuid[])", ids)
+	if err != nil {
+		return nil, err
+	}
+	existingIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if len(existingIDs) == 0 {
+		return nil, err
+	}
+	return existingIDs, err
+}
+
+func (pr *pvzRepositoryImpl) GetPVZs(ctx context.Context, page, limit int) ([]model.PVZ, error) {
 	offset := (page - 1) * limit
-	query := "SELECT id, registration_date, city FROM pvzs ORDER BY id LIMIT $1 OFFSET $2"
-	rows, err := pr.db.Query(query, limit, offset)
+	query := "SELECT " + pvzColumns + " FROM pvzs ORDER BY id LIMIT $1 OFFSET $2"
+	rows, err := pr.replica.Query(ctx, query, limit, offset)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-	var pvzs []model.PVZ
-	for rows.Next() {
-		var pvz model.PVZ
-		if err := rows.Scan(&pvz.ID, &pvz.RegistrationDate, &pvz.City); err != nil {
-			return nil, err
-		}
-		pvzs = append(pvzs, pvz)
+	return scanPVZs(rows)
+}
+
+// GetPVZPage sends the page, receptions and products queries in one batch, so
+// the page costs a single round-trip. The receptions and products queries
+// select the page again instead of waiting for its ids.
+func (pr *pvzRepositoryImpl) GetPVZPage(ctx context.Context, page, limit int, startDate, endDate time.Time, includeDeleted bool) (*model.PVZPage, error) {
+	offset := (page - 1) * limit
+	pageIDs := "SELECT id FROM pvzs ORDER BY id LIMIT $1 OFFSET $2"
+	receptionIDs := "SELECT id FROM receptions WHERE pvz_id IN (" + pageIDs + ") AND date_time BETWEEN $3 AND $4"
+	batch := &pgx.Batch{}
+	batch.Queue("SELECT "+pvzColumns+" FROM pvzs ORDER BY id LIMIT $1 OFFSET $2", limit, offset)
+	batch.Queue("SELECT "+receptionColumns+" FROM receptions WHERE id IN ("+receptionIDs+")", limit, offset, startDate, endDate)
+	batch.Queue("SELECT "+productColumns+" FROM products WHERE reception_id IN ("+receptionIDs+") AND (deleted_at IS NULL OR $5)",
+		limit, offset, startDate, endDate, includeDeleted)
+
+	results := pr.replica.SendBatch(ctx, batch)
+	defer results.Close()
+	var pvzPage model.PVZPage
+	rows, err := results.Query()
+	if err != nil {
+		return nil, err
+	}
+	if pvzPage.PVZs, err = scanPVZs(rows); err != nil {
+		return nil, err
+	}
+	if rows, err = results.Query(); err != nil {
+		return nil, err
+	}
+	if pvzPage.Receptions, err = scanReceptions(rows); err != nil {
+		return nil, err
+	}
+	if rows, err = results.Query(); err != nil {
+		return nil, err
 	}
-	return pvzs, nil
+	if pvzPage.Products, err = scanProducts(rows); err != nil {
+		return nil, err
+	}
+	return &pvzPage, results.Close()
+}
+
+func (pr *pvzRepositoryImpl) GetAllPVZs(ctx context.Context) ([]model.PVZ, error) {
+	query := "SELECT " + pvzColumns + " FROM pvzs"
+	rows, err := pr.replica.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return scanPVZs(rows)
+}
+
+func (pr *pvzRepositoryImpl) GetPVZByID(ctx context.Context, id string) (*model.PVZ, error) {
+	query := "SELECT " + pvzColumns + " FROM pvzs WHERE id = $1"
+	pvz, err := scanPVZ(pr.db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &model.PVZ{}, nil
+	}
+	if err != nil {
+		return &model.PVZ{}, err
+	}
+	return pvz, nil
+}
+
+func (pr *pvzRepositoryImpl) UpdatePVZ(ctx context.Context, pvz *model.PVZ) error {
+	query := `UPDATE pvzs
+		SET address = $1, latitude = $2, longitude = $3, working_hours = $4, phone = $5, status = $6
+		WHERE id = $7`
+	_, err := pr.db.Exec(ctx, query, pvz.Address, pvz.Latitude, pvz.Longitude, pvz.WorkingHours, pvz.Phone, pvz.Status, pvz.ID)
+	return err
 }
 
-func (pr *pvzRepositoryImpl) GetAllPVZs() ([]model.PVZ, error) {
-	query := "SELECT id, registration_date, city FROM pvzs"
-	rows, err := pr.db.Query(query)
+func (pr *pvzRepositoryImpl) UpdatePVZStatus(ctx context.Context, id string, status string) error {
+	query := "UPDATE pvzs SET status = $1 WHERE id = $2"
+	_, err := pr.db.Exec(ctx, query, status, id)
+	return err
+}
+
+func (pr *pvzRepositoryImpl) GetNearbyPVZs(ctx context.Context, latitude, longitude, radius float64, city string, limit int) ([]model.NearbyPVZ, error) {
+	latDelta := radius / kmPerDegree
+	lonDelta := radius / (kmPerDegree * math.Cos(latitude*math.Pi/180))
+	crossesAntimeridian := longitude-lonDelta < -180 || longitude+lonDelta > 180
+	query := `SELECT ` + pvzColumns + `, distance FROM (
+			SELECT ` + pvzColumns + `,
+				$1 * 2 * ASIN(LEAST(1, SQRT(
+					POWER(SIN(RADIANS(latitude - $2) / 2), 2) +
+					COS(RADIANS($2)) * COS(RADIANS(latitude)) * POWER(SIN(RADIANS(longitude - $3) / 2), 2)
+				))) AS distance
+			FROM pvzs
+			WHERE status = $4
+				AND latitude BETWEEN $5 AND $6
+				AND (longitude BETWEEN $7 AND $8 OR $9)
+				AND ($10::TEXT = '' OR city = $10)
+		) AS candidates
+		WHERE distance <= $11
+		ORDER BY distance
+		LIMIT $12`
+	rows, err := pr.db.Query(ctx, query, earthRadiusKm, latitude, longitude, enum.PVZStatusActive.String(),
+		latitude-latDelta, latitude+latDelta, longitude-lonDelta, longitude+lonDelta,
+		crossesAntimeridian, city, radius, limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var pvzs []model.PVZ
+	var nearbyPVZs []model.NearbyPVZ
 	for rows.Next() {
-		var pvz model.PVZ
-		if err := rows.Scan(&pvz.ID, &pvz.RegistrationDate, &pvz.City); err != nil {
+		var nearbyPVZ model.NearbyPVZ
+		pvz, err := scanPVZ(rows, &nearbyPVZ.Distance)
+		if err != nil {
 			return nil, err
 		}
-		pvzs = append(pvzs, pvz)
+		nearbyPVZ.PVZ = *pvz
+		nearbyPVZs = append(nearbyPVZs, nearbyPVZ)
 	}
-	return pvzs, nil
+	return nearbyPVZs, rows.Err()
 }
 
-func (pr *pvzRepositoryImpl) GetPVZByID(id string) (*model.PVZ, error) {
+func scanPVZ(row pgx.Row, extra ...any) (*model.PVZ, error) {
 	var pvz model.PVZ
-	query := "SELECT id, registration_date, city FROM pvzs WHERE id = $1"
-	err := pr.db.QueryRow(query, id).Scan(&pvz.ID, &pvz.RegistrationDate, &pvz.City)
-	if errors.Is(err, sql.ErrNoRows) {
-		return &model.PVZ{}, nil
+	dest := []any{&pvz.ID, &pvz.RegistrationDate, &pvz.City, &pvz.Address, &pvz.Latitude, &pvz.Longitude,
+		&pvz.WorkingHours, &pvz.Phone, &pvz.Status}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
 	}
-	if err != nil {
-		return &model.PVZ{}, err
+	if pvz.Latitude == nil || pvz.Longitude == nil {
+		pvz.Latitude, pvz.Longitude = nil, nil
 	}
 	return &pvz, nil
 }
+
+func scanPVZs(rows pgx.Rows) ([]model.PVZ, error) {
+	defer rows.Close()
+	var pvzs []model.PVZ
+	for rows.Next() {
+		pvz, err := scanPVZ(rows)
+		if err != nil {
+			return nil, err
+		}
+		pvzs = append(pvzs, *pvz)
+	}
+	return pvzs, rows.Err()
+}
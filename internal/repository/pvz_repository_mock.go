@@ -1,30 +1,62 @@
 package repository
 
 import (
+	"context"
 	"github.com/ners1us/order-service/internal/model"
 	"github.com/stretchr/testify/mock"
+	"time"
 )
 
 type MockPVZRepository struct {
 	mock.Mock
 }
 
-func (mpr *MockPVZRepository) CreatePVZ(pvz *model.PVZ) error {
+func (mpr *MockPVZRepository) CreatePVZ(ctx context.Context, pvz *model.PVZ) error {
 	args := mpr.Called(pvz)
 	return args.Error(0)
 }
 
-func (mpr *MockPVZRepository) GetPVZs(page, limit int) ([]model.PVZ, error) {
+func (mpr *MockPVZRepository) CreatePVZs(ctx context.Context, pvzs []model.PVZ) error {
+	args := mpr.Called(pvzs)
+	return args.Error(0)
+}
+
+func (mpr *MockPVZRepository) GetExistingPVZIDs(ctx context.Context, ids []string) ([]string, error) {
+	args := mpr.Called(ids)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (mpr *MockPVZRepository) GetPVZs(ctx context.Context, page, limit int) ([]model.PVZ, error) {
 	args := mpr.Called(page, limit)
 	return args.Get(0).([]model.PVZ), args.Error(1)
 }
 
-func (mpr *MockPVZRepository) GetAllPVZs() ([]model.PVZ, error) {
+func (mpr *MockPVZRepository) GetPVZPage(ctx context.Context, page, limit int, startDate, endDate time.Time, includeDeleted bool) (*model.PVZPage, error) {
+	args := mpr.Called(page, limit, startDate, endDate, includeDeleted)
+	return args.Get(0).(*model.PVZPage), args.Error(1)
+}
+
+func (mpr *MockPVZRepository) GetAllPVZs(ctx context.Context) ([]model.PVZ, error) {
 	args := mpr.Called()
 	return args.Get(0).([]model.PVZ), args.Error(1)
 }
 
-func (mpr *MockPVZRepository) GetPVZByID(id string) (*model.PVZ, error) {
+func (mpr *MockPVZRepository) GetPVZByID(ctx context.Context, id string) (*model.PVZ, error) {
 	args := mpr.Called(id)
 	return args.Get(0).(*model.PVZ), args.Error(1)
 }
+
+func (mpr *MockPVZRepository) UpdatePVZ(ctx context.Context, pvz *model.PVZ) error {
+	args := mpr.Called(pvz)
+	return args.Error(0)
+}
+
+func (mpr *MockPVZRepository) UpdatePVZStatus(ctx context.Context, id string, status string) error {
+	args := mpr.Called(id, status)
+	return args.Error(0)
+}
+
+func (mpr *MockPVZRepository) GetNearbyPVZs(ctx context.Context, latitude, longitude, radius float64, city string, limit int) ([]model.NearbyPVZ, error) {
+	args := mpr.Called(latitude, longitude, radius, city, limit)
+	return args.Get(0).([]model.NearbyPVZ), args.Error(1)
+}
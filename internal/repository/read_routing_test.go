@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ners1us/order-service/internal/breaker"
+	"github.com/ners1us/order-service/internal/database"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestPostgresReadRouting runs against two unrelated databases standing for a
+// primary and a replica, so a row is visible only where it was written. Set
+// TEST_PRIMARY_DB_URL and TEST_REPLICA_DB_URL to use local databases instead
+// of containers; their tables are truncated.
+func TestPostgresReadRouting(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	primary, replica := startReadRoutingDatabases(t)
+	router := database.NewReadRouter(primary, []database.Replica{{DB: replica, Breaker: breaker.New("test-replica", 5, time.Minute)}})
+	routed := contractRepositories{
+		PVZ:       NewPVZRepository(primary, router),
+		Reception: NewReceptionRepository(primary, router),
+		Product:   NewProductRepository(primary, router),
+	}
+	replicated := contractRepositories{
+		PVZ:       NewPVZRepository(replica, replica),
+		Reception: NewReceptionRepository(replica, replica),
+		Product:   NewProductRepository(replica, replica),
+	}
+	written := createContractPVZ(t, routed)
+	writtenReception := createContractReception(t, routed, written.ID, enum.StatusInProgress, contractTime)
+	createContractProduct(t, routed, writtenReception.ID, enum.ProductElectronics, contractTime)
+	onReplica := createContractPVZ(t, replicated)
+	replicaReception := createContractReception(t, replicated, onReplica.ID, enum.StatusInProgress, contractTime)
+	replicaProduct := createContractProduct(t, replicated, replicaReception.ID, enum.ProductShoes, contractTime)
+	startDate, endDate := contractTime.Add(-time.Hour), contractTime.Add(time.Hour)
+
+	// Act
+	pvz, pvzErr := routed.PVZ.GetPVZByID(ctx, written.ID)
+	lastReception, lastReceptionErr := routed.Reception.GetLastReceptionByPVZID(ctx, written.ID)
+	pvzs, pvzsErr := routed.PVZ.GetPVZs(ctx, 1, 10)
+	allPVZs, allPVZsErr := routed.PVZ.GetAllPVZs(ctx)
+	pvzPage, pvzPageErr := routed.PVZ.GetPVZPage(ctx, 1, 10, startDate, endDate, false)
+	receptions, receptionsErr := routed.Reception.GetReceptionsByPVZIDsAndDate(ctx, []string{written.ID, onReplica.ID}, startDate, endDate)
+	products, productsErr := routed.Product.GetProductsByReceptionIDs(ctx, []string{writtenReception.ID, replicaReception.ID}, false)
+
+	// Assert
+	require.NoError(t, pvzErr)
+	require.NoError(t, lastReceptionErr)
+	require.NoError(t, pvzsErr)
+	require.NoError(t, allPVZsErr)
+	require.NoError(t, pvzPageErr)
+	require.NoError(t, receptionsErr)
+	require.NoError(t, productsErr)
+	assert.Equal(t, written.ID, pvz.ID)
+	assert.Equal(t, writtenReception.ID, lastReception.ID)
+	require.Len(t, pvzs, 1)
+	assert.Equal(t, onReplica.ID, pvzs[0].ID)
+	require.Len(t, allPVZs, 1)
+	assert.Equal(t, onReplica.ID, allPVZs[0].ID)
+	require.Len(t, pvzPage.PVZs, 1)
+	assert.Equal(t, onReplica.ID, pvzPage.PVZs[0].ID)
+	require.Len(t, receptions, 1)
+	assert.Equal(t, replicaReception.ID, receptions[0].ID)
+	require.Len(t, products, 1)
+	assert.Equal(t, replicaProduct.ID, products[0].ID)
+}
+
+func startReadRoutingDatabases(t *testing.T) (*pgxpool.Pool, *pgxpool.Pool) {
+	primaryURL, replicaURL := os.Getenv("TEST_PRIMARY_DB_URL"), os.Getenv("TEST_REPLICA_DB_URL")
+	if primaryURL == "" || replicaURL == "" {
+		testcontainers.SkipIfProviderIsNotHealthy(t)
+		return startPostgres(t), startPostgres(t)
+	}
+	return openTestDatabase(t, primaryURL), openTestDatabase(t, replicaURL)
+}
+
+func openTestDatabase(t *testing.T, url string) *pgxpool.Pool {
+	ctx := context.Background()
+	db, err := pgxpool.New(ctx, url)
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+	require.NoError(t, database.MigrateUp(ctx, db))
+	_, err = db.Exec(ctx, "TRUNCATE pvzs, receptions, products, product_deletions")
+	require.NoError(t, err)
+	return db
+}
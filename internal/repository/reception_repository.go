@@ -1,65 +1,158 @@
 package repository
 
 import (
-	"database/sql"
+	"context"
 	"errors"
-	"github.com/lib/pq"
-	_ "github.com/lib/pq"
+	"github.com/jackc/pgx/v5"
+	"github.com/ners1us/order-service/internal/database"
+	"github.com/ners1us/order-service/internal/enum"
 	"github.com/ners1us/order-service/internal/model"
 	"time"
 )
 
 type ReceptionRepository interface {
-	CreateReception(reception *model.Reception) error
-	GetLastReceptionByPVZID(pvzID string) (*model.Reception, error)
-	UpdateReceptionStatus(id string, status string) error
-	GetReceptionsByPVZIDsAndDate(pvzIDs []string, startDate, endDate time.Time) ([]model.Reception, error)
+	CreateReception(ctx context.Context, reception *model.Reception, actor string) error
+	GetLastReceptionByPVZID(ctx context.Context, pvzID string) (*model.Reception, error)
+	CloseReception(ctx context.Context, id string, closedAt time.Time, actor string) error
+	GetReceptionsByPVZIDsAndDate(ctx context.Context, pvzIDs []string, startDate, endDate time.Time) ([]model.Reception, error)
+	GetReceptionByID(ctx context.Context, id string) (*model.Reception, error)
+	GetOpenReceptions(ctx context.Context) ([]model.OpenReception, error)
+	GetReceptionEvents(ctx context.Context, receptionID string) ([]model.ReceptionEvent, error)
 }
 
+const receptionColumns = "id, date_time, pvz_id, status, closed_at"
+
+// Every change to a reception or its products appends to reception_events in
+// the same statement or transaction, so the log cannot miss a committed change.
+const receptionEventColumns = `id, reception_id, type, COALESCE(actor, ''), occurred_at,
+	COALESCE(product_id::text, ''), COALESCE(product_type, ''), COALESCE(reason, '')`
+
 type receptionRepositoryImpl struct {
-	db *sql.DB
+	db      database.DB
+	replica database.Reader
 }
 
-func NewReceptionRepository(db *sql.DB) ReceptionRepository {
-	return &receptionRepositoryImpl{db}
+// NewReceptionRepository reads receptions by PVZ and date from replica, which
+// may lag behind db. Lookups made while changing receptions or products go to
+// db.
+func NewReceptionRepository(db database.DB, replica database.Reader) ReceptionRepository {
+	return &receptionRepositoryImpl{db, replica}
 }
 
-func (rr *receptionRepositoryImpl) CreateReception(reception *model.Reception) error {
-	query := "INSERT INTO receptions (id, date_time, pvz_id, status) VALUES ($1, $2, $3, $4)"
-	_, err := rr.db.Exec(query, reception.ID, reception.DateTime, reception.PVZID, reception.Status)
+func (rr *receptionRepositoryImpl) CreateReception(ctx context.Context, reception *model.Reception, actor string) error {
+	query := `WITH created AS (
+			INSERT INTO receptions (id, date_time, pvz_id, status) VALUES ($1, $2, $3, $4) RETURNING id, date_time
+		)
+		INSERT INTO reception_events (reception_id, type, actor, occurred_at)
+		SELECT id, $5, NULLIF($6, ''), date_time FROM created`
+	_, err := rr.db.Exec(ctx, query, reception.ID, reception.DateTime, reception.PVZID, reception.Status,
+		enum.ReceptionEventOpened.String(), actor)
 	return err
 }
 
-func (rr *receptionRepositoryImpl) GetLastReceptionByPVZID(pvzID string) (*model.Reception, error) {
-	var reception model.Reception
-	query := "SELECT id, date_time, pvz_id, status FROM receptions WHERE pvz_id = $1 ORDER BY date_time DESC LIMIT 1"
-	err := rr.db.QueryRow(query, pvzID).Scan(&reception.ID, &reception.DateTime, &reception.PVZID, &reception.Status)
-	if errors.Is(err, sql.ErrNoRows) {
+func (rr *receptionRepositoryImpl) GetLastReceptionByPVZID(ctx context.Context, pvzID string) (*model.Reception, error) {
+	query := "SELECT " + receptionColumns + " FROM receptions WHERE pvz_id = $1 ORDER BY status = $2 DESC, date_time DESC LIMIT 1"
+	reception, err := scanReception(rr.db.QueryRow(ctx, query, pvzID, enum.StatusInProgress.String()))
+	if errors.Is(err, pgx.ErrNoRows) {
 		return &model.Reception{}, nil
 	}
-	return &reception, err
+	return reception, err
 }
 
-func (rr *receptionRepositoryImpl) UpdateReceptionStatus(id string, status string) error {
-	query := "UPDATE receptions SET status = $1 WHERE id = $2"
-	_, err := rr.db.Exec(query, status, id)
+func (rr *receptionRepositoryImpl) CloseReception(ctx context.Context, id string, closedAt time.Time, actor string) error {
+	query := `WITH closed AS (
+			UPDATE receptions SET status = $1, closed_at = $2 WHERE id = $3 RETURNING id, closed_at
+		)
+		INSERT INTO reception_events (reception_id, type, actor, occurred_at)
+		SELECT id, $4, NULLIF($5, ''), closed_at FROM closed`
+	_, err := rr.db.Exec(ctx, query, enum.StatusClosed.String(), closedAt, id, enum.ReceptionEventClosed.String(), actor)
 	return err
 }
 
-func (rr *receptionRepositoryImpl) GetReceptionsByPVZIDsAndDate(pvzIDs []string, startDate, endDate time.Time) ([]model.Reception, error) {
-	query := "SELECT id, date_time, pvz_id, status FROM receptions WHERE pvz_id = ANY($1) AND date_time BETWEEN $2 AND $3"
-	rows, err := rr.db.Query(query, pq.Array(pvzIDs), startDate, endDate)
+func (rr *receptionRepositoryImpl) GetReceptionsByPVZIDsAndDate(ctx context.Context, pvzIDs []string, startDate, endDate time.Time) ([]model.Reception, error) {
+	query := "SELECT " + receptionColumns + " FROM receptions WHERE pvz_id = ANY($1) AND date_time BETWEEN $2 AND $3"
+	rows, err := rr.replica.Query(ctx, query, pvzIDs, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return scanReceptions(rows)
+}
+
+func (rr *receptionRepositoryImpl) GetReceptionByID(ctx context.Context, id string) (*model.Reception, error) {
+	query := "SELECT " + receptionColumns + " FROM receptions WHERE id = $1"
+	reception, err := scanReception(rr.db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &model.Reception{}, nil
+	}
+	return reception, err
+}
+
+func (rr *receptionRepositoryImpl) GetOpenReceptions(ctx context.Context) ([]model.OpenReception, error) {
+	query := `SELECT r.id, r.date_time, r.pvz_id, r.status, p.city,
+			GREATEST(r.date_time, COALESCE((
+				SELECT MAX(rrr.reviewed_at) FROM reception_reopen_requests rrr
+				WHERE rrr.reception_id = r.id AND rrr.status = $2
+			), r.date_time)) AS opened_at,
+			(SELECT MAX(e.created_at) FROM reception_escalations e WHERE e.reception_id = r.id) AS escalated_at
+		FROM receptions r
+		JOIN pvzs p ON p.id = r.pvz_id
+		WHERE r.status = $1`
+	rows, err := rr.db.Query(ctx, query, enum.StatusInProgress.String(), enum.ReopenRequestApproved.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var openReceptions []model.OpenReception
+	for rows.Next() {
+		var openReception model.OpenReception
+		reception := &openReception.Reception
+		err := rows.Scan(&reception.ID, &reception.DateTime, &reception.PVZID, &reception.Status,
+			&openReception.City, &openReception.OpenedAt, &openReception.EscalatedAt)
+		if err != nil {
+			return nil, err
+		}
+		openReceptions = append(openReceptions, openReception)
+	}
+	return openReceptions, rows.Err()
+}
+
+func (rr *receptionRepositoryImpl) GetReceptionEvents(ctx context.Context, receptionID string) ([]model.ReceptionEvent, error) {
+	query := "SELECT " + receptionEventColumns + " FROM reception_events WHERE reception_id = $1 ORDER BY occurred_at, id"
+	rows, err := rr.db.Query(ctx, query, receptionID)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
+	var events []model.ReceptionEvent
+	for rows.Next() {
+		var event model.ReceptionEvent
+		err := rows.Scan(&event.ID, &event.ReceptionID, &event.Type, &event.Actor, &event.OccurredAt,
+			&event.ProductID, &event.ProductType, &event.Reason)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func scanReception(row pgx.Row) (*model.Reception, error) {
+	var reception model.Reception
+	if err := row.Scan(&reception.ID, &reception.DateTime, &reception.PVZID, &reception.Status, &reception.ClosedAt); err != nil {
+		return nil, err
+	}
+	return &reception, nil
+}
+
+func scanReceptions(rows pgx.Rows) ([]model.Reception, error) {
 	defer rows.Close()
 	var receptions []model.Reception
 	for rows.Next() {
-		var reception model.Reception
-		if err := rows.Scan(&reception.ID, &reception.DateTime, &reception.PVZID, &reception.Status); err != nil {
+		reception, err := scanReception(rows)
+		if err != nil {
 			return nil, err
 		}
-		receptions = append(receptions, reception)
+		receptions = append(receptions, *reception)
 	}
-	return receptions, nil
+	return receptions, rows.Err()
 }
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"github.com/ners1us/order-service/internal/model"
 	"github.com/stretchr/testify/mock"
 	"time"
@@ -10,22 +11,37 @@ type MockReceptionRepository struct {
 	mock.Mock
 }
 
-func (mrr *MockReceptionRepository) CreateReception(reception *model.Reception) error {
-	args := mrr.Called(reception)
+func (mrr *MockReceptionRepository) CreateReception(ctx context.Context, reception *model.Reception, actor string) error {
+	args := mrr.Called(reception, actor)
 	return args.Error(0)
 }
 
-func (mrr *MockReceptionRepository) GetLastReceptionByPVZID(pvzID string) (*model.Reception, error) {
+func (mrr *MockReceptionRepository) GetLastReceptionByPVZID(ctx context.Context, pvzID string) (*model.Reception, error) {
 	args := mrr.Called(pvzID)
 	return args.Get(0).(*model.Reception), args.Error(1)
 }
 
-func (mrr *MockReceptionRepository) UpdateReceptionStatus(id string, status string) error {
-	args := mrr.Called(id, status)
+func (mrr *MockReceptionRepository) CloseReception(ctx context.Context, id string, closedAt time.Time, actor string) error {
+	args := mrr.Called(id, closedAt, actor)
 	return args.Error(0)
 }
 
-func (mrr *MockReceptionRepository) GetReceptionsByPVZIDsAndDate(pvzIDs []string, startDate, endDate time.Time) ([]model.Reception, error) {
+func (mrr *MockReceptionRepository) GetReceptionsByPVZIDsAndDate(ctx context.Context, pvzIDs []string, startDate, endDate time.Time) ([]model.Reception, error) {
 	args := mrr.Called(pvzIDs, startDate, endDate)
 	return args.Get(0).([]model.Reception), args.Error(1)
 }
+
+func (mrr *MockReceptionRepository) GetReceptionByID(ctx context.Context, id string) (*model.Reception, error) {
+	args := mrr.Called(id)
+	return args.Get(0).(*model.Reception), args.Error(1)
+}
+
+func (mrr *MockReceptionRepository) GetOpenReceptions(ctx context.Context) ([]model.OpenReception, error) {
+	args := mrr.Called()
+	return args.Get(0).([]model.OpenReception), args.Error(1)
+}
+
+func (mrr *MockReceptionRepository) GetReceptionEvents(ctx context.Context, receptionID string) ([]model.ReceptionEvent, error) {
+	args := mrr.Called(receptionID)
+	return args.Get(0).([]model.ReceptionEvent), args.Error(1)
+}
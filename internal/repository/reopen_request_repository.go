@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/ners1us/order-service/internal/database"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+)
+
+type ReopenRequestRepository interface {
+	CreateReopenRequest(ctx context.Context, request *model.ReopenRequest, receptionStatus string) error
+	ResolveReopenRequest(ctx context.Context, request *model.ReopenRequest, receptionStatus string) error
+	GetReopenRequestByID(ctx context.Context, id string) (*model.ReopenRequest, error)
+	GetReopenRequestsByStatus(ctx context.Context, status string) ([]model.ReopenRequest, error)
+	GetReopenRequestsByReceptionID(ctx context.Context, receptionID string) ([]model.ReopenRequest, error)
+}
+
+const reopenRequestColumns = "id, reception_id, reason, requested_by, requested_at, status, reviewed_by, review_comment, reviewed_at"
+
+type reopenRequestRepositoryImpl struct {
+	db database.DB
+}
+
+func NewReopenRequestRepository(db database.DB) ReopenRequestRepository {
+	return &reopenRequestRepositoryImpl{db}
+}
+
+func (rrr *reopenRequestRepositoryImpl) CreateReopenRequest(ctx context.Context, request *model.ReopenRequest, receptionStatus string) error {
+	tx, err := rrr.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	receptionQuery := "UPDATE receptions SET status = $1 WHERE id = $2 AND status = $3"
+	tag, err := tx.Exec(ctx, receptionQuery, receptionStatus, request.ReceptionID, enum.StatusClosed.String())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return enum.ErrReceptionNotClosed
+	}
+	query := "INSERT INTO reception_reopen_requests (id, reception_id, reason, requested_by, requested_at, status) VALUES ($1, $2, $3, $4, $5, $6)"
+	_, err = tx.Exec(ctx, query, request.ID, request.ReceptionID, request.Reason, request.RequestedBy, request.RequestedAt, request.Status)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (rrr *reopenRequestRepositoryImpl) ResolveReopenRequest(ctx context.Context, request *model.ReopenRequest, receptionStatus string) error {
+	tx, err := rrr.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := "UPDATE reception_reopen_requests SET status = $1, reviewed_by = $2, review_comment = $3, reviewed_at = $4 WHERE id = $5 AND status = $6"
+	tag, err := tx.Exec(ctx, query, request.Status, request.ReviewedBy, request.ReviewComment, request.ReviewedAt, request.ID,
+		enum.ReopenRequestPending.String())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return enum.ErrReopenRequestResolved
+	}
+	receptionQuery := "UPDATE receptions SET status = $1, closed_at = CASE WHEN $1 = $2 THEN NULL ELSE closed_at END WHERE id = $3 AND status = $4"
+	tag, err = tx.Exec(ctx, receptionQuery, receptionStatus, enum.StatusInProgress.String(), request.ReceptionID,
+		enum.StatusReopenRequested.String())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return enum.ErrReopenRequestResolved
+	}
+	if receptionStatus == enum.StatusInProgress.String() {
+		eventQuery := "INSERT INTO reception_events (reception_id, type, actor, occurred_at, reason) VALUES ($1, $2, $3, $4, $5)"
+		_, err = tx.Exec(ctx, eventQuery, request.ReceptionID, enum.ReceptionEventReopened.String(), request.ReviewedBy,
+			request.ReviewedAt, request.Reason)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (rrr *reopenRequestRepositoryImpl) GetReopenRequestByID(ctx context.Context, id string) (*model.ReopenRequest, error) {
+	query := "SELECT " + reopenRequestColumns + " FROM reception_reopen_requests WHERE id = $1"
+	request, err := scanReopenRequest(rrr.db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &model.ReopenRequest{}, nil
+	}
+	if err != nil {
+		return &model.ReopenRequest{}, err
+	}
+	return request, nil
+}
+
+func (rrr *reopenRequestRepositoryImpl) GetReopenRequestsByStatus(ctx context.Context, status string) ([]model.ReopenRequest, error) {
+	query := "SELECT " + reopenRequestColumns + " FROM reception_reopen_requests WHERE status = $1 ORDER BY requested_at"
+	rows, err := rrr.db.Query(ctx, query, status)
+	if err != nil {
+		return nil, err
+	}
+	return scanReopenRequests(rows)
+}
+
+func (rrr *reopenRequestRepositoryImpl) GetReopenRequestsByReceptionID(ctx context.Context, receptionID string) ([]model.ReopenRequest, error) {
+	query := "SELECT " + reopenRequestColumns + " FROM reception_reopen_requests WHERE reception_id = $1 ORDER BY requested_at"
+	rows, err := rrr.db.Query(ctx, query, receptionID)
+	if err != nil {
+		return nil, err
+	}
+	return scanReopenRequests(rows)
+}
+
+func scanReopenRequest(row pgx.Row) (*model.ReopenRequest, error) {
+	var request model.ReopenRequest
+	err := row.Scan(&request.ID, &request.ReceptionID, &request.Reason, &request.RequestedBy, &request.RequestedAt,
+		&request.Status, &request.ReviewedBy, &request.ReviewComment, &request.ReviewedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func scanReopenRequests(rows pgx.Rows) ([]model.ReopenRequest, error) {
+	defer rows.Close()
+	var requests []model.ReopenRequest
+	for rows.Next() {
+		request, err := scanReopenRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, *request)
+	}
+	return requests, rows.Err()
+}
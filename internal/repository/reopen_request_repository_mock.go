@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReopenRequestRepository struct {
+	mock.Mock
+}
+
+func (mrr *MockReopenRequestRepository) CreateReopenRequest(ctx context.Context, request *model.ReopenRequest, receptionStatus string) error {
+	args := mrr.Called(request, receptionStatus)
+	return args.Error(0)
+}
+
+func (mrr *MockReopenRequestRepository) ResolveReopenRequest(ctx context.Context, request *model.ReopenRequest, receptionStatus string) error {
+	args := mrr.Called(request, receptionStatus)
+	return args.Error(0)
+}
+
+func (mrr *MockReopenRequestRepository) GetReopenRequestByID(ctx context.Context, id string) (*model.ReopenRequest, error) {
+	args := mrr.Called(id)
+	return args.Get(0).(*model.ReopenRequest), args.Error(1)
+}
+
+func (mrr *MockReopenRequestRepository) GetReopenRequestsByStatus(ctx context.Context, status string) ([]model.ReopenRequest, error) {
+	args := mrr.Called(status)
+	return args.Get(0).([]model.ReopenRequest), args.Error(1)
+}
+
+func (mrr *MockReopenRequestRepository) GetReopenRequestsByReceptionID(ctx context.Context, receptionID string) ([]model.ReopenRequest, error) {
+	args := mrr.Called(receptionID)
+	return args.Get(0).([]model.ReopenRequest), args.Error(1)
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"testing"
+	"time"
+)
+
+// TestPostgresReopenRequests checks that the status guards of the reopen
+// request repository hold when two callers race past the service checks.
+func TestPostgresReopenRequests(t *testing.T) {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	db := startPostgres(t)
+	repos := contractRepositories{
+		PVZ:       NewPVZRepository(db, db),
+		Reception: NewReceptionRepository(db, db),
+	}
+	reopenRequests := NewReopenRequestRepository(db)
+
+	newClosedReception := func(t *testing.T) model.Reception {
+		pvz := createContractPVZ(t, repos)
+		reception := createContractReception(t, repos, pvz.ID, enum.StatusInProgress, contractTime)
+		require.NoError(t, repos.Reception.CloseReception(context.Background(), reception.ID, contractTime.Add(time.Hour), contractActor))
+		return reception
+	}
+	newRequest := func(receptionID string) *model.ReopenRequest {
+		return &model.ReopenRequest{
+			ID:          uuid.NewString(),
+			ReceptionID: receptionID,
+			Reason:      "пропущен товар",
+			RequestedBy: contractActor,
+			RequestedAt: contractTime.Add(2 * time.Hour),
+			Status:      enum.ReopenRequestPending.String(),
+		}
+	}
+
+	t.Run("CreateTwice", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		reception := newClosedReception(t)
+		require.NoError(t, reopenRequests.CreateReopenRequest(ctx, newRequest(reception.ID), enum.StatusReopenRequested.String()))
+
+		// Act
+		err := reopenRequests.CreateReopenRequest(ctx, newRequest(reception.ID), enum.StatusReopenRequested.String())
+		requests, errRequests := reopenRequests.GetReopenRequestsByReceptionID(ctx, reception.ID)
+
+		// Assert
+		assert.ErrorIs(t, err, enum.ErrReceptionNotClosed)
+		assert.NoError(t, errRequests)
+		assert.Len(t, requests, 1)
+	})
+
+	t.Run("ResolveTwice", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		reception := newClosedReception(t)
+		request := newRequest(reception.ID)
+		require.NoError(t, reopenRequests.CreateReopenRequest(ctx, request, enum.StatusReopenRequested.String()))
+		reviewedAt := contractTime.Add(3 * time.Hour)
+		approved := *request
+		approved.Status = enum.ReopenRequestApproved.String()
+		approved.ReviewedAt = &reviewedAt
+		rejected := *request
+		rejected.Status = enum.ReopenRequestRejected.String()
+		rejected.ReviewedAt = &reviewedAt
+		require.NoError(t, reopenRequests.ResolveReopenRequest(ctx, &approved, enum.StatusInProgress.String()))
+
+		// Act
+		err := reopenRequests.ResolveReopenRequest(ctx, &rejected, enum.StatusClosed.String())
+		stored, errStored := reopenRequests.GetReopenRequestByID(ctx, request.ID)
+		current, errCurrent := repos.Reception.GetReceptionByID(ctx, reception.ID)
+
+		// Assert
+		assert.ErrorIs(t, err, enum.ErrReopenRequestResolved)
+		assert.NoError(t, errStored)
+		assert.Equal(t, enum.ReopenRequestApproved.String(), stored.Status)
+		assert.NoError(t, errCurrent)
+		assert.Equal(t, enum.StatusInProgress.String(), current.Status)
+	})
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/ners1us/order-service/internal/database"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+)
+
+type ReportRepository interface {
+	GetReport(ctx context.Context, filter *model.ReportFilter) ([]model.ReportRow, error)
+}
+
+var reportGroupColumns = map[string]string{
+	enum.ReportGroupPVZ.String():  "r.pvz_id::text",
+	enum.ReportGroupCity.String(): "p.city",
+}
+
+// reportQuery buckets receptions by opening and closing time and products by
+// creation time separately, then joins the three aggregates on bucket and group.
+// Deleted products count only when $4 is true.
+const reportQuery = `WITH reception_items AS (
+		SELECT r.id, r.date_time, r.closed_at, %[1]s AS group_key, COUNT(pr.id) AS items
+		FROM receptions r
+		JOIN pvzs p ON p.id = r.pvz_id
+		LEFT JOIN products pr ON pr.reception_id = r.id AND (pr.deleted_at IS NULL OR $4)
+		WHERE r.date_time BETWEEN $2 AND $3 OR r.closed_at BETWEEN $2 AND $3
+		GROUP BY r.id, r.date_time, r.closed_at, group_key
+	),
+	opened AS (
+		SELECT date_trunc($1, date_time) AS bucket, group_key, COUNT(*) AS receptions_opened, AVG(items) AS avg_items
+		FROM reception_items
+		WHERE date_time BETWEEN $2 AND $3
+		GROUP BY bucket, group_key
+	),
+	closed AS (
+		SELECT date_trunc($1, closed_at) AS bucket, group_key, COUNT(*) AS receptions_closed,
+			AVG(EXTRACT(EPOCH FROM closed_at - date_time)) AS avg_duration
+		FROM reception_items
+		WHERE closed_at BETWEEN $2 AND $3
+		GROUP BY bucket, group_key
+	),
+	product_types AS (
+		SELECT date_trunc($1, pr.date_time) AS bucket, %[1]s AS group_key, pr.type, COUNT(*) AS products
+		FROM products pr
+		JOIN receptions r ON r.id = pr.reception_id
+		JOIN pvzs p ON p.id = r.pvz_id
+		WHERE pr.date_time BETWEEN $2 AND $3 AND (pr.deleted_at IS NULL OR $4)
+		GROUP BY bucket, group_key, pr.type
+	),
+	products AS (
+		SELECT bucket, group_key, jsonb_object_agg(type, products) AS products_by_type, SUM(products) AS total_products
+		FROM product_types
+		GROUP BY bucket, group_key
+	),
+	buckets AS (
+		SELECT bucket, group_key FROM opened
+		UNION SELECT bucket, group_key FROM closed
+		UNION SELECT bucket, group_key FROM products
+	)
+	SELECT b.bucket, b.group_key,
+		COALESCE(o.receptions_opened, 0), COALESCE(c.receptions_closed, 0),
+		COALESCE(pt.products_by_type, '{}'), COALESCE(pt.total_products, 0),
+		c.avg_duration, COALESCE(o.avg_items, 0)
+	FROM buckets b
+	LEFT JOIN opened o USING (bucket, group_key)
+	LEFT JOIN closed c USING (bucket, group_key)
+	LEFT JOIN products pt USING (bucket, group_key)
+	ORDER BY b.bucket, b.group_key`
+
+type reportRepositoryImpl struct {
+	db database.Reader
+}
+
+func NewReportRepository(db database.Reader) ReportRepository {
+	return &reportRepositoryImpl{db}
+}
+
+func (rr *reportRepositoryImpl) GetReport(ctx context.Context, filter *model.ReportFilter) ([]model.ReportRow, error) {
+	groupColumn, ok := reportGroupColumns[filter.GroupBy]
+	if !ok {
+		return nil, enum.ErrInvalidReportGroup
+	}
+	query := fmt.Sprintf(reportQuery, groupColumn)
+	rows, err := rr.db.Query(ctx, query, filter.Period, filter.StartDate, filter.EndDate, filter.IncludeDeleted)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	reportRows := make([]model.ReportRow, 0)
+	for rows.Next() {
+		var row model.ReportRow
+		var productsByType []byte
+		err := rows.Scan(&row.PeriodStart, &row.Group, &row.ReceptionsOpened, &row.ReceptionsClosed,
+			&productsByType, &row.TotalProducts, &row.AvgReceptionDurationSeconds, &row.AvgProductsPerReception)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(productsByType, &row.ProductsByType); err != nil {
+			return nil, err
+		}
+		reportRows = append(reportRows, row)
+	}
+	return reportRows, rows.Err()
+}
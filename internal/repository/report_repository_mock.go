@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReportRepository struct {
+	mock.Mock
+}
+
+func (mrr *MockReportRepository) GetReport(ctx context.Context, filter *model.ReportFilter) ([]model.ReportRow, error) {
+	args := mrr.Called(filter)
+	return args.Get(0).([]model.ReportRow), args.Error(1)
+}
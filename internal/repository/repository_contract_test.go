@@ -0,0 +1,846 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ners1us/order-service/internal/database"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// contractRepositories are the repositories whose implementations must behave
+// the same whatever the storage.
+type contractRepositories struct {
+	PVZ          PVZRepository
+	Reception    ReceptionRepository
+	Product      ProductRepository
+	User         UserRepository
+	CapacityRule CapacityRuleRepository
+}
+
+var contractTime = time.Date(2025, time.March, 10, 9, 30, 0, 0, time.UTC)
+
+// contractActor opens the receptions and adds the products created by the
+// contract helpers.
+var contractActor = uuid.NewString()
+
+func TestMemoryRepositoryContract(t *testing.T) {
+	runRepositoryContract(t, func(t *testing.T) contractRepositories {
+		store := NewMemoryStore()
+		return contractRepositories{
+			PVZ:          NewMemoryPVZRepository(store),
+			Reception:    NewMemoryReceptionRepository(store),
+			Product:      NewMemoryProductRepository(store),
+			User:         NewMemoryUserRepository(store),
+			CapacityRule: NewMemoryCapacityRuleRepository(store),
+		}
+	})
+}
+
+func TestPostgresRepositoryContract(t *testing.T) {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	db := startPostgres(t)
+	runRepositoryContract(t, func(t *testing.T) contractRepositories {
+		_, err := db.Exec(context.Background(), `TRUNCATE users, pvzs, receptions, products, product_deletions, reception_reopen_requests,
+			reception_escalations, pvz_capacity_rules, reception_events`)
+		require.NoError(t, err)
+		return contractRepositories{
+			PVZ:          NewPVZRepository(db, db),
+			Reception:    NewReceptionRepository(db, db),
+			Product:      NewProductRepository(db, db),
+			User:         NewUserRepository(db),
+			CapacityRule: NewCapacityRuleRepository(db),
+		}
+	})
+}
+
+func startPostgres(t *testing.T) *pgxpool.Pool {
+	ctx := context.Background()
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "postgres:latest",
+			ExposedPorts: []string{"5432/tcp"},
+			Env: map[string]string{
+				"POSTGRES_USER":     "testuser",
+				"POSTGRES_PASSWORD": "testpassword",
+				"POSTGRES_DB":       "test-db",
+			},
+			WaitingFor: wait.ForListeningPort("5432/tcp"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = container.Terminate(ctx)
+	})
+
+	host, err := container.Host(ctx)
+	require.NoError(t, err)
+	mappedPort, err := container.MappedPort(ctx, "5432")
+	require.NoError(t, err)
+	db, err := pgxpool.New(ctx, fmt.Sprintf("host=%s port=%s user=testuser password=testpassword dbname=test-db sslmode=disable",
+		host, mappedPort.Port()))
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+	require.NoError(t, database.MigrateUp(ctx, db))
+	return db
+}
+
+// runRepositoryContract runs every case against fresh, empty repositories
+// returned by newRepositories.
+func runRepositoryContract(t *testing.T, newRepositories func(t *testing.T) contractRepositories) {
+	cases := map[string]func(t *testing.T, repos contractRepositories){
+		"UserRoundTrip":                             testUserRoundTrip,
+		"UserDuplicateEmail":                        testUserDuplicateEmail,
+		"PVZRoundTrip":                              testPVZRoundTrip,
+		"PVZNotFound":                               testPVZNotFound,
+		"PVZMalformedID":                            testPVZMalformedID,
+		"PVZDuplicateID":                            testPVZDuplicateID,
+		"PVZBatchIsAtomic":                          testPVZBatchIsAtomic,
+		"PVZPagesOrderedByID":                       testPVZPagesOrderedByID,
+		"PVZPageWithReceptionsAndProducts":          testPVZPageWithReceptionsAndProducts,
+		"PVZUpdate":                                 testPVZUpdate,
+		"PVZNearbyOrderedByDistance":                testPVZNearbyOrderedByDistance,
+		"ReceptionRequiresPVZ":                      testReceptionRequiresPVZ,
+		"ReceptionOneInProgressPerPVZ":              testReceptionOneInProgressPerPVZ,
+		"ReceptionLastPrefersInProgress":            testReceptionLastPrefersInProgress,
+		"ReceptionClose":                            testReceptionClose,
+		"ReceptionsByPVZIDsAndDateIncludeBounds":    testReceptionsByPVZIDsAndDateIncludeBounds,
+		"ReceptionsOpenIncludeCity":                 testReceptionsOpenIncludeCity,
+		"ReceptionEventsInOrder":                    testReceptionEventsInOrder,
+		"ProductRequiresReception":                  testProductRequiresReception,
+		"ProductLastAndDelete":                      testProductLastAndDelete,
+		"ProductsByReceptionIDsAndCounts":           testProductsByReceptionIDsAndCounts,
+		"ProductDeleteWithReason":                   testProductDeleteWithReason,
+		"ProductDeleteWithReasonTwice":              testProductDeleteWithReasonTwice,
+		"ProductWithinCapacityIsSerialized":         testProductWithinCapacityIsSerialized,
+		"ProductWithinCapacityNeedsOpenReception":   testProductWithinCapacityNeedsOpenReception,
+		"ProductSoftDeleteKeepsHistory":             testProductSoftDeleteKeepsHistory,
+		"CapacityRulesDefaultAndOverwrite":          testCapacityRulesDefaultAndOverwrite,
+		"ReceptionAndProductLookupsOfUnknownEntity": testReceptionAndProductLookupsOfUnknownEntity,
+	}
+	for name, run := range cases {
+		t.Run(name, func(t *testing.T) {
+			run(t, newRepositories(t))
+		})
+	}
+}
+
+func newContractPVZ(city string, latitude, longitude float64) model.PVZ {
+	return model.PVZ{
+		ID:               uuid.NewString(),
+		RegistrationDate: contractTime,
+		City:             city,
+		Address:          "ул. Тестовая, 1",
+		Latitude:         &latitude,
+		Longitude:        &longitude,
+		WorkingHours:     "09:00-21:00",
+		Phone:            "+79000000000",
+		Status:           enum.PVZStatusActive.String(),
+	}
+}
+
+func createContractPVZ(t *testing.T, repos contractRepositories) model.PVZ {
+	pvz := newContractPVZ(enum.CityMoscow.String(), 55.75, 37.61)
+	require.NoError(t, repos.PVZ.CreatePVZ(context.Background(), &pvz))
+	return pvz
+}
+
+func createContractReception(t *testing.T, repos contractRepositories, pvzID string, status enum.Status, dateTime time.Time) model.Reception {
+	reception := model.Reception{ID: uuid.NewString(), DateTime: dateTime, PVZID: pvzID, Status: status.String()}
+	require.NoError(t, repos.Reception.CreateReception(context.Background(), &reception, contractActor))
+	return reception
+}
+
+func createContractProduct(t *testing.T, repos contractRepositories, receptionID string, productType enum.ProductType, dateTime time.Time) model.Product {
+	product := model.Product{ID: uuid.NewString(), DateTime: dateTime, Type: productType.String(), ReceptionID: receptionID}
+	require.NoError(t, repos.Product.CreateProduct(context.Background(), &product, contractActor))
+	return product
+}
+
+func testUserRoundTrip(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	user := &model.User{ID: uuid.NewString(), Email: "user@example.com", Password: "hash", Role: enum.RoleEmployee.String()}
+	require.NoError(t, repos.User.CreateUser(ctx, user))
+
+	// Act
+	found, errFound := repos.User.GetUserByEmail(ctx, user.Email)
+	missing, errMissing := repos.User.GetUserByEmail(ctx, "missing@example.com")
+
+	// Assert
+	assert.NoError(t, errFound)
+	assert.Equal(t, user, found)
+	assert.NoError(t, errMissing)
+	assert.Equal(t, &model.User{}, missing)
+}
+
+func testUserDuplicateEmail(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	user := &model.User{ID: uuid.NewString(), Email: "user@example.com", Password: "hash", Role: enum.RoleEmployee.String()}
+	require.NoError(t, repos.User.CreateUser(ctx, user))
+	duplicate := &model.User{ID: uuid.NewString(), Email: user.Email, Password: "hash", Role: enum.RoleModerator.String()}
+
+	// Act
+	err := repos.User.CreateUser(ctx, duplicate)
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func testPVZRoundTrip(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	pvz := newContractPVZ(enum.CityKazan.String(), 55.79, 49.10)
+	withoutCoordinates := newContractPVZ(enum.CityKazan.String(), 0, 0)
+	withoutCoordinates.Latitude, withoutCoordinates.Longitude = nil, nil
+	require.NoError(t, repos.PVZ.CreatePVZ(ctx, &pvz))
+	require.NoError(t, repos.PVZ.CreatePVZ(ctx, &withoutCoordinates))
+
+	// Act
+	found, err := repos.PVZ.GetPVZByID(ctx, pvz.ID)
+	foundWithoutCoordinates, errWithoutCoordinates := repos.PVZ.GetPVZByID(ctx, withoutCoordinates.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.WithinDuration(t, pvz.RegistrationDate, found.RegistrationDate, 0)
+	found.RegistrationDate = pvz.RegistrationDate
+	assert.Equal(t, &pvz, found)
+	assert.NoError(t, errWithoutCoordinates)
+	assert.Nil(t, foundWithoutCoordinates.Latitude)
+	assert.Nil(t, foundWithoutCoordinates.Longitude)
+}
+
+func testPVZNotFound(t *testing.T, repos contractRepositories) {
+	// Act
+	pvz, err := repos.PVZ.GetPVZByID(context.Background(), uuid.NewString())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &model.PVZ{}, pvz)
+}
+
+func testPVZMalformedID(t *testing.T, repos contractRepositories) {
+	// Act
+	_, err := repos.PVZ.GetPVZByID(context.Background(), "not-a-uuid")
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func testPVZDuplicateID(t *testing.T, repos contractRepositories) {
+	// Arrange
+	pvz := createContractPVZ(t, repos)
+
+	// Act
+	err := repos.PVZ.CreatePVZ(context.Background(), &pvz)
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func testPVZBatchIsAtomic(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	existing := createContractPVZ(t, repos)
+	fresh := newContractPVZ(enum.CityMoscow.String(), 55.70, 37.60)
+
+	// Act
+	err := repos.PVZ.CreatePVZs(ctx, []model.PVZ{fresh, existing})
+	existingIDs, errExisting := repos.PVZ.GetExistingPVZIDs(ctx, []string{fresh.ID, existing.ID})
+
+	// Assert
+	assert.Error(t, err)
+	assert.NoError(t, errExisting)
+	assert.Equal(t, []string{existing.ID}, existingIDs)
+}
+
+func testPVZPagesOrderedByID(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	var ids []string
+	for i := 0; i < 5; i++ {
+		ids = append(ids, createContractPVZ(t, repos).ID)
+	}
+
+	// Act
+	firstPage, errFirst := repos.PVZ.GetPVZs(ctx, 1, 2)
+	lastPage, errLast := repos.PVZ.GetPVZs(ctx, 3, 2)
+	pastEnd, errPastEnd := repos.PVZ.GetPVZs(ctx, 4, 2)
+	all, errAll := repos.PVZ.GetAllPVZs(ctx)
+
+	// Assert
+	assert.NoError(t, errFirst)
+	assert.NoError(t, errLast)
+	assert.NoError(t, errPastEnd)
+	assert.NoError(t, errAll)
+	var pageIDs []string
+	for _, pvz := range append(firstPage, lastPage...) {
+		pageIDs = append(pageIDs, pvz.ID)
+	}
+	var allIDs []string
+	for _, pvz := range all {
+		allIDs = append(allIDs, pvz.ID)
+	}
+	assert.ElementsMatch(t, ids, allIDs)
+	assert.Len(t, firstPage, 2)
+	assert.Len(t, lastPage, 1)
+	assert.Empty(t, pastEnd)
+	assert.IsIncreasing(t, pageIDs)
+}
+
+func testPVZPageWithReceptionsAndProducts(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	pvzs := []model.PVZ{createContractPVZ(t, repos), createContractPVZ(t, repos), createContractPVZ(t, repos)}
+	slices.SortFunc(pvzs, func(a, b model.PVZ) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	inRange := createContractReception(t, repos, pvzs[0].ID, enum.StatusClosed, contractTime)
+	outOfRange := createContractReception(t, repos, pvzs[1].ID, enum.StatusClosed, contractTime.Add(48*time.Hour))
+	offPage := createContractReception(t, repos, pvzs[2].ID, enum.StatusClosed, contractTime)
+	product := createContractProduct(t, repos, inRange.ID, enum.ProductShoes, contractTime.Add(time.Minute))
+	createContractProduct(t, repos, outOfRange.ID, enum.ProductShoes, contractTime.Add(48*time.Hour+time.Minute))
+	createContractProduct(t, repos, offPage.ID, enum.ProductShoes, contractTime.Add(time.Minute))
+
+	// Act
+	pvzPage, err := repos.PVZ.GetPVZPage(ctx, 1, 2, contractTime, contractTime.Add(24*time.Hour), false)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, pvzPage.PVZs, 2)
+	assert.Equal(t, pvzs[0].ID, pvzPage.PVZs[0].ID)
+	assert.Equal(t, pvzs[1].ID, pvzPage.PVZs[1].ID)
+	require.Len(t, pvzPage.Receptions, 1)
+	assert.Equal(t, inRange.ID, pvzPage.Receptions[0].ID)
+	require.Len(t, pvzPage.Products, 1)
+	assert.Equal(t, product.ID, pvzPage.Products[0].ID)
+}
+
+func testPVZUpdate(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	pvz := createContractPVZ(t, repos)
+	latitude, longitude := 55.80, 37.70
+	update := pvz
+	update.City = enum.CityKazan.String()
+	update.Address = "ул. Новая, 2"
+	update.Latitude, update.Longitude = &latitude, &longitude
+	update.WorkingHours = "10:00-22:00"
+	update.Phone = "+79000000001"
+	update.Status = enum.PVZStatusSuspended.String()
+
+	// Act
+	errUpdate := repos.PVZ.UpdatePVZ(ctx, &update)
+	updated, errUpdated := repos.PVZ.GetPVZByID(ctx, pvz.ID)
+	errStatus := repos.PVZ.UpdatePVZStatus(ctx, pvz.ID, enum.PVZStatusClosed.String())
+	closed, errClosed := repos.PVZ.GetPVZByID(ctx, pvz.ID)
+
+	// Assert
+	assert.NoError(t, errUpdate)
+	assert.NoError(t, errUpdated)
+	assert.Equal(t, pvz.City, updated.City)
+	assert.Equal(t, update.Address, updated.Address)
+	assert.Equal(t, latitude, *updated.Latitude)
+	assert.Equal(t, longitude, *updated.Longitude)
+	assert.Equal(t, update.WorkingHours, updated.WorkingHours)
+	assert.Equal(t, update.Phone, updated.Phone)
+	assert.Equal(t, update.Status, updated.Status)
+	assert.NoError(t, errStatus)
+	assert.NoError(t, errClosed)
+	assert.Equal(t, enum.PVZStatusClosed.String(), closed.Status)
+}
+
+func testPVZNearbyOrderedByDistance(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	near := newContractPVZ(enum.CityMoscow.String(), 55.7560, 37.6175)
+	farther := newContractPVZ(enum.CityMoscow.String(), 55.7700, 37.6300)
+	outOfRadius := newContractPVZ(enum.CityMoscow.String(), 56.5000, 38.5000)
+	suspended := newContractPVZ(enum.CityMoscow.String(), 55.7559, 37.6174)
+	suspended.Status = enum.PVZStatusSuspended.String()
+	otherCity := newContractPVZ(enum.CityKazan.String(), 55.7561, 37.6176)
+	require.NoError(t, repos.PVZ.CreatePVZs(ctx, []model.PVZ{near, farther, outOfRadius, suspended, otherCity}))
+
+	// Act
+	nearby, err := repos.PVZ.GetNearbyPVZs(ctx, 55.7558, 37.6173, 5, enum.CityMoscow.String(), 10)
+	limited, errLimited := repos.PVZ.GetNearbyPVZs(ctx, 55.7558, 37.6173, 5, "", 1)
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, nearby, 2) {
+		assert.Equal(t, near.ID, nearby[0].PVZ.ID)
+		assert.Equal(t, farther.ID, nearby[1].PVZ.ID)
+		assert.InDelta(t, 0.0254, nearby[0].Distance, 0.001)
+	}
+	assert.NoError(t, errLimited)
+	if assert.Len(t, limited, 1) {
+		assert.Equal(t, near.ID, limited[0].PVZ.ID)
+	}
+}
+
+func testReceptionRequiresPVZ(t *testing.T, repos contractRepositories) {
+	// Arrange
+	reception := &model.Reception{ID: uuid.NewString(), DateTime: contractTime, PVZID: uuid.NewString(), Status: enum.StatusInProgress.String()}
+
+	// Act
+	err := repos.Reception.CreateReception(context.Background(), reception, contractActor)
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func testReceptionOneInProgressPerPVZ(t *testing.T, repos contractRepositories) {
+	// Arrange
+	pvz := createContractPVZ(t, repos)
+	createContractReception(t, repos, pvz.ID, enum.StatusInProgress, contractTime)
+	second := &model.Reception{ID: uuid.NewString(), DateTime: contractTime.Add(time.Hour), PVZID: pvz.ID, Status: enum.StatusInProgress.String()}
+
+	// Act
+	err := repos.Reception.CreateReception(context.Background(), second, contractActor)
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func testReceptionLastPrefersInProgress(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	pvz := createContractPVZ(t, repos)
+	empty := createContractPVZ(t, repos)
+	inProgress := createContractReception(t, repos, pvz.ID, enum.StatusInProgress, contractTime)
+	createContractReception(t, repos, pvz.ID, enum.StatusClosed, contractTime.Add(time.Hour))
+
+	// Act
+	last, err := repos.Reception.GetLastReceptionByPVZID(ctx, pvz.ID)
+	none, errNone := repos.Reception.GetLastReceptionByPVZID(ctx, empty.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, inProgress.ID, last.ID)
+	assert.Equal(t, enum.StatusInProgress.String(), last.Status)
+	assert.Nil(t, last.ClosedAt)
+	assert.NoError(t, errNone)
+	assert.Equal(t, &model.Reception{}, none)
+}
+
+func testReceptionClose(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	pvz := createContractPVZ(t, repos)
+	reception := createContractReception(t, repos, pvz.ID, enum.StatusInProgress, contractTime)
+	closedAt := contractTime.Add(2 * time.Hour)
+
+	// Act
+	err := repos.Reception.CloseReception(ctx, reception.ID, closedAt, contractActor)
+	closed, errClosed := repos.Reception.GetReceptionByID(ctx, reception.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, errClosed)
+	assert.Equal(t, enum.StatusClosed.String(), closed.Status)
+	if assert.NotNil(t, closed.ClosedAt) {
+		assert.WithinDuration(t, closedAt, *closed.ClosedAt, 0)
+	}
+	assert.WithinDuration(t, reception.DateTime, closed.DateTime, 0)
+}
+
+func testReceptionsByPVZIDsAndDateIncludeBounds(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	pvz := createContractPVZ(t, repos)
+	otherPVZ := createContractPVZ(t, repos)
+	atStart := createContractReception(t, repos, pvz.ID, enum.StatusClosed, contractTime)
+	atEnd := createContractReception(t, repos, pvz.ID, enum.StatusClosed, contractTime.Add(24*time.Hour))
+	createContractReception(t, repos, pvz.ID, enum.StatusClosed, contractTime.Add(25*time.Hour))
+	createContractReception(t, repos, otherPVZ.ID, enum.StatusClosed, contractTime.Add(time.Hour))
+
+	// Act
+	receptions, err := repos.Reception.GetReceptionsByPVZIDsAndDate(ctx, []string{pvz.ID}, contractTime, contractTime.Add(24*time.Hour))
+	none, errNone := repos.Reception.GetReceptionsByPVZIDsAndDate(ctx, []string{}, contractTime, contractTime.Add(24*time.Hour))
+
+	// Assert
+	assert.NoError(t, err)
+	var ids []string
+	for _, reception := range receptions {
+		ids = append(ids, reception.ID)
+	}
+	assert.ElementsMatch(t, []string{atStart.ID, atEnd.ID}, ids)
+	assert.NoError(t, errNone)
+	assert.Empty(t, none)
+}
+
+func testReceptionsOpenIncludeCity(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	pvz := createContractPVZ(t, repos)
+	open := createContractReception(t, repos, pvz.ID, enum.StatusInProgress, contractTime)
+	createContractReception(t, repos, pvz.ID, enum.StatusClosed, contractTime.Add(-time.Hour))
+
+	// Act
+	openReceptions, err := repos.Reception.GetOpenReceptions(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, openReceptions, 1) {
+		assert.Equal(t, open.ID, openReceptions[0].Reception.ID)
+		assert.Equal(t, pvz.City, openReceptions[0].City)
+		assert.WithinDuration(t, open.DateTime, openReceptions[0].OpenedAt, 0)
+		assert.Nil(t, openReceptions[0].EscalatedAt)
+	}
+}
+
+func testReceptionEventsInOrder(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	pvz := createContractPVZ(t, repos)
+	reception := createContractReception(t, repos, pvz.ID, enum.StatusInProgress, contractTime)
+	kept := createContractProduct(t, repos, reception.ID, enum.ProductShoes, contractTime.Add(time.Minute))
+	removed := createContractProduct(t, repos, reception.ID, enum.ProductElectronics, contractTime.Add(2*time.Minute))
+	deletedBy := uuid.NewString()
+	deletedAt := contractTime.Add(3 * time.Minute)
+	closedAt := contractTime.Add(time.Hour)
+	require.NoError(t, repos.Product.DeleteProduct(ctx, removed.ID, deletedBy, enum.DeletionReasonLastProduct.String(), deletedAt))
+	require.NoError(t, repos.Product.DeleteProduct(ctx, removed.ID, deletedBy, enum.DeletionReasonLastProduct.String(), closedAt))
+	require.NoError(t, repos.Reception.CloseReception(ctx, reception.ID, closedAt, ""))
+	otherPVZ := createContractPVZ(t, repos)
+	createContractReception(t, repos, otherPVZ.ID, enum.StatusInProgress, contractTime.Add(time.Second))
+
+	// Act
+	events, err := repos.Reception.GetReceptionEvents(ctx, reception.ID)
+	none, errNone := repos.Reception.GetReceptionEvents(ctx, uuid.NewString())
+
+	// Assert
+	assert.NoError(t, err)
+	require.Len(t, events, 5)
+	for i, event := range events {
+		assert.Equal(t, reception.ID, event.ReceptionID)
+		if i > 0 {
+			assert.Greater(t, event.ID, events[i-1].ID)
+		}
+	}
+	assert.Equal(t, model.ReceptionEvent{
+		ID: events[0].ID, ReceptionID: reception.ID, Type: enum.ReceptionEventOpened.String(),
+		Actor: contractActor, OccurredAt: events[0].OccurredAt,
+	}, events[0])
+	assert.WithinDuration(t, contractTime, events[0].OccurredAt, 0)
+	assert.Equal(t, enum.ReceptionEventProductAdded.String(), events[1].Type)
+	assert.Equal(t, kept.ID, events[1].ProductID)
+	assert.Equal(t, kept.Type, events[1].ProductType)
+	assert.Equal(t, contractActor, events[1].Actor)
+	assert.Equal(t, enum.ReceptionEventProductAdded.String(), events[2].Type)
+	assert.Equal(t, removed.ID, events[2].ProductID)
+	assert.Equal(t, enum.ReceptionEventProductDeleted.String(), events[3].Type)
+	assert.Equal(t, removed.ID, events[3].ProductID)
+	assert.Equal(t, removed.Type, events[3].ProductType)
+	assert.Equal(t, deletedBy, events[3].Actor)
+	assert.Equal(t, enum.DeletionReasonLastProduct.String(), events[3].Reason)
+	assert.WithinDuration(t, deletedAt, events[3].OccurredAt, 0)
+	assert.Equal(t, enum.ReceptionEventClosed.String(), events[4].Type)
+	assert.Empty(t, events[4].Actor)
+	assert.Empty(t, events[4].ProductID)
+	assert.WithinDuration(t, closedAt, events[4].OccurredAt, 0)
+	assert.NoError(t, errNone)
+	assert.Empty(t, none)
+}
+
+func testProductRequiresReception(t *testing.T, repos contractRepositories) {
+	// Arrange
+	product := &model.Product{ID: uuid.NewString(), DateTime: contractTime, Type: enum.ProductShoes.String(), ReceptionID: uuid.NewString()}
+
+	// Act
+	err := repos.Product.CreateProduct(context.Background(), product, contractActor)
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func testProductLastAndDelete(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	pvz := createContractPVZ(t, repos)
+	reception := createContractReception(t, repos, pvz.ID, enum.StatusInProgress, contractTime)
+	first := createContractProduct(t, repos, reception.ID, enum.ProductClothes, contractTime.Add(time.Minute))
+	second := createContractProduct(t, repos, reception.ID, enum.ProductShoes, contractTime.Add(2*time.Minute))
+	deletedBy := uuid.NewString()
+
+	// Act
+	last, errLast := repos.Product.GetLastProductByReceptionID(ctx, reception.ID)
+	errDelete := repos.Product.DeleteProduct(ctx, second.ID, deletedBy, enum.DeletionReasonLastProduct.String(), contractTime.Add(time.Hour))
+	lastAfterDelete, errAfterDelete := repos.Product.GetLastProductByReceptionID(ctx, reception.ID)
+	errDeleteFirst := repos.Product.DeleteProduct(ctx, first.ID, deletedBy, enum.DeletionReasonLastProduct.String(), contractTime.Add(time.Hour))
+	none, errNone := repos.Product.GetLastProductByReceptionID(ctx, reception.ID)
+
+	// Assert
+	assert.NoError(t, errLast)
+	assert.Equal(t, second.ID, last.ID)
+	assert.Equal(t, second.Type, last.Type)
+	assert.NoError(t, errDelete)
+	assert.NoError(t, errAfterDelete)
+	assert.Equal(t, first.ID, lastAfterDelete.ID)
+	assert.NoError(t, errDeleteFirst)
+	assert.NoError(t, errNone)
+	assert.Equal(t, &model.Product{}, none)
+}
+
+func testProductsByReceptionIDsAndCounts(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	pvz := createContractPVZ(t, repos)
+	reception := createContractReception(t, repos, pvz.ID, enum.StatusClosed, contractTime)
+	otherReception := createContractReception(t, repos, pvz.ID, enum.StatusInProgress, contractTime.Add(time.Hour))
+	electronics := createContractProduct(t, repos, reception.ID, enum.ProductElectronics, contractTime.Add(time.Minute))
+	shoes := createContractProduct(t, repos, reception.ID, enum.ProductShoes, contractTime.Add(2*time.Minute))
+	moreShoes := createContractProduct(t, repos, reception.ID, enum.ProductShoes, contractTime.Add(3*time.Minute))
+	createContractProduct(t, repos, otherReception.ID, enum.ProductClothes, contractTime.Add(time.Hour+time.Minute))
+
+	// Act
+	products, err := repos.Product.GetProductsByReceptionIDs(ctx, []string{reception.ID}, false)
+	counts, errCounts := repos.Product.CountProductsByType(ctx, reception.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	var ids []string
+	for _, product := range products {
+		ids = append(ids, product.ID)
+	}
+	assert.ElementsMatch(t, []string{electronics.ID, shoes.ID, moreShoes.ID}, ids)
+	assert.NoError(t, errCounts)
+	assert.Equal(t, map[string]int{enum.ProductElectronics.String(): 1, enum.ProductShoes.String(): 2}, counts)
+}
+
+func testProductWithinCapacityIsSerialized(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	pvz := createContractPVZ(t, repos)
+	reception := createContractReception(t, repos, pvz.ID, enum.StatusInProgress, contractTime)
+	capacityFull := errors.New("capacity full")
+	checkOneProduct := func(counts map[string]int) error {
+		if counts[enum.ProductShoes.String()] >= 1 {
+			return capacityFull
+		}
+		return nil
+	}
+	const attempts = 5
+
+	// Act
+	var wg sync.WaitGroup
+	errs := make([]error, attempts)
+	for i := range attempts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			product := model.Product{ID: uuid.NewString(), DateTime: contractTime.Add(time.Minute), Type: enum.ProductShoes.String(), ReceptionID: reception.ID}
+			errs[i] = repos.Product.CreateProductWithinCapacity(ctx, &product, contractActor, checkOneProduct)
+		}()
+	}
+	wg.Wait()
+	counts, errCounts := repos.Product.CountProductsByType(ctx, reception.ID)
+
+	// Assert
+	created := 0
+	for _, err := range errs {
+		if err == nil {
+			created++
+		} else {
+			assert.ErrorIs(t, err, capacityFull)
+		}
+	}
+	assert.Equal(t, 1, created)
+	assert.NoError(t, errCounts)
+	assert.Equal(t, map[string]int{enum.ProductShoes.String(): 1}, counts)
+}
+
+func testProductWithinCapacityNeedsOpenReception(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	pvz := createContractPVZ(t, repos)
+	reception := createContractReception(t, repos, pvz.ID, enum.StatusClosed, contractTime)
+	product := model.Product{ID: uuid.NewString(), DateTime: contractTime.Add(time.Minute), Type: enum.ProductShoes.String(), ReceptionID: reception.ID}
+
+	// Act
+	err := repos.Product.CreateProductWithinCapacity(ctx, &product, contractActor, func(counts map[string]int) error {
+		return nil
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, enum.ErrNoOpenReceptionsToAdd)
+}
+
+func testProductDeleteWithReason(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	pvz := createContractPVZ(t, repos)
+	reception := createContractReception(t, repos, pvz.ID, enum.StatusInProgress, contractTime)
+	product := createContractProduct(t, repos, reception.ID, enum.ProductElectronics, contractTime.Add(time.Minute))
+	deletion := &model.ProductDeletion{
+		ID:              uuid.NewString(),
+		ProductID:       product.ID,
+		ReceptionID:     reception.ID,
+		ProductType:     product.Type,
+		ProductDateTime: product.DateTime,
+		Reason:          enum.DeletionReasonDamaged.String(),
+		DeletedBy:       uuid.NewString(),
+		DeletedAt:       contractTime.Add(time.Hour),
+	}
+
+	// Act
+	err := repos.Product.DeleteProductWithReason(ctx, deletion)
+	deleted, errDeleted := repos.Product.GetProductByID(ctx, product.ID)
+	history, errHistory := repos.Product.GetProductsByReceptionIDs(ctx, []string{reception.ID}, true)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, errDeleted)
+	assert.Equal(t, &model.Product{}, deleted)
+	assert.NoError(t, errHistory)
+	require.Len(t, history, 1)
+	assert.Equal(t, deletion.DeletedBy, history[0].DeletedBy)
+	assert.Equal(t, deletion.Reason, history[0].DeletionReason)
+	require.NotNil(t, history[0].DeletedAt)
+	assert.True(t, deletion.DeletedAt.Equal(*history[0].DeletedAt))
+}
+
+func testProductDeleteWithReasonTwice(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	pvz := createContractPVZ(t, repos)
+	reception := createContractReception(t, repos, pvz.ID, enum.StatusInProgress, contractTime)
+	product := createContractProduct(t, repos, reception.ID, enum.ProductClothes, contractTime.Add(time.Minute))
+	newDeletion := func(reason enum.DeletionReason, deletedAt time.Time) *model.ProductDeletion {
+		return &model.ProductDeletion{
+			ID:              uuid.NewString(),
+			ProductID:       product.ID,
+			ReceptionID:     reception.ID,
+			ProductType:     product.Type,
+			ProductDateTime: product.DateTime,
+			Reason:          reason.String(),
+			DeletedBy:       uuid.NewString(),
+			DeletedAt:       deletedAt,
+		}
+	}
+	first := newDeletion(enum.DeletionReasonDamaged, contractTime.Add(time.Hour))
+	require.NoError(t, repos.Product.DeleteProductWithReason(ctx, first))
+
+	// Act
+	err := repos.Product.DeleteProductWithReason(ctx, newDeletion(enum.DeletionReasonDuplicate, contractTime.Add(2*time.Hour)))
+	history, errHistory := repos.Product.GetProductsByReceptionIDs(ctx, []string{reception.ID}, true)
+	events, errEvents := repos.Reception.GetReceptionEvents(ctx, reception.ID)
+
+	// Assert
+	assert.ErrorIs(t, err, enum.ErrProductNotFound)
+	assert.NoError(t, errHistory)
+	require.Len(t, history, 1)
+	assert.Equal(t, first.DeletedBy, history[0].DeletedBy)
+	assert.Equal(t, first.Reason, history[0].DeletionReason)
+	assert.NoError(t, errEvents)
+	deletions := slices.DeleteFunc(events, func(event model.ReceptionEvent) bool {
+		return event.Type != enum.ReceptionEventProductDeleted.String()
+	})
+	assert.Len(t, deletions, 1)
+}
+
+func testProductSoftDeleteKeepsHistory(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	pvz := createContractPVZ(t, repos)
+	reception := createContractReception(t, repos, pvz.ID, enum.StatusInProgress, contractTime)
+	kept := createContractProduct(t, repos, reception.ID, enum.ProductShoes, contractTime.Add(time.Minute))
+	deleted := createContractProduct(t, repos, reception.ID, enum.ProductShoes, contractTime.Add(2*time.Minute))
+	deletedBy := uuid.NewString()
+	deletedAt := contractTime.Add(time.Hour)
+	require.NoError(t, repos.Product.DeleteProduct(ctx, deleted.ID, deletedBy, enum.DeletionReasonLastProduct.String(), deletedAt))
+
+	// Act
+	errDeleteAgain := repos.Product.DeleteProduct(ctx, deleted.ID, uuid.NewString(), enum.DeletionReasonOther.String(), deletedAt.Add(time.Hour))
+	active, errActive := repos.Product.GetProductsByReceptionIDs(ctx, []string{reception.ID}, false)
+	history, errHistory := repos.Product.GetProductsByReceptionIDs(ctx, []string{reception.ID}, true)
+	counts, errCounts := repos.Product.CountProductsByType(ctx, reception.ID)
+	byID, errByID := repos.Product.GetProductByID(ctx, deleted.ID)
+	activePage, errActivePage := repos.PVZ.GetPVZPage(ctx, 1, 10, contractTime, contractTime.Add(24*time.Hour), false)
+	historyPage, errHistoryPage := repos.PVZ.GetPVZPage(ctx, 1, 10, contractTime, contractTime.Add(24*time.Hour), true)
+
+	// Assert
+	assert.NoError(t, errDeleteAgain)
+	assert.NoError(t, errActive)
+	require.Len(t, active, 1)
+	assert.Equal(t, kept.ID, active[0].ID)
+	assert.Nil(t, active[0].DeletedAt)
+	assert.NoError(t, errHistory)
+	require.Len(t, history, 2)
+	deletedIndex := slices.IndexFunc(history, func(product model.Product) bool { return product.ID == deleted.ID })
+	require.NotEqual(t, -1, deletedIndex)
+	require.NotNil(t, history[deletedIndex].DeletedAt)
+	assert.True(t, deletedAt.Equal(*history[deletedIndex].DeletedAt))
+	assert.Equal(t, deletedBy, history[deletedIndex].DeletedBy)
+	assert.Equal(t, enum.DeletionReasonLastProduct.String(), history[deletedIndex].DeletionReason)
+	assert.NoError(t, errCounts)
+	assert.Equal(t, map[string]int{enum.ProductShoes.String(): 1}, counts)
+	assert.NoError(t, errByID)
+	assert.Equal(t, &model.Product{}, byID)
+	assert.NoError(t, errActivePage)
+	assert.Len(t, activePage.Products, 1)
+	assert.NoError(t, errHistoryPage)
+	assert.Len(t, historyPage.Products, 2)
+}
+
+func testCapacityRulesDefaultAndOverwrite(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+	pvz := createContractPVZ(t, repos)
+	maxProducts := 20
+	rules := &model.PVZCapacityRules{
+		PVZID:         pvz.ID,
+		MaxProducts:   &maxProducts,
+		AcceptedTypes: []string{enum.ProductShoes.String()},
+		TypeQuotas:    map[string]int{enum.ProductShoes.String(): 5},
+	}
+
+	// Act
+	defaults, errDefaults := repos.CapacityRule.GetCapacityRules(ctx, pvz.ID)
+	errSave := repos.CapacityRule.SaveCapacityRules(ctx, rules)
+	errOverwrite := repos.CapacityRule.SaveCapacityRules(ctx, &model.PVZCapacityRules{PVZID: pvz.ID, TypeQuotas: map[string]int{}})
+	overwritten, errOverwritten := repos.CapacityRule.GetCapacityRules(ctx, pvz.ID)
+
+	// Assert
+	assert.NoError(t, errDefaults)
+	assert.Equal(t, &model.PVZCapacityRules{PVZID: pvz.ID}, defaults)
+	assert.NoError(t, errSave)
+	assert.NoError(t, errOverwrite)
+	assert.NoError(t, errOverwritten)
+	assert.Nil(t, overwritten.MaxProducts)
+	assert.Nil(t, overwritten.AcceptedTypes)
+	assert.Empty(t, overwritten.TypeQuotas)
+}
+
+func testReceptionAndProductLookupsOfUnknownEntity(t *testing.T, repos contractRepositories) {
+	// Arrange
+	ctx := context.Background()
+
+	// Act
+	reception, errReception := repos.Reception.GetReceptionByID(ctx, uuid.NewString())
+	product, errProduct := repos.Product.GetProductByID(ctx, uuid.NewString())
+	errClose := repos.Reception.CloseReception(ctx, uuid.NewString(), contractTime, contractActor)
+	errDelete := repos.Product.DeleteProduct(ctx, uuid.NewString(), uuid.NewString(), enum.DeletionReasonLastProduct.String(), contractTime)
+
+	// Assert
+	assert.NoError(t, errReception)
+	assert.Equal(t, &model.Reception{}, reception)
+	assert.NoError(t, errProduct)
+	assert.Equal(t, &model.Product{}, product)
+	assert.NoError(t, errClose)
+	assert.NoError(t, errDelete)
+}
@@ -1,35 +1,37 @@
 package repository
 
 import (
-	"database/sql"
+	"context"
 	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/ners1us/order-service/internal/database"
 	"github.com/ners1us/order-service/internal/model"
 )
 
 type UserRepository interface {
-	CreateUser(user *model.User) error
-	GetUserByEmail(email string) (*model.User, error)
+	CreateUser(ctx context.Context, user *model.User) error
+	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
 }
 
 type userRepositoryImpl struct {
-	db *sql.DB
+	db database.DB
 }
 
-func NewUserRepository(db *sql.DB) UserRepository {
+func NewUserRepository(db database.DB) UserRepository {
 	return &userRepositoryImpl{db}
 }
 
-func (ur *userRepositoryImpl) CreateUser(user *model.User) error {
+func (ur *userRepositoryImpl) CreateUser(ctx context.Context, user *model.User) error {
 	query := "INSERT INTO users (id, email, password, role) VALUES ($1, $2, $3, $4)"
-	_, err := ur.db.Exec(query, user.ID, user.Email, user.Password, user.Role)
+	_, err := ur.db.Exec(ctx, query, user.ID, user.Email, user.Password, user.Role)
 	return err
 }
 
-func (ur *userRepositoryImpl) GetUserByEmail(email string) (*model.User, error) {
+func (ur *userRepositoryImpl) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
 	var user model.User
 	query := "SELECT id, email, password, role FROM users WHERE email = $1"
-	err := ur.db.QueryRow(query, email).Scan(&user.ID, &user.Email, &user.Password, &user.Role)
-	if errors.Is(err, sql.ErrNoRows) {
+	err := ur.db.QueryRow(ctx, query, email).Scan(&user.ID, &user.Email, &user.Password, &user.Role)
+	if errors.Is(err, pgx.ErrNoRows) {
 		return &model.User{}, nil
 	}
 	return &user, err
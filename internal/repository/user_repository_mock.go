@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"github.com/ners1us/order-service/internal/model"
 	"github.com/stretchr/testify/mock"
 )
@@ -9,12 +10,12 @@ type MockUserRepository struct {
 	mock.Mock
 }
 
-func (mur *MockUserRepository) CreateUser(user *model.User) error {
+func (mur *MockUserRepository) CreateUser(ctx context.Context, user *model.User) error {
 	args := mur.Called(user)
 	return args.Error(0)
 }
 
-func (mur *MockUserRepository) GetUserByEmail(email string) (*model.User, error) {
+func (mur *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
 	args := mur.Called(email)
 	return args.Get(0).(*model.User), args.Error(1)
 }
@@ -0,0 +1,8 @@
+package scheduler
+
+import "context"
+
+type Scheduler interface {
+	Start()
+	Stop(ctx context.Context)
+}
@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ners1us/order-service/internal/database"
+	"github.com/ners1us/order-service/internal/service"
+	"log/slog"
+	"time"
+)
+
+const staleReceptionLockKey int64 = 0x5354414c45
+
+type staleReceptionScheduler struct {
+	db                    *pgxpool.Pool
+	staleReceptionService service.StaleReceptionService
+	interval              time.Duration
+	log                   *slog.Logger
+	cancel                context.CancelFunc
+	doneCh                chan struct{}
+}
+
+func NewStaleReceptionScheduler(
+	db *pgxpool.Pool,
+	staleReceptionService service.StaleReceptionService,
+	interval time.Duration,
+	log *slog.Logger,
+) Scheduler {
+	return &staleReceptionScheduler{
+		db:                    db,
+		staleReceptionService: staleReceptionService,
+		interval:              interval,
+		log:                   log,
+		doneCh:                make(chan struct{}),
+	}
+}
+
+func (srs *staleReceptionScheduler) Start() {
+	srs.log.Info("starting stale reception scheduler", slog.Duration("interval", srs.interval))
+	ctx, cancel := context.WithCancel(context.Background())
+	srs.cancel = cancel
+
+	go func() {
+		defer close(srs.doneCh)
+		ticker := time.NewTicker(srs.interval)
+		defer ticker.Stop()
+
+		for {
+			srs.run(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (srs *staleReceptionScheduler) Stop(ctx context.Context) {
+	if srs.cancel == nil {
+		return
+	}
+	srs.cancel()
+
+	select {
+	case <-srs.doneCh:
+		srs.log.Info("stale reception scheduler stopped gracefully")
+	case <-ctx.Done():
+		srs.log.Warn("stale reception scheduler forced to stop")
+	}
+}
+
+func (srs *staleReceptionScheduler) run(ctx context.Context) {
+	var escalated int
+	acquired, err := database.WithAdvisoryLock(ctx, srs.db, staleReceptionLockKey, func() error {
+		var err error
+		escalated, err = srs.staleReceptionService.ProcessStaleReceptions(ctx)
+		return err
+	})
+	if err != nil {
+		if ctx.Err() == nil {
+			srs.log.Error("stale reception check failed", slog.Any("error", err))
+		}
+		return
+	}
+	if acquired && escalated > 0 {
+		srs.log.Info("stale reception check escalated receptions", slog.Int("escalated", escalated))
+	}
+}
@@ -0,0 +1,292 @@
+// Package seed fills a database with demo accounts and generated PVZs,
+// receptions and products for local development and load testing.
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/service"
+	"iter"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	ModeratorEmail = "moderator@example.com"
+	EmployeeEmail  = "employee@example.com"
+
+	minReceptionDuration = 30 * time.Minute
+	maxReceptionDuration = 8 * time.Hour
+	coordinateJitter     = 0.15
+)
+
+type coordinates struct {
+	latitude, longitude float64
+}
+
+var cityCenters = map[enum.City]coordinates{
+	enum.CityMoscow:          {55.7558, 37.6173},
+	enum.CitySaintPetersburg: {59.9343, 30.3351},
+	enum.CityKazan:           {55.7963, 49.1088},
+}
+
+var cities = []enum.City{enum.CityMoscow, enum.CitySaintPetersburg, enum.CityKazan}
+
+type Options struct {
+	Password         string
+	PVZsPerCity      int
+	ReceptionsPerPVZ int
+	MinProducts      int
+	MaxProducts      int
+	From             time.Time
+	To               time.Time
+	// RandomSeed makes the generated data reproducible.
+	RandomSeed uint64
+}
+
+func (o Options) validate() error {
+	if o.PVZsPerCity < 0 || o.ReceptionsPerPVZ < 0 {
+		return errors.New("numbers of pvzs and receptions must not be negative")
+	}
+	if o.MinProducts < 0 || o.MaxProducts < o.MinProducts {
+		return errors.New("products per reception must satisfy 0 <= min <= max")
+	}
+	if !o.From.Before(o.To) {
+		return enum.ErrInvalidDateRange
+	}
+	return nil
+}
+
+type Report struct {
+	Users      int    `json:"users"`
+	PVZs       int    `json:"pvzs"`
+	Receptions int    `json:"receptions"`
+	Products   int    `json:"products"`
+	Duration   string `json:"duration"`
+}
+
+// Run creates a moderator and an employee with opts.Password, skipping accounts
+// that already exist, and then generates opts.PVZsPerCity PVZs in every city
+// with closed receptions spread over [opts.From, opts.To) and products of every
+// type. The generated rows are written with COPY in one transaction, bypassing
+// the services, so publisher is only told that the PVZ list changed.
+func Run(
+	ctx context.Context,
+	db *pgxpool.Pool,
+	userService service.UserService,
+	publisher event.Publisher,
+	opts Options,
+) (*Report, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	startedAt := time.Now()
+	report := &Report{}
+
+	for email, role := range map[string]enum.Role{ModeratorEmail: enum.RoleModerator, EmployeeEmail: enum.RoleEmployee} {
+		_, err := userService.CreateUser(ctx, email, opts.Password, role.String())
+		if errors.Is(err, enum.ErrUserAlreadyExists) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", email, err)
+		}
+		report.Users++
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	g := &generator{opts: opts, random: rand.New(rand.NewPCG(opts.RandomSeed, opts.RandomSeed))}
+	pvzIDs, err := g.copyPVZs(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy pvzs: %w", err)
+	}
+	receptions, err := g.copyReceptions(ctx, tx, pvzIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy receptions: %w", err)
+	}
+	products, err := g.copyProducts(ctx, tx, receptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy products: %w", err)
+	}
+	if err := recordReceptionEvents(ctx, tx, receptions); err != nil {
+		return nil, fmt.Errorf("failed to record reception events: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	report.PVZs = len(pvzIDs)
+	report.Receptions = len(receptions)
+	report.Products = products
+	if report.PVZs > 0 {
+		pvzsChanged := model.Event{Type: enum.EventPVZsChanged.String(), OccurredAt: time.Now(), Payload: report.PVZs}
+		if err := publisher.Publish(&pvzsChanged); err != nil {
+			return nil, fmt.Errorf("failed to publish %s: %w", pvzsChanged.Type, err)
+		}
+	}
+	report.Duration = time.Since(startedAt).Round(time.Millisecond).String()
+	return report, nil
+}
+
+type generator struct {
+	opts   Options
+	random *rand.Rand
+}
+
+type generatedReception struct {
+	id                 string
+	openedAt, closedAt time.Time
+}
+
+func (g *generator) copyPVZs(ctx context.Context, tx pgx.Tx) ([]string, error) {
+	var ids []string
+	err := copyRows(ctx, tx, "pvzs", []string{"id", "registration_date", "city", "address", "latitude", "longitude", "working_hours", "phone", "status"},
+		func(write func(values ...any) error) error {
+			for _, city := range cities {
+				center := cityCenters[city]
+				for i := 1; i <= g.opts.PVZsPerCity; i++ {
+					id := uuid.NewString()
+					err := write(
+						id,
+						g.opts.From,
+						city.String(),
+						fmt.Sprintf("ул. Тестовая, %d", i),
+						center.latitude+g.jitter(),
+						center.longitude+g.jitter(),
+						"09:00-21:00",
+						fmt.Sprintf("+7900%07d", g.random.IntN(10_000_000)),
+						enum.PVZStatusActive.String(),
+					)
+					if err != nil {
+						return err
+					}
+					ids = append(ids, id)
+				}
+			}
+			return nil
+		})
+	return ids, err
+}
+
+// copyReceptions spreads the receptions of every PVZ evenly over the date
+// range, so that they do not overlap.
+func (g *generator) copyReceptions(ctx context.Context, tx pgx.Tx, pvzIDs []string) ([]generatedReception, error) {
+	var receptions []generatedReception
+	if g.opts.ReceptionsPerPVZ == 0 {
+		return receptions, nil
+	}
+	slot := g.opts.To.Sub(g.opts.From) / time.Duration(g.opts.ReceptionsPerPVZ)
+	maxDuration := min(maxReceptionDuration, slot)
+	minDuration := min(minReceptionDuration, maxDuration)
+
+	err := copyRows(ctx, tx, "receptions", []string{"id", "date_time", "pvz_id", "status", "closed_at"},
+		func(write func(values ...any) error) error {
+			for _, pvzID := range pvzIDs {
+				for i := 0; i < g.opts.ReceptionsPerPVZ; i++ {
+					duration := minDuration + g.duration(maxDuration-minDuration)
+					openedAt := g.opts.From.Add(time.Duration(i)*slot + g.duration(slot-duration))
+					reception := generatedReception{id: uuid.NewString(), openedAt: openedAt, closedAt: openedAt.Add(duration)}
+					if err := write(reception.id, reception.openedAt, pvzID, enum.StatusClosed.String(), reception.closedAt); err != nil {
+						return err
+					}
+					receptions = append(receptions, reception)
+				}
+			}
+			return nil
+		})
+	return receptions, err
+}
+
+func (g *generator) copyProducts(ctx context.Context, tx pgx.Tx, receptions []generatedReception) (int, error) {
+	count := 0
+	err := copyRows(ctx, tx, "products", []string{"id", "date_time", "type", "reception_id"},
+		func(write func(values ...any) error) error {
+			for _, reception := range receptions {
+				products := g.opts.MinProducts + g.random.IntN(g.opts.MaxProducts-g.opts.MinProducts+1)
+				for i := 0; i < products; i++ {
+					addedAt := reception.openedAt.Add(g.duration(reception.closedAt.Sub(reception.openedAt)))
+					productType := enum.ProductTypes[g.random.IntN(len(enum.ProductTypes))]
+					if err := write(uuid.NewString(), addedAt, productType.String(), reception.id); err != nil {
+						return err
+					}
+					count++
+				}
+			}
+			return nil
+		})
+	return count, err
+}
+
+// recordReceptionEvents fills the event log of the copied receptions from
+// their rows. The actors are left unknown.
+func recordReceptionEvents(ctx context.Context, tx pgx.Tx, receptions []generatedReception) error {
+	ids := make([]string, 0, len(receptions))
+	for _, reception := range receptions {
+		ids = append(ids, reception.id)
+	}
+	query := `INSERT INTO reception_events (reception_id, type, occurred_at, product_id, product_type)
+		SELECT reception_id, type, occurred_at, product_id, product_type
+		FROM (SELECT r.id AS reception_id, $1::text AS type, r.date_time AS occurred_at,
+				NULL::uuid AS product_id, NULL::text AS product_type, 0 AS step
+			FROM receptions r WHERE r.id = ANY($4::text[]::uuid[])
+			UNION ALL
+			SELECT p.reception_id, $2::text, p.date_time, p.id, p.type, 1
+			FROM products p WHERE p.reception_id = ANY($4::text[]::uuid[])
+			UNION ALL
+			SELECT r.id, $3::text, r.closed_at, NULL, NULL, 2
+			FROM receptions r WHERE r.id = ANY($4::text[]::uuid[]) AND r.closed_at IS NOT NULL) AS history
+		ORDER BY occurred_at, step`
+	_, err := tx.Exec(ctx, query, enum.ReceptionEventOpened.String(), enum.ReceptionEventProductAdded.String(),
+		enum.ReceptionEventClosed.String(), ids)
+	return err
+}
+
+func (g *generator) jitter() float64 {
+	return (g.random.Float64()*2 - 1) * coordinateJitter
+}
+
+// duration returns a random duration in [0, limit).
+func (g *generator) duration(limit time.Duration) time.Duration {
+	if limit <= 0 {
+		return 0
+	}
+	return time.Duration(g.random.Int64N(int64(limit)))
+}
+
+var errCopyStopped = errors.New("copy stopped")
+
+// copyRows streams the rows produced by fill into table with COPY FROM STDIN.
+// fill runs as an iterator pulled by the copy, so rows are never buffered.
+func copyRows(ctx context.Context, tx pgx.Tx, table string, columns []string, fill func(write func(values ...any) error) error) error {
+	var fillErr error
+	next, stop := iter.Pull(func(yield func([]any) bool) {
+		fillErr = fill(func(values ...any) error {
+			if !yield(values) {
+				return errCopyStopped
+			}
+			return nil
+		})
+	})
+	defer stop()
+
+	_, err := tx.CopyFrom(ctx, pgx.Identifier{table}, columns, pgx.CopyFromFunc(func() ([]any, error) {
+		row, ok := next()
+		if !ok {
+			return nil, fillErr
+		}
+		return row, nil
+	}))
+	return err
+}
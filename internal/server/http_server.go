@@ -4,73 +4,143 @@ import (
 	"context"
 	"github.com/gin-gonic/gin"
 	"github.com/ners1us/order-service/internal/api/rest"
+	"github.com/ners1us/order-service/internal/breaker"
+	"github.com/ners1us/order-service/internal/health"
 	"github.com/ners1us/order-service/internal/middleware"
 	"github.com/ners1us/order-service/internal/service"
 	ginprometheus "github.com/zsais/go-gin-prometheus"
-	"log"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"log/slog"
 	"net/http"
 	"time"
 )
 
+const tracingServiceName = "order-service"
+
+// HTTPTimeouts are passed to http.Server; zero disables a timeout.
+type HTTPTimeouts struct {
+	Read  time.Duration
+	Write time.Duration
+	Idle  time.Duration
+}
+
 type httpServer struct {
 	server           *http.Server
 	engine           *gin.Engine
+	log              *slog.Logger
+	health           *health.Checker
+	breaker          *breaker.Breaker
 	userHandler      rest.UserHandler
 	pvzHandler       rest.PVZHandler
 	receptionHandler rest.ReceptionHandler
 	productHandler   rest.ProductHandler
+	reopenHandler    rest.ReopenRequestHandler
+	capacityHandler  rest.CapacityHandler
+	reportHandler    rest.ReportHandler
+	exportHandler    rest.ExportHandler
+	pvzImportHandler rest.PVZImportHandler
 	jwtService       service.JWTService
 }
 
 func NewHTTPServer(
 	port string,
+	timeouts HTTPTimeouts,
+	log *slog.Logger,
+	healthChecker *health.Checker,
+	dbBreaker *breaker.Breaker,
 	userHandler rest.UserHandler,
 	pvzHandler rest.PVZHandler,
 	receptionHandler rest.ReceptionHandler,
 	productHandler rest.ProductHandler,
+	reopenHandler rest.ReopenRequestHandler,
+	capacityHandler rest.CapacityHandler,
+	reportHandler rest.ReportHandler,
+	exportHandler rest.ExportHandler,
+	pvzImportHandler rest.PVZImportHandler,
 	jwtService service.JWTService,
 ) BackendServer {
-	r := gin.Default()
+	r := gin.New()
+	r.Use(
+		otelgin.Middleware(tracingServiceName),
+		middleware.RequestIDMiddleware(),
+		middleware.LoggingMiddleware(log),
+		gin.Recovery(),
+	)
 
 	p := ginprometheus.NewPrometheus("gin")
 	p.Use(r)
 
 	srv := &http.Server{
-		Addr:    ":" + port,
-		Handler: r,
+		Addr:         ":" + port,
+		Handler:      r,
+		ReadTimeout:  timeouts.Read,
+		WriteTimeout: timeouts.Write,
+		IdleTimeout:  timeouts.Idle,
 	}
 
 	return &httpServer{
 		server:           srv,
 		engine:           r,
+		log:              log,
+		health:           healthChecker,
+		breaker:          dbBreaker,
 		userHandler:      userHandler,
 		pvzHandler:       pvzHandler,
 		receptionHandler: receptionHandler,
 		productHandler:   productHandler,
+		reopenHandler:    reopenHandler,
+		capacityHandler:  capacityHandler,
+		reportHandler:    reportHandler,
+		exportHandler:    exportHandler,
+		pvzImportHandler: pvzImportHandler,
 		jwtService:       jwtService,
 	}
 }
 
 func (hs *httpServer) ConfigureRoutes() {
+	hs.engine.GET("/healthz", gin.WrapH(hs.health.LivenessHandler()))
+	hs.engine.GET("/readyz", gin.WrapH(hs.health.ReadinessHandler()))
 	hs.engine.POST("/dummyLogin", hs.userHandler.DummyLogin)
-	hs.engine.POST("/register", hs.userHandler.Register)
-	hs.engine.POST("/login", hs.userHandler.Login)
 
-	secured := hs.engine.Group("/", middleware.AuthMiddleware(hs.jwtService))
+	// Health checks and dummyLogin do not need the database, so the breaker
+	// guards only the routes below.
+	guarded := hs.engine.Group("/", middleware.CircuitBreakerMiddleware(hs.breaker))
+	guarded.POST("/register", hs.userHandler.Register)
+	guarded.POST("/login", hs.userHandler.Login)
+
+	secured := guarded.Group("/", middleware.AuthMiddleware(hs.jwtService))
 	secured.POST("/pvz", hs.pvzHandler.CreatePVZ)
 	secured.GET("/pvz", hs.pvzHandler.GetPVZList)
+	secured.POST("/pvz/import", hs.pvzImportHandler.ImportPVZs)
+	secured.GET("/pvz/nearby", hs.pvzHandler.GetNearbyPVZs)
+	secured.GET("/pvz/:pvzId", hs.pvzHandler.GetPVZ)
+	secured.PATCH("/pvz/:pvzId", hs.pvzHandler.UpdatePVZ)
+	secured.DELETE("/pvz/:pvzId", hs.pvzHandler.DecommissionPVZ)
+	secured.GET("/pvz/:pvzId/capacity_rules", hs.capacityHandler.GetCapacityRules)
+	secured.PUT("/pvz/:pvzId/capacity_rules", hs.capacityHandler.UpdateCapacityRules)
+	secured.GET("/pvz/:pvzId/capacity", hs.capacityHandler.GetReceptionCapacity)
 	secured.POST("/pvz/:pvzId/close_last_reception", hs.receptionHandler.CloseLastReception)
 	secured.POST("/pvz/:pvzId/delete_last_product", hs.productHandler.DeleteLastProduct)
 	secured.POST("/receptions", hs.receptionHandler.CreateReception)
 	secured.POST("/products", hs.productHandler.AddProduct)
+	secured.DELETE("/products/:productId", hs.productHandler.DeleteProduct)
+	secured.GET("/receptions/:receptionId/timeline", hs.receptionHandler.GetReceptionTimeline)
+	secured.POST("/receptions/:receptionId/reopen_requests", hs.reopenHandler.RequestReopen)
+	secured.GET("/receptions/:receptionId/reopen_requests", hs.reopenHandler.GetReceptionReopenHistory)
+	secured.GET("/reopen_requests", hs.reopenHandler.GetReopenRequests)
+	secured.POST("/reopen_requests/:requestId/approve", hs.reopenHandler.ApproveReopen)
+	secured.POST("/reopen_requests/:requestId/reject", hs.reopenHandler.RejectReopen)
+	secured.GET("/reports", hs.reportHandler.GetReport)
+	secured.GET("/export/receptions", hs.exportHandler.ExportReceptions)
 }
 
 func (hs *httpServer) Start() error {
-	log.Println("starting HTTP server...")
+	hs.log.Info("starting HTTP server", slog.String("addr", hs.server.Addr))
 	return hs.server.ListenAndServe()
 }
 
 func (hs *httpServer) Stop(ctx context.Context) {
+	hs.health.Drain()
 	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
@@ -84,11 +154,11 @@ func (hs *httpServer) Stop(ctx context.Context) {
 	select {
 	case err := <-errCh:
 		if err != nil {
-			log.Printf("HTTP server shutdown error: %v", err)
+			hs.log.Error("HTTP server shutdown failed", slog.Any("error", err))
 		} else {
-			log.Println("HTTP server stopped gracefully")
+			hs.log.Info("HTTP server stopped gracefully")
 		}
 	case <-shutdownCtx.Done():
-		log.Println("HTTP server forced to stop")
+		hs.log.Warn("HTTP server forced to stop")
 	}
 }
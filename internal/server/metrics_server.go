@@ -2,8 +2,9 @@ package server
 
 import (
 	"context"
+	"github.com/ners1us/order-service/internal/health"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
 )
@@ -11,9 +12,11 @@ import (
 type metricsServer struct {
 	server *http.Server
 	mux    *http.ServeMux
+	log    *slog.Logger
+	health *health.Checker
 }
 
-func NewMetricsServer(port string) BackendServer {
+func NewMetricsServer(port string, log *slog.Logger, healthChecker *health.Checker) BackendServer {
 	mux := http.NewServeMux()
 
 	srv := &http.Server{
@@ -24,19 +27,24 @@ func NewMetricsServer(port string) BackendServer {
 	return &metricsServer{
 		server: srv,
 		mux:    mux,
+		log:    log,
+		health: healthChecker,
 	}
 }
 
 func (ms *metricsServer) ConfigureRoutes() {
 	ms.mux.Handle("/metrics", promhttp.Handler())
+	ms.mux.Handle("/healthz", ms.health.LivenessHandler())
+	ms.mux.Handle("/readyz", ms.health.ReadinessHandler())
 }
 
 func (ms *metricsServer) Start() error {
-	log.Println("starting metrics server...")
+	ms.log.Info("starting metrics server", slog.String("addr", ms.server.Addr))
 	return ms.server.ListenAndServe()
 }
 
 func (ms *metricsServer) Stop(ctx context.Context) {
+	ms.health.Drain()
 	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
@@ -50,11 +58,11 @@ func (ms *metricsServer) Stop(ctx context.Context) {
 	select {
 	case err := <-errCh:
 		if err != nil {
-			log.Printf("metrics server shutdown error: %v", err)
+			ms.log.Error("metrics server shutdown failed", slog.Any("error", err))
 		} else {
-			log.Println("metrics server stopped gracefully")
+			ms.log.Info("metrics server stopped gracefully")
 		}
 	case <-shutdownCtx.Done():
-		log.Println("metrics server forced to stop")
+		ms.log.Warn("metrics server forced to stop")
 	}
 }
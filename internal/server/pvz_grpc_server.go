@@ -2,40 +2,82 @@ package server
 
 import (
 	"context"
+	"errors"
+	"github.com/ners1us/order-service/internal/breaker"
+	"github.com/ners1us/order-service/internal/database"
+	"github.com/ners1us/order-service/internal/health"
 	"github.com/ners1us/order-service/internal/logger"
 	"github.com/ners1us/order-service/internal/service"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
-	"log"
+	"google.golang.org/grpc/status"
+	"log/slog"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/ners1us/order-service/internal/repository"
 	"github.com/ners1us/order-service/pkg/generated/proto"
 )
 
+const (
+	healthCheckInterval = 10 * time.Second
+	healthCheckTimeout  = 2 * time.Second
+)
+
 type pvzGrpcServer struct {
-	server         *grpc.Server
-	pvzGrpcService *service.PVZGrpcService
-	listener       net.Listener
-	pvzRepo        repository.PVZRepository
+	server               *grpc.Server
+	pvzGrpcService       *service.PVZGrpcService
+	reportGrpcService    *service.ReportGrpcService
+	receptionGrpcService *service.ReceptionGrpcService
+	listener             net.Listener
+	pvzRepo              repository.PVZRepository
+	reportService        service.ReportService
+	receptionService     service.ReceptionService
+	log                  *slog.Logger
+	health               *health.Checker
+	healthServer         *grpchealth.Server
+	healthWatchCtx       context.Context
+	cancelHealthWatch    context.CancelFunc
 }
 
 func NewServer(
 	pvzRepo repository.PVZRepository,
+	reportService service.ReportService,
+	receptionService service.ReceptionService,
 	port string,
+	log *slog.Logger,
+	healthChecker *health.Checker,
+	dbBreaker *breaker.Breaker,
 ) (BackendServer, error) {
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		return nil, err
 	}
 
-	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(logger.GrpcLogger))
+	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(logger.GrpcRequestID, logger.GrpcLogger(log), circuitBreakerInterceptor(dbBreaker)),
+	)
 
+	// The health watch is cancelled by Stop, which may run while Start is
+	// still launching it, so both are set up here.
+	healthWatchCtx, cancelHealthWatch := context.WithCancel(context.Background())
 	return &pvzGrpcServer{
-		server:   grpcServer,
-		pvzRepo:  pvzRepo,
-		listener: lis,
+		server:            grpcServer,
+		pvzRepo:           pvzRepo,
+		reportService:     reportService,
+		receptionService:  receptionService,
+		listener:          lis,
+		log:               log,
+		health:            healthChecker,
+		healthServer:      grpchealth.NewServer(),
+		healthWatchCtx:    healthWatchCtx,
+		cancelHealthWatch: cancelHealthWatch,
 	}, nil
 }
 
@@ -43,14 +85,60 @@ func (pgs *pvzGrpcServer) ConfigureRoutes() {
 	reflection.Register(pgs.server)
 	pgs.pvzGrpcService = service.NewPVZGrpcService(pgs.pvzRepo)
 	proto.RegisterPVZServiceServer(pgs.server, pgs.pvzGrpcService)
+	pgs.reportGrpcService = service.NewReportGrpcService(pgs.reportService)
+	proto.RegisterReportServiceServer(pgs.server, pgs.reportGrpcService)
+	pgs.receptionGrpcService = service.NewReceptionGrpcService(pgs.receptionService)
+	proto.RegisterReceptionServiceServer(pgs.server, pgs.receptionGrpcService)
+	healthpb.RegisterHealthServer(pgs.server, pgs.healthServer)
 }
 
 func (pgs *pvzGrpcServer) Start() error {
-	log.Println("starting gRPC server...")
+	pgs.log.Info("starting gRPC server", slog.String("addr", pgs.listener.Addr().String()))
+	go pgs.watchHealth(pgs.healthWatchCtx)
 	return pgs.server.Serve(pgs.listener)
 }
 
+// watchHealth mirrors the readiness of the process to the overall serving
+// status of the grpc.health.v1.Health service. Draining is reported at once,
+// without waiting for the next tick.
+func (pgs *pvzGrpcServer) watchHealth(ctx context.Context) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	draining := pgs.health.Draining()
+	for {
+		pgs.updateServingStatus(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-draining:
+			draining = nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (pgs *pvzGrpcServer) updateServingStatus(ctx context.Context) {
+	checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if err := pgs.health.Ready(checkCtx); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		pgs.log.Warn("gRPC server is not ready", slog.Any("error", err))
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	pgs.healthServer.SetServingStatus("", status)
+}
+
 func (pgs *pvzGrpcServer) Stop(ctx context.Context) {
+	pgs.health.Drain()
+	pgs.cancelHealthWatch()
+	// Shutdown sets NOT_SERVING for every service and ignores later updates.
+	pgs.healthServer.Shutdown()
+
 	shutdownCtx, cancel := context.WithTimeout(ctx, time.Second*5)
 	defer cancel()
 
@@ -63,9 +151,28 @@ func (pgs *pvzGrpcServer) Stop(ctx context.Context) {
 
 	select {
 	case <-doneCh:
-		log.Println("gRPC server stopped gracefully")
+		pgs.log.Info("gRPC server stopped gracefully")
 	case <-shutdownCtx.Done():
 		pgs.server.Stop()
-		log.Println("gRPC server forced to stop")
+		pgs.log.Warn("gRPC server forced to stop")
+	}
+}
+
+// circuitBreakerInterceptor fails calls with Unavailable while b rejects them,
+// except for the health service, which must keep reporting. Errors meaning the
+// database is unavailable are reported as Unavailable as well.
+func circuitBreakerInterceptor(b *breaker.Breaker) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if strings.HasPrefix(info.FullMethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/") {
+			return handler(ctx, req)
+		}
+		if !b.Ready() {
+			return nil, status.Error(codes.Unavailable, breaker.ErrOpen.Error())
+		}
+		resp, err := handler(ctx, req)
+		if errors.Is(err, breaker.ErrOpen) || database.IsUnavailable(err) {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		return resp, err
 	}
 }
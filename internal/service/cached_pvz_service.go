@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/ners1us/order-service/internal/cache"
+	"github.com/ners1us/order-service/internal/database"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"slices"
+	"time"
+)
+
+// CachedPVZService caches the assembled GetPVZList pages of the wrapped
+// PVZService. It is also an event.Handler: reception and product change events
+// evict only the pages that contain the affected PVZ, while PVZ changes and
+// listener reconnects drop every page because they can shift pagination or
+// hide missed notifications. For replicaLag after an eviction pages are
+// filled from the primary, so that a replica which has not caught up yet does
+// not put the evicted data back.
+type CachedPVZService struct {
+	PVZService
+	pageCache  cache.Cache[[]model.PVZWithReceptions]
+	replicaLag time.Duration
+}
+
+func NewCachedPVZService(pvzService PVZService, pageCache cache.Cache[[]model.PVZWithReceptions], replicaLag time.Duration) *CachedPVZService {
+	return &CachedPVZService{
+		PVZService: pvzService,
+		pageCache:  pageCache,
+		replicaLag: replicaLag,
+	}
+}
+
+// GetPVZList caches only pages without deleted products, so that a page read
+// by a moderator is never served to anyone else and the role check always runs.
+func (cps *CachedPVZService) GetPVZList(ctx context.Context, startDate, endDate time.Time, page, limit int, includeDeleted bool, userRole string) ([]model.PVZWithReceptions, error) {
+	if includeDeleted {
+		return cps.PVZService.GetPVZList(ctx, startDate, endDate, page, limit, includeDeleted, userRole)
+	}
+	key := fmt.Sprintf("%d:%d:%d:%d", startDate.UnixNano(), endDate.UnixNano(), page, limit)
+	if pvzList, ok := cps.pageCache.Get(key); ok {
+		return pvzList, nil
+	}
+	generation := cps.pageCache.Generation()
+	ctx = database.WithPrimaryIfRecent(ctx, cps.pageCache.InvalidatedAt(), cps.replicaLag)
+	pvzList, err := cps.PVZService.GetPVZList(ctx, startDate, endDate, page, limit, includeDeleted, userRole)
+	if err != nil {
+		return nil, err
+	}
+	cps.pageCache.Set(key, pvzList, generation)
+	return pvzList, nil
+}
+
+func (cps *CachedPVZService) CreatePVZ(ctx context.Context, pvz *model.PVZ, userRole string) (*model.PVZ, error) {
+	createdPVZ, err := cps.PVZService.CreatePVZ(ctx, pvz, userRole)
+	if err == nil {
+		cps.pageCache.Clear()
+	}
+	return createdPVZ, err
+}
+
+func (cps *CachedPVZService) UpdatePVZ(ctx context.Context, id string, update *model.PVZUpdate, userRole string) (*model.PVZ, error) {
+	updatedPVZ, err := cps.PVZService.UpdatePVZ(ctx, id, update, userRole)
+	if err == nil {
+		cps.evictPVZ(id)
+	}
+	return updatedPVZ, err
+}
+
+func (cps *CachedPVZService) DecommissionPVZ(ctx context.Context, id string, userRole string) (*model.PVZ, error) {
+	decommissionedPVZ, err := cps.PVZService.DecommissionPVZ(ctx, id, userRole)
+	if err == nil {
+		cps.evictPVZ(id)
+	}
+	return decommissionedPVZ, err
+}
+
+func (cps *CachedPVZService) Handle(event *model.Event) error {
+	switch enum.EventType(event.Type) {
+	case enum.EventReceptionChanged, enum.EventProductsChanged:
+		change, ok := event.Payload.(model.ReceptionChange)
+		if !ok {
+			return fmt.Errorf("unexpected %s payload %T", event.Type, event.Payload)
+		}
+		cps.evictPVZ(change.PVZID)
+	case enum.EventReceptionEscalated:
+		escalation, ok := event.Payload.(model.ReceptionEscalation)
+		if !ok {
+			return fmt.Errorf("unexpected %s payload %T", event.Type, event.Payload)
+		}
+		cps.evictPVZ(escalation.PVZID)
+	case enum.EventPVZsChanged, enum.EventListenerReconnected:
+		cps.pageCache.Clear()
+	}
+	return nil
+}
+
+func (cps *CachedPVZService) evictPVZ(pvzID string) {
+	cps.pageCache.DeleteFunc(func(_ string, pvzList []model.PVZWithReceptions) bool {
+		return slices.ContainsFunc(pvzList, func(pvz model.PVZWithReceptions) bool {
+			return pvz.PVZ.ID == pvzID
+		})
+	})
+}
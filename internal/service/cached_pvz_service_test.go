@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"github.com/ners1us/order-service/internal/cache"
+	"github.com/ners1us/order-service/internal/database"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func newCachedPVZServiceWithMocks(capacity int) (*CachedPVZService, *repository.MockPVZRepository) {
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	mockPVZRepo.On("GetPVZPage", 1, 10, mock.Anything, mock.Anything, false).Return(&model.PVZPage{PVZs: []model.PVZ{{ID: "pvz_1"}}}, nil)
+	mockPVZRepo.On("GetPVZPage", 2, 10, mock.Anything, mock.Anything, false).Return(&model.PVZPage{PVZs: []model.PVZ{{ID: "pvz_2"}}}, nil)
+
+	service := NewCachedPVZService(
+		NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger),
+		cache.NewLRUCache[[]model.PVZWithReceptions]("test_pvz_pages", capacity, time.Minute),
+		0,
+	)
+	return service, mockPVZRepo
+}
+
+func TestCachedGetPVZList_CacheHit(t *testing.T) {
+	// Arrange
+	service, mockPVZRepo := newCachedPVZServiceWithMocks(10)
+	startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	first, err1 := service.GetPVZList(context.Background(), startDate, endDate, 1, 10, false, enum.RoleEmployee.String())
+	second, err2 := service.GetPVZList(context.Background(), startDate, endDate, 1, 10, false, enum.RoleEmployee.String())
+
+	// Assert
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, first, second)
+	mockPVZRepo.AssertNumberOfCalls(t, "GetPVZPage", 1)
+}
+
+func TestCachedGetPVZList_IncludeDeletedBypassesCache(t *testing.T) {
+	// Arrange
+	service, mockPVZRepo := newCachedPVZServiceWithMocks(10)
+	mockPVZRepo.On("GetPVZPage", 1, 10, mock.Anything, mock.Anything, true).Return(&model.PVZPage{PVZs: []model.PVZ{{ID: "pvz_1"}}}, nil)
+	startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	_, _ = service.GetPVZList(context.Background(), startDate, endDate, 1, 10, false, enum.RoleEmployee.String())
+
+	// Act
+	_, errModerator := service.GetPVZList(context.Background(), startDate, endDate, 1, 10, true, enum.RoleModerator.String())
+	_, errEmployee := service.GetPVZList(context.Background(), startDate, endDate, 1, 10, true, enum.RoleEmployee.String())
+
+	// Assert
+	assert.NoError(t, errModerator)
+	assert.ErrorIs(t, errEmployee, enum.ErrNoModeratorRights)
+	mockPVZRepo.AssertNumberOfCalls(t, "GetPVZPage", 2)
+}
+
+func TestCachedGetPVZList_ReceptionChangedEvictsMatchingPage(t *testing.T) {
+	// Arrange
+	service, mockPVZRepo := newCachedPVZServiceWithMocks(10)
+	bus := event.NewBus()
+	bus.Subscribe(enum.EventReceptionChanged, service)
+	startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	_, _ = service.GetPVZList(context.Background(), startDate, endDate, 1, 10, false, enum.RoleEmployee.String())
+	_, _ = service.GetPVZList(context.Background(), startDate, endDate, 2, 10, false, enum.RoleEmployee.String())
+
+	// Act
+	publishReceptionChange(context.Background(), discardLogger, bus, enum.EventReceptionChanged, "rec_1", "pvz_1")
+	_, err1 := service.GetPVZList(context.Background(), startDate, endDate, 1, 10, false, enum.RoleEmployee.String())
+	_, err2 := service.GetPVZList(context.Background(), startDate, endDate, 2, 10, false, enum.RoleEmployee.String())
+
+	// Assert
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	mockPVZRepo.AssertNumberOfCalls(t, "GetPVZPage", 3)
+}
+
+func TestCachedGetPVZList_PVZsChangedClearsCache(t *testing.T) {
+	// Arrange
+	service, mockPVZRepo := newCachedPVZServiceWithMocks(10)
+	startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	_, _ = service.GetPVZList(context.Background(), startDate, endDate, 1, 10, false, enum.RoleEmployee.String())
+	_, _ = service.GetPVZList(context.Background(), startDate, endDate, 2, 10, false, enum.RoleEmployee.String())
+
+	// Act
+	err := service.Handle(&model.Event{Type: enum.EventPVZsChanged.String(), Payload: 1})
+	_, _ = service.GetPVZList(context.Background(), startDate, endDate, 1, 10, false, enum.RoleEmployee.String())
+	_, _ = service.GetPVZList(context.Background(), startDate, endDate, 2, 10, false, enum.RoleEmployee.String())
+
+	// Assert
+	assert.NoError(t, err)
+	mockPVZRepo.AssertNumberOfCalls(t, "GetPVZPage", 4)
+}
+
+func TestCachedGetPVZList_ListenerReconnectedClearsCache(t *testing.T) {
+	// Arrange
+	service, mockPVZRepo := newCachedPVZServiceWithMocks(10)
+	remoteEventBus := event.NewBus()
+	event.SubscribeAll(remoteEventBus, service, enum.EventListenerReconnected)
+	startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	_, _ = service.GetPVZList(context.Background(), startDate, endDate, 1, 10, false, enum.RoleEmployee.String())
+
+	// Act
+	err := remoteEventBus.Publish(&model.Event{Type: enum.EventListenerReconnected.String()})
+	_, _ = service.GetPVZList(context.Background(), startDate, endDate, 1, 10, false, enum.RoleEmployee.String())
+
+	// Assert
+	assert.NoError(t, err)
+	mockPVZRepo.AssertNumberOfCalls(t, "GetPVZPage", 2)
+}
+
+func TestCachedPVZService_HandleUnexpectedPayload(t *testing.T) {
+	// Arrange
+	service, _ := newCachedPVZServiceWithMocks(10)
+
+	// Act
+	err := service.Handle(&model.Event{Type: enum.EventProductsChanged.String(), Payload: "pvz_1"})
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestCachedGetPVZList_InvalidationDuringLoadIsNotCached(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewCachedPVZService(
+		NewPVZService(mockPVZRepo, new(repository.MockReceptionRepository), new(repository.MockProductRepository), event.NewBus(), discardLogger),
+		cache.NewLRUCache[[]model.PVZWithReceptions]("test_pvz_pages_invalidation", 10, time.Minute),
+		0,
+	)
+	mockPVZRepo.On("GetPVZPage", 1, 10, mock.Anything, mock.Anything, false).
+		Run(func(mock.Arguments) {
+			_ = service.Handle(&model.Event{Type: enum.EventPVZsChanged.String()})
+		}).
+		Return(&model.PVZPage{PVZs: []model.PVZ{{ID: "pvz_1"}}}, nil)
+	startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	_, err1 := service.GetPVZList(context.Background(), startDate, endDate, 1, 10, false, enum.RoleEmployee.String())
+	_, err2 := service.GetPVZList(context.Background(), startDate, endDate, 1, 10, false, enum.RoleEmployee.String())
+
+	// Assert
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	mockPVZRepo.AssertNumberOfCalls(t, "GetPVZPage", 2)
+}
+
+// primaryRecordingPVZService records whether each GetPVZList call was sent to
+// the primary.
+type primaryRecordingPVZService struct {
+	PVZService
+	usedPrimary []bool
+}
+
+func (prs *primaryRecordingPVZService) GetPVZList(ctx context.Context, startDate, endDate time.Time, page, limit int, includeDeleted bool, userRole string) ([]model.PVZWithReceptions, error) {
+	prs.usedPrimary = append(prs.usedPrimary, database.UsesPrimary(ctx))
+	return []model.PVZWithReceptions{{PVZ: model.PVZ{ID: "pvz_1"}}}, nil
+}
+
+func TestCachedGetPVZList_FillsFromPrimaryRightAfterEviction(t *testing.T) {
+	cases := map[string]struct {
+		evict               bool
+		replicaLag          time.Duration
+		expectedUsedPrimary bool
+	}{
+		"NeverEvicted":    {replicaLag: time.Minute, expectedUsedPrimary: false},
+		"RecentlyEvicted": {evict: true, replicaLag: time.Minute, expectedUsedPrimary: true},
+		"LagDisabled":     {evict: true, replicaLag: 0, expectedUsedPrimary: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			pvzService := &primaryRecordingPVZService{}
+			service := NewCachedPVZService(
+				pvzService,
+				cache.NewLRUCache[[]model.PVZWithReceptions]("test_pvz_pages_lag", 10, time.Minute),
+				tc.replicaLag,
+			)
+			if tc.evict {
+				_ = service.Handle(&model.Event{
+					Type:    enum.EventReceptionChanged.String(),
+					Payload: model.ReceptionChange{PVZID: "pvz_1"},
+				})
+			}
+			startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			endDate := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+
+			// Act
+			_, err := service.GetPVZList(context.Background(), startDate, endDate, 1, 10, false, enum.RoleEmployee.String())
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, []bool{tc.expectedUsedPrimary}, pvzService.usedPrimary)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/repository"
+	"github.com/ners1us/order-service/internal/tracing"
+	"slices"
+)
+
+type CapacityService interface {
+	GetCapacityRules(ctx context.Context, pvzID string) (*model.PVZCapacityRules, error)
+	UpdateCapacityRules(ctx context.Context, rules *model.PVZCapacityRules, userRole string) (*model.PVZCapacityRules, error)
+	GetReceptionCapacity(ctx context.Context, pvzID string) (*model.ReceptionCapacity, error)
+}
+
+type capacityServiceImpl struct {
+	capacityRuleRepo repository.CapacityRuleRepository
+	pvzRepo          repository.PVZRepository
+	receptionRepo    repository.ReceptionRepository
+	productRepo      repository.ProductRepository
+}
+
+func NewCapacityService(
+	capacityRuleRepo repository.CapacityRuleRepository,
+	pvzRepo repository.PVZRepository,
+	receptionRepo repository.ReceptionRepository,
+	productRepo repository.ProductRepository,
+) CapacityService {
+	return &capacityServiceImpl{
+		capacityRuleRepo,
+		pvzRepo,
+		receptionRepo,
+		productRepo,
+	}
+}
+
+func (cs *capacityServiceImpl) GetCapacityRules(ctx context.Context, pvzID string) (_ *model.PVZCapacityRules, err error) {
+	ctx, span := tracing.Start(ctx, "CapacityService.GetCapacityRules")
+	defer tracing.End(span, &err)
+	if err := cs.checkPVZExists(ctx, pvzID); err != nil {
+		return &model.PVZCapacityRules{}, err
+	}
+	return cs.capacityRuleRepo.GetCapacityRules(ctx, pvzID)
+}
+
+func (cs *capacityServiceImpl) UpdateCapacityRules(ctx context.Context, rules *model.PVZCapacityRules, userRole string) (_ *model.PVZCapacityRules, err error) {
+	ctx, span := tracing.Start(ctx, "CapacityService.UpdateCapacityRules")
+	defer tracing.End(span, &err)
+	if userRole != enum.RoleModerator.String() {
+		return &model.PVZCapacityRules{}, enum.ErrNoModeratorRights
+	}
+	if !isValidCapacityRules(rules) {
+		return &model.PVZCapacityRules{}, enum.ErrInvalidCapacityRules
+	}
+	if err := cs.checkPVZExists(ctx, rules.PVZID); err != nil {
+		return &model.PVZCapacityRules{}, err
+	}
+	if rules.TypeQuotas == nil {
+		rules.TypeQuotas = map[string]int{}
+	}
+	if err := cs.capacityRuleRepo.SaveCapacityRules(ctx, rules); err != nil {
+		return &model.PVZCapacityRules{}, err
+	}
+	return rules, nil
+}
+
+func (cs *capacityServiceImpl) GetReceptionCapacity(ctx context.Context, pvzID string) (_ *model.ReceptionCapacity, err error) {
+	ctx, span := tracing.Start(ctx, "CapacityService.GetReceptionCapacity")
+	defer tracing.End(span, &err)
+	if err := cs.checkPVZExists(ctx, pvzID); err != nil {
+		return &model.ReceptionCapacity{}, err
+	}
+	lastReception, err := cs.receptionRepo.GetLastReceptionByPVZID(ctx, pvzID)
+	if err != nil {
+		return &model.ReceptionCapacity{}, err
+	}
+	if lastReception.Status != enum.StatusInProgress.String() {
+		return &model.ReceptionCapacity{}, enum.ErrNoOpenReceptionsToAdd
+	}
+	rules, err := cs.capacityRuleRepo.GetCapacityRules(ctx, pvzID)
+	if err != nil {
+		return &model.ReceptionCapacity{}, err
+	}
+	counts, err := cs.productRepo.CountProductsByType(ctx, lastReception.ID)
+	if err != nil {
+		return &model.ReceptionCapacity{}, err
+	}
+
+	capacity := model.ReceptionCapacity{
+		ReceptionID: lastReception.ID,
+		PVZID:       pvzID,
+		MaxProducts: rules.MaxProducts,
+		Types:       make([]model.ProductTypeCapacity, 0, len(enum.ProductTypes)),
+	}
+	for _, count := range counts {
+		capacity.TotalProducts += count
+	}
+	if rules.MaxProducts != nil {
+		capacity.Remaining = remainingOf(*rules.MaxProducts, capacity.TotalProducts)
+	}
+	for _, productType := range enum.ProductTypes {
+		typeCapacity := model.ProductTypeCapacity{
+			Type:     productType.String(),
+			Accepted: isProductTypeAccepted(rules, productType.String()),
+			Count:    counts[productType.String()],
+		}
+		if quota, ok := rules.TypeQuotas[productType.String()]; ok {
+			typeCapacity.Quota = &quota
+			typeCapacity.Remaining = remainingOf(quota, typeCapacity.Count)
+		}
+		capacity.Types = append(capacity.Types, typeCapacity)
+	}
+	return &capacity, nil
+}
+
+func (cs *capacityServiceImpl) checkPVZExists(ctx context.Context, pvzID string) error {
+	pvz, err := cs.pvzRepo.GetPVZByID(ctx, pvzID)
+	if err != nil {
+		return err
+	}
+	if pvz.ID == "" {
+		return enum.ErrPVZNotFound
+	}
+	return nil
+}
+
+func checkCapacity(rules *model.PVZCapacityRules, counts map[string]int, productType string) error {
+	if !isProductTypeAccepted(rules, productType) {
+		return enum.ErrProductTypeNotAccepted
+	}
+	if rules.MaxProducts != nil {
+		total := 0
+		for _, count := range counts {
+			total += count
+		}
+		if total >= *rules.MaxProducts {
+			return enum.ErrReceptionCapacityFull
+		}
+	}
+	if quota, ok := rules.TypeQuotas[productType]; ok && counts[productType] >= quota {
+		return enum.ErrProductTypeQuotaFull
+	}
+	return nil
+}
+
+func hasCapacityRules(rules *model.PVZCapacityRules) bool {
+	return rules.MaxProducts != nil || rules.AcceptedTypes != nil || len(rules.TypeQuotas) > 0
+}
+
+func isProductTypeAccepted(rules *model.PVZCapacityRules, productType string) bool {
+	return rules.AcceptedTypes == nil || slices.Contains(rules.AcceptedTypes, productType)
+}
+
+func isValidCapacityRules(rules *model.PVZCapacityRules) bool {
+	if rules.MaxProducts != nil && *rules.MaxProducts < 1 {
+		return false
+	}
+	for i, productType := range rules.AcceptedTypes {
+		if !enum.IsValidProductType(enum.ProductType(productType)) || slices.Contains(rules.AcceptedTypes[:i], productType) {
+			return false
+		}
+	}
+	for productType, quota := range rules.TypeQuotas {
+		if !enum.IsValidProductType(enum.ProductType(productType)) || quota < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func remainingOf(limit, count int) *int {
+	remaining := max(limit-count, 0)
+	return &remaining
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestUpdateCapacityRules_Success(t *testing.T) {
+	// Arrange
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewCapacityService(mockCapacityRuleRepo, mockPVZRepo, mockReceptionRepo, mockProductRepo)
+	maxProducts := 20
+	rules := &model.PVZCapacityRules{PVZID: "pvz_1", MaxProducts: &maxProducts, AcceptedTypes: []string{enum.ProductClothes.String()}}
+	mockPVZRepo.On("GetPVZByID", "pvz_1").Return(&model.PVZ{ID: "pvz_1"}, nil)
+	mockCapacityRuleRepo.On("SaveCapacityRules", rules).Return(nil)
+
+	// Act
+	result, err := service.UpdateCapacityRules(context.Background(), rules, enum.RoleModerator.String())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{}, result.TypeQuotas)
+}
+
+func TestUpdateCapacityRules_InvalidType(t *testing.T) {
+	// Arrange
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewCapacityService(mockCapacityRuleRepo, mockPVZRepo, mockReceptionRepo, mockProductRepo)
+	rules := &model.PVZCapacityRules{PVZID: "pvz_1", TypeQuotas: map[string]int{"мебель": 1}}
+
+	// Act
+	_, err := service.UpdateCapacityRules(context.Background(), rules, enum.RoleModerator.String())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrInvalidCapacityRules, err)
+	mockCapacityRuleRepo.AssertNotCalled(t, "SaveCapacityRules", mock.Anything)
+}
+
+func TestUpdateCapacityRules_NoModerator(t *testing.T) {
+	// Arrange
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewCapacityService(mockCapacityRuleRepo, mockPVZRepo, mockReceptionRepo, mockProductRepo)
+
+	// Act
+	_, err := service.UpdateCapacityRules(context.Background(), &model.PVZCapacityRules{PVZID: "pvz_1"}, enum.RoleEmployee.String())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrNoModeratorRights, err)
+}
+
+func TestGetReceptionCapacity_Success(t *testing.T) {
+	// Arrange
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewCapacityService(mockCapacityRuleRepo, mockPVZRepo, mockReceptionRepo, mockProductRepo)
+	maxProducts := 10
+	rules := &model.PVZCapacityRules{
+		PVZID:         "pvz_1",
+		MaxProducts:   &maxProducts,
+		AcceptedTypes: []string{enum.ProductClothes.String(), enum.ProductShoes.String()},
+		TypeQuotas:    map[string]int{enum.ProductShoes.String(): 3},
+	}
+	mockPVZRepo.On("GetPVZByID", "pvz_1").Return(&model.PVZ{ID: "pvz_1"}, nil)
+	mockReceptionRepo.On("GetLastReceptionByPVZID", "pvz_1").Return(&model.Reception{ID: "rec_1", Status: enum.StatusInProgress.String()}, nil)
+	mockCapacityRuleRepo.On("GetCapacityRules", "pvz_1").Return(rules, nil)
+	mockProductRepo.On("CountProductsByType", "rec_1").Return(map[string]int{enum.ProductClothes.String(): 4, enum.ProductShoes.String(): 1}, nil)
+
+	// Act
+	result, err := service.GetReceptionCapacity(context.Background(), "pvz_1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "rec_1", result.ReceptionID)
+	assert.Equal(t, 5, result.TotalProducts)
+	assert.Equal(t, 5, *result.Remaining)
+	assert.Len(t, result.Types, 3)
+	assert.False(t, result.Types[0].Accepted)
+	assert.Nil(t, result.Types[1].Quota)
+	assert.Equal(t, 2, *result.Types[2].Remaining)
+}
+
+func TestGetReceptionCapacity_NoOpenReception(t *testing.T) {
+	// Arrange
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewCapacityService(mockCapacityRuleRepo, mockPVZRepo, mockReceptionRepo, mockProductRepo)
+	mockPVZRepo.On("GetPVZByID", "pvz_1").Return(&model.PVZ{ID: "pvz_1"}, nil)
+	mockReceptionRepo.On("GetLastReceptionByPVZID", "pvz_1").Return(&model.Reception{ID: "rec_1", Status: enum.StatusClosed.String()}, nil)
+
+	// Act
+	_, err := service.GetReceptionCapacity(context.Background(), "pvz_1")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrNoOpenReceptionsToAdd, err)
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
+	"github.com/ners1us/order-service/internal/model"
+	"log/slog"
+	"time"
+)
+
+// publishReceptionChange announces that a reception or its products changed.
+// The write has already been committed, so a failed publish is only logged.
+func publishReceptionChange(ctx context.Context, log *slog.Logger, publisher event.Publisher, eventType enum.EventType, receptionID, pvzID string) {
+	changeEvent := model.Event{
+		Type:       eventType.String(),
+		OccurredAt: time.Now(),
+		Payload:    model.ReceptionChange{ReceptionID: receptionID, PVZID: pvzID},
+	}
+	if err := publisher.Publish(&changeEvent); err != nil {
+		log.ErrorContext(ctx, "failed to publish event",
+			slog.String("type", eventType.String()), slog.String("reception_id", receptionID), slog.Any("error", err))
+	}
+}
+
+// publishPVZsChanged announces that count PVZs were created or modified.
+func publishPVZsChanged(ctx context.Context, log *slog.Logger, publisher event.Publisher, count int) {
+	changeEvent := model.Event{
+		Type:       enum.EventPVZsChanged.String(),
+		OccurredAt: time.Now(),
+		Payload:    count,
+	}
+	if err := publisher.Publish(&changeEvent); err != nil {
+		log.ErrorContext(ctx, "failed to publish event",
+			slog.String("type", enum.EventPVZsChanged.String()), slog.Int("count", count), slog.Any("error", err))
+	}
+}
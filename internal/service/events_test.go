@@ -0,0 +1,47 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func TestPublishReceptionChange_LogsPublishError(t *testing.T) {
+	// Arrange
+	var logged bytes.Buffer
+	log := slog.New(slog.NewTextHandler(&logged, nil))
+	mockPublisher := new(event.MockPublisher)
+	mockPublisher.On("Publish", mock.Anything).Return(errors.New("publish error"))
+
+	// Act
+	publishReceptionChange(context.Background(), log, mockPublisher, enum.EventReceptionChanged, "rec_1", "pvz_1")
+
+	// Assert
+	assert.Contains(t, logged.String(), "failed to publish event")
+	assert.Contains(t, logged.String(), "reception_id=rec_1")
+	assert.Contains(t, logged.String(), "publish error")
+}
+
+func TestPublishPVZsChanged_LogsPublishError(t *testing.T) {
+	// Arrange
+	var logged bytes.Buffer
+	log := slog.New(slog.NewTextHandler(&logged, nil))
+	mockPublisher := new(event.MockPublisher)
+	mockPublisher.On("Publish", mock.Anything).Return(errors.New("publish error"))
+
+	// Act
+	publishPVZsChanged(context.Background(), log, mockPublisher, 2)
+
+	// Assert
+	assert.Contains(t, logged.String(), "failed to publish event")
+	assert.Contains(t, logged.String(), "count=2")
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/export"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/repository"
+	"github.com/ners1us/order-service/internal/tracing"
+	"io"
+	"slices"
+	"time"
+)
+
+type ExportService interface {
+	ExportReceptions(ctx context.Context, filter *model.ExportFilter, userRole string, w io.Writer) error
+}
+
+const exportTimeLayout = "2006-01-02 15:04:05"
+
+var exportHeader = []string{
+	"ПВЗ", "Город", "Приемка", "Дата приемки", "Статус приемки", "Дата закрытия", "Товар", "Дата товара", "Тип товара",
+}
+
+// exportDeletionHeader is appended to exportHeader when deleted products are
+// exported too.
+var exportDeletionHeader = []string{"Дата удаления", "Удалил", "Причина удаления"}
+
+type exportServiceImpl struct {
+	exportRepo repository.ExportRepository
+}
+
+func NewExportService(exportRepo repository.ExportRepository) ExportService {
+	return &exportServiceImpl{exportRepo}
+}
+
+func (es *exportServiceImpl) ExportReceptions(ctx context.Context, filter *model.ExportFilter, userRole string, w io.Writer) (err error) {
+	ctx, span := tracing.Start(ctx, "ExportService.ExportReceptions")
+	defer tracing.End(span, &err)
+	if userRole != enum.RoleModerator.String() {
+		return enum.ErrNoModeratorRights
+	}
+	if filter.Format == "" {
+		filter.Format = enum.ExportFormatCSV.String()
+	}
+	if err := validateExportFilter(filter); err != nil {
+		return err
+	}
+	writer, err := export.NewWriter(enum.ExportFormat(filter.Format), w)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+	header := exportHeader
+	if filter.IncludeDeleted {
+		header = slices.Concat(exportHeader, exportDeletionHeader)
+	}
+	if err := writer.WriteRow(header); err != nil {
+		return err
+	}
+	err = es.exportRepo.StreamReceptionProducts(ctx, filter, func(row *model.ExportRow) error {
+		values := toExportValues(row)
+		if filter.IncludeDeleted {
+			values = append(values, formatExportTime(row.ProductDeletedAt), row.ProductDeletedBy, row.ProductDeletionReason)
+		}
+		return writer.WriteRow(values)
+	})
+	if err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+func validateExportFilter(filter *model.ExportFilter) error {
+	if !enum.IsValidExportFormat(enum.ExportFormat(filter.Format)) {
+		return enum.ErrInvalidExportFormat
+	}
+	if filter.StartDate.IsZero() {
+		return enum.ErrInvalidStartDate
+	}
+	if filter.EndDate.IsZero() {
+		return enum.ErrInvalidEndDate
+	}
+	if !filter.StartDate.Before(filter.EndDate) {
+		return enum.ErrInvalidDateRange
+	}
+	for _, pvzID := range filter.PVZIDs {
+		if _, err := uuid.Parse(pvzID); err != nil {
+			return enum.ErrInvalidPVZID
+		}
+	}
+	return nil
+}
+
+func toExportValues(row *model.ExportRow) []string {
+	return []string{
+		row.PVZID,
+		row.City,
+		row.ReceptionID,
+		row.ReceptionDateTime.Format(exportTimeLayout),
+		row.ReceptionStatus,
+		formatExportTime(row.ReceptionClosedAt),
+		row.ProductID,
+		formatExportTime(row.ProductDateTime),
+		row.ProductType,
+	}
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(exportTimeLayout)
+}
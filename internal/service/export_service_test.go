@@ -0,0 +1,174 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newExportFilter(format string) *model.ExportFilter {
+	return &model.ExportFilter{
+		Format:    format,
+		StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestExportReceptions_CSV(t *testing.T) {
+	// Arrange
+	mockExportRepo := new(repository.MockExportRepository)
+	service := NewExportService(mockExportRepo)
+	filter := newExportFilter(enum.ExportFormatCSV.String())
+	productDateTime := time.Date(2025, 1, 10, 12, 30, 0, 0, time.UTC)
+	rows := []model.ExportRow{
+		{
+			PVZID:             "pvz_1",
+			City:              enum.CityMoscow.String(),
+			ReceptionID:       "rec_1",
+			ReceptionDateTime: time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC),
+			ReceptionStatus:   enum.StatusInProgress.String(),
+			ProductID:         "prod_1",
+			ProductDateTime:   &productDateTime,
+			ProductType:       enum.ProductShoes.String(),
+		},
+	}
+	mockExportRepo.On("StreamReceptionProducts", filter, mock.Anything).Return(rows, nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := service.ExportReceptions(context.Background(), filter, enum.RoleModerator.String(), &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "\ufeffПВЗ;Город;"))
+	assert.Equal(t, "pvz_1;Москва;rec_1;2025-01-10 12:00:00;in_progress;;prod_1;2025-01-10 12:30:00;обувь", lines[1])
+}
+
+func TestExportReceptions_IncludeDeletedAddsDeletionColumns(t *testing.T) {
+	// Arrange
+	mockExportRepo := new(repository.MockExportRepository)
+	service := NewExportService(mockExportRepo)
+	filter := newExportFilter(enum.ExportFormatCSV.String())
+	filter.IncludeDeleted = true
+	productDateTime := time.Date(2025, 1, 10, 12, 30, 0, 0, time.UTC)
+	deletedAt := time.Date(2025, 1, 10, 12, 40, 0, 0, time.UTC)
+	rows := []model.ExportRow{
+		{
+			PVZID:                 "pvz_1",
+			City:                  enum.CityMoscow.String(),
+			ReceptionID:           "rec_1",
+			ReceptionDateTime:     time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC),
+			ReceptionStatus:       enum.StatusInProgress.String(),
+			ProductID:             "prod_1",
+			ProductDateTime:       &productDateTime,
+			ProductType:           enum.ProductShoes.String(),
+			ProductDeletedAt:      &deletedAt,
+			ProductDeletedBy:      "user_1",
+			ProductDeletionReason: enum.DeletionReasonMisScan.String(),
+		},
+	}
+	mockExportRepo.On("StreamReceptionProducts", filter, mock.Anything).Return(rows, nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := service.ExportReceptions(context.Background(), filter, enum.RoleModerator.String(), &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.True(t, strings.HasSuffix(lines[0], ";Тип товара;Дата удаления;Удалил;Причина удаления"))
+	assert.Equal(t, "pvz_1;Москва;rec_1;2025-01-10 12:00:00;in_progress;;prod_1;2025-01-10 12:30:00;обувь;2025-01-10 12:40:00;user_1;mis_scan", lines[1])
+}
+
+func TestExportReceptions_XLSX(t *testing.T) {
+	// Arrange
+	mockExportRepo := new(repository.MockExportRepository)
+	service := NewExportService(mockExportRepo)
+	filter := newExportFilter(enum.ExportFormatXLSX.String())
+	rows := []model.ExportRow{{PVZID: "pvz_1", City: enum.CityKazan.String(), ReceptionID: "rec_1"}}
+	mockExportRepo.On("StreamReceptionProducts", filter, mock.Anything).Return(rows, nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := service.ExportReceptions(context.Background(), filter, enum.RoleModerator.String(), &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	_, err = zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+}
+
+func TestExportReceptions_NoModerator(t *testing.T) {
+	// Arrange
+	mockExportRepo := new(repository.MockExportRepository)
+	service := NewExportService(mockExportRepo)
+	var buf bytes.Buffer
+
+	// Act
+	err := service.ExportReceptions(context.Background(), newExportFilter(enum.ExportFormatCSV.String()), enum.RoleEmployee.String(), &buf)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrNoModeratorRights, err)
+	assert.Zero(t, buf.Len())
+}
+
+func TestExportReceptions_InvalidFormat(t *testing.T) {
+	// Arrange
+	mockExportRepo := new(repository.MockExportRepository)
+	service := NewExportService(mockExportRepo)
+	var buf bytes.Buffer
+
+	// Act
+	err := service.ExportReceptions(context.Background(), newExportFilter("pdf"), enum.RoleModerator.String(), &buf)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrInvalidExportFormat, err)
+	mockExportRepo.AssertNotCalled(t, "StreamReceptionProducts", mock.Anything, mock.Anything)
+}
+
+func TestExportReceptions_InvalidPVZID(t *testing.T) {
+	// Arrange
+	mockExportRepo := new(repository.MockExportRepository)
+	service := NewExportService(mockExportRepo)
+	filter := newExportFilter(enum.ExportFormatCSV.String())
+	filter.PVZIDs = []string{"not-a-uuid"}
+	var buf bytes.Buffer
+
+	// Act
+	err := service.ExportReceptions(context.Background(), filter, enum.RoleModerator.String(), &buf)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrInvalidPVZID, err)
+}
+
+func TestExportReceptions_RepoError(t *testing.T) {
+	// Arrange
+	mockExportRepo := new(repository.MockExportRepository)
+	service := NewExportService(mockExportRepo)
+	filter := newExportFilter(enum.ExportFormatCSV.String())
+	mockExportRepo.On("StreamReceptionProducts", filter, mock.Anything).Return(nil, errors.New("stream error"))
+	var buf bytes.Buffer
+
+	// Act
+	err := service.ExportReceptions(context.Background(), filter, enum.RoleModerator.String(), &buf)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, "stream error", err.Error())
+	assert.Zero(t, buf.Len())
+}
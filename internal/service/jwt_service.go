@@ -13,10 +13,11 @@ type JWTService interface {
 
 type jwtServiceImpl struct {
 	secretKey string
+	tokenTTL  time.Duration
 }
 
-func NewJWTService(secretKey string) JWTService {
-	return &jwtServiceImpl{secretKey}
+func NewJWTService(secretKey string, tokenTTL time.Duration) JWTService {
+	return &jwtServiceImpl{secretKey, tokenTTL}
 }
 
 func (js *jwtServiceImpl) GenerateToken(userID, role string) (string, error) {
@@ -24,7 +25,7 @@ func (js *jwtServiceImpl) GenerateToken(userID, role string) (string, error) {
 		UserID: userID,
 		Role:   role,
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(1 * time.Hour).Unix(),
+			ExpiresAt: time.Now().Add(js.tokenTTL).Unix(),
 		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -4,12 +4,13 @@ import (
 	"github.com/ners1us/order-service/internal/enum"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestGenerateToken(t *testing.T) {
 	// Arrange
 	secretKey := "secret_for_testing"
-	jwtService := NewJWTService(secretKey)
+	jwtService := NewJWTService(secretKey, time.Hour)
 	userID := "222"
 	role := enum.RoleEmployee.String()
 
@@ -24,7 +25,7 @@ func TestGenerateToken(t *testing.T) {
 func TestValidateToken(t *testing.T) {
 	// Arrange
 	secretKey := "secret_for_testing"
-	jwtService := NewJWTService(secretKey)
+	jwtService := NewJWTService(secretKey, time.Hour)
 	userID := "444"
 	role := enum.RoleEmployee.String()
 	token, _ := jwtService.GenerateToken(userID, role)
@@ -41,7 +42,7 @@ func TestValidateToken(t *testing.T) {
 func TestValidateToken_InvalidToken(t *testing.T) {
 	// Arrange
 	secretKey := "secret_for_testing"
-	jwtService := NewJWTService(secretKey)
+	jwtService := NewJWTService(secretKey, time.Hour)
 	invalidToken := "invalid_token_str"
 
 	// Act
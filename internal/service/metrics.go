@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"github.com/ners1us/order-service/internal/metric"
+	"github.com/ners1us/order-service/internal/repository"
+	"time"
+)
+
+const (
+	unknownCity       = "unknown"
+	closedByEmployee  = "employee"
+	closedByScheduler = "scheduler"
+)
+
+// pvzCity returns the city that labels the metrics of a PVZ. The write being
+// measured has already succeeded, so a failed lookup only loses the label.
+func pvzCity(ctx context.Context, pvzRepo repository.PVZRepository, pvzID string) string {
+	pvz, err := pvzRepo.GetPVZByID(ctx, pvzID)
+	if err != nil || pvz.City == "" {
+		return unknownCity
+	}
+	return pvz.City
+}
+
+func observeReceptionClosed(city, closedBy string, openedAt, closedAt time.Time) {
+	metric.ReceptionsClosed.WithLabelValues(city, closedBy).Inc()
+	metric.ReceptionDuration.WithLabelValues(city).Observe(closedAt.Sub(openedAt).Seconds())
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
+	"github.com/ners1us/order-service/internal/metric"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/repository"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// The metrics are global, so the tests compare them before and after the call.
+
+func histogramSampleCount(t *testing.T, observer prometheus.Observer) uint64 {
+	var written dto.Metric
+	require.NoError(t, observer.(prometheus.Metric).Write(&written))
+	return written.GetHistogram().GetSampleCount()
+}
+
+func TestCreateReception_CountsCreatedReception(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewReceptionService(mockReceptionRepo, mockPVZRepo, new(repository.MockProductRepository), event.NewBus(), discardLogger)
+	pvzID := "test_pvz_id"
+	mockPVZRepo.On("GetPVZByID", pvzID).Return(&model.PVZ{ID: pvzID, City: enum.CityKazan.String()}, nil)
+	mockReceptionRepo.On("GetLastReceptionByPVZID", pvzID).Return(&model.Reception{}, nil)
+	mockReceptionRepo.On("CreateReception", mock.Anything, "user_1").Return(nil)
+	created := metric.ReceptionsCreated.WithLabelValues(enum.CityKazan.String())
+	before := testutil.ToFloat64(created)
+
+	// Act
+	_, err := service.CreateReception(context.Background(), pvzID, "user_1", enum.RoleEmployee.String())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, before+1, testutil.ToFloat64(created))
+}
+
+func TestCloseLastReception_ObservesClosedReception(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewReceptionService(mockReceptionRepo, mockPVZRepo, mockProductRepo, event.NewBus(), discardLogger)
+	pvzID := "test_pvz_id"
+	lastReception := &model.Reception{ID: "rec_1", PVZID: pvzID, DateTime: time.Now().Add(-time.Hour), Status: enum.StatusInProgress.String()}
+	mockReceptionRepo.On("GetLastReceptionByPVZID", pvzID).Return(lastReception, nil)
+	mockReceptionRepo.On("CloseReception", "rec_1", mock.AnythingOfType("time.Time"), "user_1").Return(nil)
+	mockPVZRepo.On("GetPVZByID", pvzID).Return(&model.PVZ{ID: pvzID, City: enum.CitySaintPetersburg.String()}, nil)
+	mockProductRepo.On("CountProductsByType", "rec_1").Return(map[string]int{enum.ProductShoes.String(): 2}, nil)
+	city := enum.CitySaintPetersburg.String()
+	closed := metric.ReceptionsClosed.WithLabelValues(city, closedByEmployee)
+	closedBefore := testutil.ToFloat64(closed)
+	durationsBefore := histogramSampleCount(t, metric.ReceptionDuration.WithLabelValues(city))
+	productCountsBefore := histogramSampleCount(t, metric.ProductsPerReception.WithLabelValues(city))
+
+	// Act
+	_, err := service.CloseLastReception(context.Background(), pvzID, "user_1", enum.RoleEmployee.String())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, closedBefore+1, testutil.ToFloat64(closed))
+	assert.Equal(t, durationsBefore+1, histogramSampleCount(t, metric.ReceptionDuration.WithLabelValues(city)))
+	assert.Equal(t, productCountsBefore+1, histogramSampleCount(t, metric.ProductsPerReception.WithLabelValues(city)))
+}
+
+func TestAddProduct_CountsAddedProduct(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewProductService(mockReceptionRepo, mockProductRepo, mockCapacityRuleRepo, mockPVZRepo, event.NewBus(), discardLogger)
+	pvzID := "test_pvz_id"
+	mockReceptionRepo.On("GetLastReceptionByPVZID", pvzID).Return(&model.Reception{ID: "rec_1", Status: enum.StatusInProgress.String()}, nil)
+	mockCapacityRuleRepo.On("GetCapacityRules", pvzID).Return(&model.PVZCapacityRules{}, nil)
+	mockProductRepo.On("CreateProduct", mock.Anything, "user_1").Return(nil)
+	mockPVZRepo.On("GetPVZByID", pvzID).Return(&model.PVZ{ID: pvzID, City: enum.CityMoscow.String()}, nil)
+	added := metric.ProductsAdded.WithLabelValues(enum.CityMoscow.String(), enum.ProductElectronics.String())
+	before := testutil.ToFloat64(added)
+
+	// Act
+	_, err := service.AddProduct(context.Background(), &model.Product{Type: enum.ProductElectronics.String()}, pvzID, "user_1", enum.RoleEmployee.String())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, before+1, testutil.ToFloat64(added))
+}
+
+func TestAddProduct_UnknownCityLabel(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewProductService(mockReceptionRepo, mockProductRepo, mockCapacityRuleRepo, mockPVZRepo, event.NewBus(), discardLogger)
+	pvzID := "test_pvz_id"
+	mockReceptionRepo.On("GetLastReceptionByPVZID", pvzID).Return(&model.Reception{ID: "rec_1", Status: enum.StatusInProgress.String()}, nil)
+	mockCapacityRuleRepo.On("GetCapacityRules", pvzID).Return(&model.PVZCapacityRules{}, nil)
+	mockProductRepo.On("CreateProduct", mock.Anything, "user_1").Return(nil)
+	mockPVZRepo.On("GetPVZByID", pvzID).Return(&model.PVZ{}, nil)
+	added := metric.ProductsAdded.WithLabelValues(unknownCity, enum.ProductClothes.String())
+	before := testutil.ToFloat64(added)
+
+	// Act
+	_, err := service.AddProduct(context.Background(), &model.Product{Type: enum.ProductClothes.String()}, pvzID, "user_1", enum.RoleEmployee.String())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, before+1, testutil.ToFloat64(added))
+}
+
+func TestProcessStaleReceptions_CountsSchedulerClose(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockEscalationRepo := new(repository.MockEscalationRepository)
+	mockPublisher := new(event.MockPublisher)
+	now := time.Date(2025, 4, 10, 9, 0, 0, 0, time.UTC)
+	service := newTestStaleReceptionService(mockReceptionRepo, mockEscalationRepo, mockPublisher, enum.EscalationActionClose, now)
+	city := enum.CityKazan.String()
+	openReceptions := []model.OpenReception{
+		{Reception: model.Reception{ID: "rec_1"}, City: city, OpenedAt: now.Add(-3 * time.Hour)},
+	}
+	mockReceptionRepo.On("GetOpenReceptions").Return(openReceptions, nil)
+	mockEscalationRepo.On("CreateEscalation", mock.Anything).Return(nil)
+	mockPublisher.On("Publish", mock.Anything).Return(nil)
+	stale := metric.ReceptionsStale.WithLabelValues(city, enum.EscalationActionClose.String())
+	closed := metric.ReceptionsClosed.WithLabelValues(city, closedByScheduler)
+	staleBefore := testutil.ToFloat64(stale)
+	closedBefore := testutil.ToFloat64(closed)
+
+	// Act
+	_, err := service.ProcessStaleReceptions(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, staleBefore+1, testutil.ToFloat64(stale))
+	assert.Equal(t, closedBefore+1, testutil.ToFloat64(closed))
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"github.com/ners1us/order-service/internal/metric"
+	"github.com/ners1us/order-service/internal/repository"
+	"github.com/prometheus/client_golang/prometheus"
+	"log/slog"
+	"time"
+)
+
+const openReceptionScrapeTimeout = 5 * time.Second
+
+type openReceptionCollector struct {
+	receptionRepo repository.ReceptionRepository
+	log           *slog.Logger
+	now           func() time.Time
+}
+
+// NewOpenReceptionCollector reports the open receptions per city as read from
+// receptionRepo on every scrape. A failed read is logged and leaves the
+// metrics out of that scrape.
+func NewOpenReceptionCollector(receptionRepo repository.ReceptionRepository, log *slog.Logger) prometheus.Collector {
+	return &openReceptionCollector{
+		receptionRepo: receptionRepo,
+		log:           log,
+		now:           time.Now,
+	}
+}
+
+func (orc *openReceptionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- metric.ReceptionsOpen
+	ch <- metric.OpenReceptionAge
+}
+
+func (orc *openReceptionCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), openReceptionScrapeTimeout)
+	defer cancel()
+	openReceptions, err := orc.receptionRepo.GetOpenReceptions(ctx)
+	if err != nil {
+		orc.log.ErrorContext(ctx, "failed to collect open receptions", slog.Any("error", err))
+		return
+	}
+
+	now := orc.now()
+	openCounts := make(map[string]int)
+	oldestAges := make(map[string]float64)
+	for _, openReception := range openReceptions {
+		openCounts[openReception.City]++
+		age := now.Sub(openReception.OpenedAt).Seconds()
+		if oldest, ok := oldestAges[openReception.City]; !ok || age > oldest {
+			oldestAges[openReception.City] = age
+		}
+	}
+	for city, count := range openCounts {
+		ch <- prometheus.MustNewConstMetric(metric.ReceptionsOpen, prometheus.GaugeValue, float64(count), city)
+	}
+	for city, age := range oldestAges {
+		ch <- prometheus.MustNewConstMetric(metric.OpenReceptionAge, prometheus.GaugeValue, age, city)
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"errors"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/repository"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestOpenReceptionCollector(mockReceptionRepo *repository.MockReceptionRepository, now time.Time) *openReceptionCollector {
+	collector := NewOpenReceptionCollector(mockReceptionRepo, discardLogger)
+	collector.(*openReceptionCollector).now = func() time.Time { return now }
+	return collector.(*openReceptionCollector)
+}
+
+func TestOpenReceptionCollector_CountAndOldestAgePerCity(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	now := time.Date(2025, 4, 10, 9, 0, 0, 0, time.UTC)
+	collector := newTestOpenReceptionCollector(mockReceptionRepo, now)
+	openReceptions := []model.OpenReception{
+		{Reception: model.Reception{ID: "rec_1"}, City: enum.CityMoscow.String(), OpenedAt: now.Add(-time.Hour)},
+		{Reception: model.Reception{ID: "rec_2"}, City: enum.CityMoscow.String(), OpenedAt: now.Add(-3 * time.Hour)},
+		{Reception: model.Reception{ID: "rec_3"}, City: enum.CityKazan.String(), OpenedAt: now.Add(-time.Minute)},
+	}
+	mockReceptionRepo.On("GetOpenReceptions").Return(openReceptions, nil)
+	expected := `
+# HELP open_reception_age_seconds age of the oldest open reception per city
+# TYPE open_reception_age_seconds gauge
+open_reception_age_seconds{city="Казань"} 60
+open_reception_age_seconds{city="Москва"} 10800
+# HELP receptions_open number of receptions in progress per city
+# TYPE receptions_open gauge
+receptions_open{city="Казань"} 1
+receptions_open{city="Москва"} 2
+`
+
+	// Act
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "open_reception_age_seconds", "receptions_open")
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestOpenReceptionCollector_RepoError(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	collector := newTestOpenReceptionCollector(mockReceptionRepo, time.Now())
+	mockReceptionRepo.On("GetOpenReceptions").Return([]model.OpenReception{}, errors.New("db error"))
+
+	// Act
+	count := testutil.CollectAndCount(collector)
+
+	// Assert
+	assert.Equal(t, 0, count)
+}
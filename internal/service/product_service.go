@@ -1,67 +1,155 @@
 package service
 
 import (
+	"context"
 	"github.com/google/uuid"
 	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
+	"github.com/ners1us/order-service/internal/metric"
 	"github.com/ners1us/order-service/internal/model"
 	"github.com/ners1us/order-service/internal/repository"
+	"github.com/ners1us/order-service/internal/tracing"
+	"log/slog"
 	"time"
 )
 
 type ProductService interface {
-	AddProduct(product *model.Product, pvzID string, userRole string) (*model.Product, error)
-	DeleteLastProduct(pvzID string, userRole string) error
+	AddProduct(ctx context.Context, product *model.Product, pvzID string, userID string, userRole string) (*model.Product, error)
+	DeleteLastProduct(ctx context.Context, pvzID string, userID string, userRole string) error
+	DeleteProduct(ctx context.Context, productID string, reason string, userID string, userRole string) (*model.ProductDeletion, error)
 }
 
 type productServiceImpl struct {
-	receptionRepo repository.ReceptionRepository
-	productRepo   repository.ProductRepository
+	receptionRepo    repository.ReceptionRepository
+	productRepo      repository.ProductRepository
+	capacityRuleRepo repository.CapacityRuleRepository
+	pvzRepo          repository.PVZRepository
+	publisher        event.Publisher
+	log              *slog.Logger
 }
 
-func NewProductService(receptionRepo repository.ReceptionRepository, productRepo repository.ProductRepository) ProductService {
+func NewProductService(
+	receptionRepo repository.ReceptionRepository,
+	productRepo repository.ProductRepository,
+	capacityRuleRepo repository.CapacityRuleRepository,
+	pvzRepo repository.PVZRepository,
+	publisher event.Publisher,
+	log *slog.Logger,
+) ProductService {
 	return &productServiceImpl{
 		receptionRepo,
 		productRepo,
+		capacityRuleRepo,
+		pvzRepo,
+		publisher,
+		log,
 	}
 }
 
-func (ps *productServiceImpl) AddProduct(product *model.Product, pvzID string, userRole string) (*model.Product, error) {
+func (ps *productServiceImpl) AddProduct(ctx context.Context, product *model.Product, pvzID string, userID string, userRole string) (_ *model.Product, err error) {
+	ctx, span := tracing.Start(ctx, "ProductService.AddProduct")
+	defer tracing.End(span, &err)
 	if userRole != enum.RoleEmployee.String() {
 		return &model.Product{}, enum.ErrNoEmployeeRights
 	}
-	lastReception, err := ps.receptionRepo.GetLastReceptionByPVZID(pvzID)
+	lastReception, err := ps.receptionRepo.GetLastReceptionByPVZID(ctx, pvzID)
 	if err != nil {
 		return &model.Product{}, err
 	}
 	if lastReception.Status != enum.StatusInProgress.String() {
 		return &model.Product{}, enum.ErrNoOpenReceptionsToAdd
 	}
+	rules, err := ps.capacityRuleRepo.GetCapacityRules(ctx, pvzID)
+	if err != nil {
+		return &model.Product{}, err
+	}
 	product.ID = uuid.New().String()
 	product.DateTime = time.Now()
 	product.ReceptionID = lastReception.ID
-	if err := ps.productRepo.CreateProduct(product); err != nil {
+	if hasCapacityRules(rules) {
+		err = ps.productRepo.CreateProductWithinCapacity(ctx, product, userID, func(counts map[string]int) error {
+			return checkCapacity(rules, counts, product.Type)
+		})
+	} else {
+		err = ps.productRepo.CreateProduct(ctx, product, userID)
+	}
+	if err != nil {
 		return &model.Product{}, err
 	}
+	metric.ProductsAdded.WithLabelValues(pvzCity(ctx, ps.pvzRepo, pvzID), product.Type).Inc()
+	publishReceptionChange(ctx, ps.log, ps.publisher, enum.EventProductsChanged, lastReception.ID, lastReception.PVZID)
 	return product, nil
 }
 
-func (ps *productServiceImpl) DeleteLastProduct(pvzID string, userRole string) error {
+func (ps *productServiceImpl) DeleteLastProduct(ctx context.Context, pvzID string, userID string, userRole string) (err error) {
+	ctx, span := tracing.Start(ctx, "ProductService.DeleteLastProduct")
+	defer tracing.End(span, &err)
 	if userRole != enum.RoleEmployee.String() {
 		return enum.ErrNoEmployeeRights
 	}
-	lastReception, err := ps.receptionRepo.GetLastReceptionByPVZID(pvzID)
+	lastReception, err := ps.receptionRepo.GetLastReceptionByPVZID(ctx, pvzID)
 	if err != nil {
 		return err
 	}
 	if lastReception.Status != enum.StatusInProgress.String() {
 		return enum.ErrNoOpenReceptionToDelete
 	}
-	lastProduct, err := ps.productRepo.GetLastProductByReceptionID(lastReception.ID)
+	lastProduct, err := ps.productRepo.GetLastProductByReceptionID(ctx, lastReception.ID)
 	if err != nil {
 		return err
 	}
 	if lastProduct.ID == "" {
 		return enum.ErrNoProductsToDelete
 	}
-	return ps.productRepo.DeleteProduct(lastProduct.ID)
+	err = ps.productRepo.DeleteProduct(ctx, lastProduct.ID, userID, enum.DeletionReasonLastProduct.String(), time.Now())
+	if err != nil {
+		return err
+	}
+	metric.ProductsDeleted.WithLabelValues(pvzCity(ctx, ps.pvzRepo, pvzID), lastProduct.Type).Inc()
+	publishReceptionChange(ctx, ps.log, ps.publisher, enum.EventProductsChanged, lastReception.ID, lastReception.PVZID)
+	return nil
+}
+
+func (ps *productServiceImpl) DeleteProduct(ctx context.Context, productID string, reason string, userID string, userRole string) (_ *model.ProductDeletion, err error) {
+	ctx, span := tracing.Start(ctx, "ProductService.DeleteProduct")
+	defer tracing.End(span, &err)
+	if userRole != enum.RoleEmployee.String() {
+		return &model.ProductDeletion{}, enum.ErrNoEmployeeRights
+	}
+	if !enum.IsValidDeletionReason(enum.DeletionReason(reason)) {
+		return &model.ProductDeletion{}, enum.ErrInvalidDeletionReason
+	}
+	if _, err := uuid.Parse(productID); err != nil {
+		return &model.ProductDeletion{}, enum.ErrProductNotFound
+	}
+	product, err := ps.productRepo.GetProductByID(ctx, productID)
+	if err != nil {
+		return &model.ProductDeletion{}, err
+	}
+	if product.ID == "" {
+		return &model.ProductDeletion{}, enum.ErrProductNotFound
+	}
+	reception, err := ps.receptionRepo.GetReceptionByID(ctx, product.ReceptionID)
+	if err != nil {
+		return &model.ProductDeletion{}, err
+	}
+	if reception.Status != enum.StatusInProgress.String() {
+		return &model.ProductDeletion{}, enum.ErrNoOpenReceptionToDelete
+	}
+	deletion := model.ProductDeletion{
+		ID:              uuid.New().String(),
+		ProductID:       product.ID,
+		ReceptionID:     product.ReceptionID,
+		ProductType:     product.Type,
+		ProductDateTime: product.DateTime,
+		Reason:          reason,
+		DeletedBy:       userID,
+		DeletedAt:       time.Now(),
+	}
+	if err := ps.productRepo.DeleteProductWithReason(ctx, &deletion); err != nil {
+		return &model.ProductDeletion{}, err
+	}
+	metric.ProductsDeleted.WithLabelValues(pvzCity(ctx, ps.pvzRepo, reception.PVZID), product.Type).Inc()
+	publishReceptionChange(ctx, ps.log, ps.publisher, enum.EventProductsChanged, reception.ID, reception.PVZID)
+	return &deletion, nil
 }
@@ -1,8 +1,10 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
 	"github.com/ners1us/order-service/internal/model"
 	"github.com/ners1us/order-service/internal/repository"
 	"github.com/stretchr/testify/assert"
@@ -14,16 +16,20 @@ func TestAddProduct_Success(t *testing.T) {
 	// Arrange
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockProductRepo := new(repository.MockProductRepository)
-	service := NewProductService(mockReceptionRepo, mockProductRepo)
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewProductService(mockReceptionRepo, mockProductRepo, mockCapacityRuleRepo, mockPVZRepo, event.NewBus(), discardLogger)
 	product := new(model.Product)
 	pvzID := "test_pvz_id"
 	userRole := enum.RoleEmployee.String()
 	lastReception := &model.Reception{ID: "rec_1", Status: enum.StatusInProgress.String()}
 	mockReceptionRepo.On("GetLastReceptionByPVZID", pvzID).Return(lastReception, nil)
-	mockProductRepo.On("CreateProduct", mock.Anything).Return(nil)
+	mockCapacityRuleRepo.On("GetCapacityRules", pvzID).Return(&model.PVZCapacityRules{}, nil)
+	mockProductRepo.On("CreateProduct", mock.Anything, "user_1").Return(nil)
+	mockPVZRepo.On("GetPVZByID", pvzID).Return(&model.PVZ{ID: pvzID, City: enum.CityMoscow.String()}, nil)
 
 	// Act
-	result, err := service.AddProduct(product, pvzID, userRole)
+	result, err := service.AddProduct(context.Background(), product, pvzID, "user_1", userRole)
 
 	// Assert
 	assert.NoError(t, err)
@@ -35,7 +41,9 @@ func TestAddProduct_ProductRepoError(t *testing.T) {
 	// Arrange
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockProductRepo := new(repository.MockProductRepository)
-	service := NewProductService(mockReceptionRepo, mockProductRepo)
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewProductService(mockReceptionRepo, mockProductRepo, mockCapacityRuleRepo, mockPVZRepo, event.NewBus(), discardLogger)
 
 	product := new(model.Product)
 	pvzID := "test_pvz_id"
@@ -43,10 +51,11 @@ func TestAddProduct_ProductRepoError(t *testing.T) {
 
 	mockReceptionRepo.On("GetLastReceptionByPVZID", pvzID).
 		Return(&model.Reception{ID: "rec_1", Status: enum.StatusInProgress.String()}, nil)
-	mockProductRepo.On("CreateProduct", mock.Anything).Return(errors.New("product error"))
+	mockCapacityRuleRepo.On("GetCapacityRules", pvzID).Return(&model.PVZCapacityRules{}, nil)
+	mockProductRepo.On("CreateProduct", mock.Anything, "user_1").Return(errors.New("product error"))
 
 	// Act
-	_, err := service.AddProduct(product, pvzID, userRole)
+	_, err := service.AddProduct(context.Background(), product, pvzID, "user_1", userRole)
 
 	// Assert
 	assert.Error(t, err)
@@ -57,13 +66,15 @@ func TestAddProduct_NotEmployee(t *testing.T) {
 	// Arrange
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockProductRepo := new(repository.MockProductRepository)
-	service := NewProductService(mockReceptionRepo, mockProductRepo)
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewProductService(mockReceptionRepo, mockProductRepo, mockCapacityRuleRepo, mockPVZRepo, event.NewBus(), discardLogger)
 	product := new(model.Product)
 	pvzID := "test_pvz_id2"
 	userRole := enum.RoleModerator.String()
 
 	// Act
-	_, err := service.AddProduct(product, pvzID, userRole)
+	_, err := service.AddProduct(context.Background(), product, pvzID, "user_1", userRole)
 
 	// Assert
 	assert.Error(t, err)
@@ -74,7 +85,9 @@ func TestAddProduct_NoOpenReception(t *testing.T) {
 	// Arrange
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockProductRepo := new(repository.MockProductRepository)
-	service := NewProductService(mockReceptionRepo, mockProductRepo)
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewProductService(mockReceptionRepo, mockProductRepo, mockCapacityRuleRepo, mockPVZRepo, event.NewBus(), discardLogger)
 	product := new(model.Product)
 	pvzID := "test_pvz_id"
 	userRole := enum.RoleEmployee.String()
@@ -82,7 +95,7 @@ func TestAddProduct_NoOpenReception(t *testing.T) {
 	mockReceptionRepo.On("GetLastReceptionByPVZID", pvzID).Return(lastReception, nil)
 
 	// Act
-	_, err := service.AddProduct(product, pvzID, userRole)
+	_, err := service.AddProduct(context.Background(), product, pvzID, "user_1", userRole)
 
 	// Assert
 	assert.Error(t, err)
@@ -93,12 +106,14 @@ func TestDeleteLastProduct_InvalidRole(t *testing.T) {
 	// Arrange
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockProductRepo := new(repository.MockProductRepository)
-	service := NewProductService(mockReceptionRepo, mockProductRepo)
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewProductService(mockReceptionRepo, mockProductRepo, mockCapacityRuleRepo, mockPVZRepo, event.NewBus(), discardLogger)
 	pvzID := "test_pvz_id"
 	userRole := "test_user_id"
 
 	// Act
-	err := service.DeleteLastProduct(pvzID, userRole)
+	err := service.DeleteLastProduct(context.Background(), pvzID, "user_1", userRole)
 
 	// Assert
 	assert.Error(t, err)
@@ -109,13 +124,15 @@ func TestDeleteLastProduct_EmptyReceptionID(t *testing.T) {
 	// Arrange
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockProductRepo := new(repository.MockProductRepository)
-	service := NewProductService(mockReceptionRepo, mockProductRepo)
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewProductService(mockReceptionRepo, mockProductRepo, mockCapacityRuleRepo, mockPVZRepo, event.NewBus(), discardLogger)
 	pvzID := "test_pvz_id"
 	userRole := enum.RoleEmployee.String()
 	mockReceptionRepo.On("GetLastReceptionByPVZID", pvzID).Return(&model.Reception{ID: ""}, nil)
 
 	// Act
-	err := service.DeleteLastProduct(pvzID, userRole)
+	err := service.DeleteLastProduct(context.Background(), pvzID, "user_1", userRole)
 
 	// Assert
 	assert.Error(t, err)
@@ -126,14 +143,16 @@ func TestAddProduct_EmptyPVZID(t *testing.T) {
 	// Arrange
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockProductRepo := new(repository.MockProductRepository)
-	service := NewProductService(mockReceptionRepo, mockProductRepo)
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewProductService(mockReceptionRepo, mockProductRepo, mockCapacityRuleRepo, mockPVZRepo, event.NewBus(), discardLogger)
 	product := new(model.Product)
 	pvzID := ""
 	userRole := enum.RoleEmployee.String()
 	mockReceptionRepo.On("GetLastReceptionByPVZID", pvzID).Return(&model.Reception{}, nil)
 
 	// Act
-	_, err := service.AddProduct(product, pvzID, userRole)
+	_, err := service.AddProduct(context.Background(), product, pvzID, "user_1", userRole)
 
 	// Assert
 	assert.Error(t, err)
@@ -144,16 +163,18 @@ func TestDeleteLastProduct_ProductRepoError(t *testing.T) {
 	// Arrange
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockProductRepo := new(repository.MockProductRepository)
-	service := NewProductService(mockReceptionRepo, mockProductRepo)
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewProductService(mockReceptionRepo, mockProductRepo, mockCapacityRuleRepo, mockPVZRepo, event.NewBus(), discardLogger)
 	pvzID := "test_pvz_id"
 	userRole := enum.RoleEmployee.String()
 	lastReception := &model.Reception{ID: "rec_1", Status: enum.StatusInProgress.String()}
 	mockReceptionRepo.On("GetLastReceptionByPVZID", pvzID).Return(lastReception, nil)
 	mockProductRepo.On("GetLastProductByReceptionID", lastReception.ID).Return(&model.Product{ID: "prod_1"}, nil)
-	mockProductRepo.On("DeleteProduct", "prod_1").Return(errors.New("delete error"))
+	mockProductRepo.On("DeleteProduct", "prod_1", "user_1", enum.DeletionReasonLastProduct.String()).Return(errors.New("delete error"))
 
 	// Act
-	err := service.DeleteLastProduct(pvzID, userRole)
+	err := service.DeleteLastProduct(context.Background(), pvzID, "user_1", userRole)
 
 	// Assert
 	assert.Error(t, err)
@@ -164,7 +185,9 @@ func TestDeleteLastProduct_GetLastProductError(t *testing.T) {
 	// Arrange
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockProductRepo := new(repository.MockProductRepository)
-	service := NewProductService(mockReceptionRepo, mockProductRepo)
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewProductService(mockReceptionRepo, mockProductRepo, mockCapacityRuleRepo, mockPVZRepo, event.NewBus(), discardLogger)
 
 	pvzID := "test_pvz_id"
 	userRole := enum.RoleEmployee.String()
@@ -176,9 +199,197 @@ func TestDeleteLastProduct_GetLastProductError(t *testing.T) {
 		Return(&model.Product{}, errors.New("product error"))
 
 	// Act
-	err := service.DeleteLastProduct(pvzID, userRole)
+	err := service.DeleteLastProduct(context.Background(), pvzID, "user_1", userRole)
 
 	// Assert
 	assert.Error(t, err)
 	assert.Equal(t, "product error", err.Error())
 }
+
+func TestDeleteProduct_Success(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewProductService(mockReceptionRepo, mockProductRepo, mockCapacityRuleRepo, mockPVZRepo, event.NewBus(), discardLogger)
+	userRole := enum.RoleEmployee.String()
+	productID := "0d5d2f7a-3c1e-4b8f-9a6d-2e4f6b8c0a1d"
+	product := &model.Product{ID: productID, ReceptionID: "rec_1", Type: enum.ProductShoes.String()}
+	mockProductRepo.On("GetProductByID", productID).Return(product, nil)
+	mockReceptionRepo.On("GetReceptionByID", "rec_1").Return(&model.Reception{ID: "rec_1", Status: enum.StatusInProgress.String()}, nil)
+	mockProductRepo.On("DeleteProductWithReason", mock.Anything).Return(nil)
+	mockPVZRepo.On("GetPVZByID", mock.Anything).Return(&model.PVZ{}, nil)
+
+	// Act
+	result, err := service.DeleteProduct(context.Background(), productID, enum.DeletionReasonMisScan.String(), "user_1", userRole)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.ID)
+	assert.Equal(t, productID, result.ProductID)
+	assert.Equal(t, "rec_1", result.ReceptionID)
+	assert.Equal(t, enum.ProductShoes.String(), result.ProductType)
+	assert.Equal(t, enum.DeletionReasonMisScan.String(), result.Reason)
+	assert.Equal(t, "user_1", result.DeletedBy)
+}
+
+func TestDeleteProduct_InvalidReason(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewProductService(mockReceptionRepo, mockProductRepo, mockCapacityRuleRepo, mockPVZRepo, event.NewBus(), discardLogger)
+	userRole := enum.RoleEmployee.String()
+	productID := "0d5d2f7a-3c1e-4b8f-9a6d-2e4f6b8c0a1d"
+
+	// Act
+	_, err := service.DeleteProduct(context.Background(), productID, "", "user_1", userRole)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrInvalidDeletionReason, err)
+}
+
+func TestDeleteProduct_NotFound(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewProductService(mockReceptionRepo, mockProductRepo, mockCapacityRuleRepo, mockPVZRepo, event.NewBus(), discardLogger)
+	userRole := enum.RoleEmployee.String()
+	productID := "0d5d2f7a-3c1e-4b8f-9a6d-2e4f6b8c0a1d"
+	mockProductRepo.On("GetProductByID", productID).Return(&model.Product{}, nil)
+
+	// Act
+	_, err := service.DeleteProduct(context.Background(), productID, enum.DeletionReasonDamaged.String(), "user_1", userRole)
+	_, errMalformed := service.DeleteProduct(context.Background(), "prod_5", enum.DeletionReasonDamaged.String(), "user_1", userRole)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrProductNotFound, err)
+	assert.Equal(t, enum.ErrProductNotFound, errMalformed)
+	mockProductRepo.AssertNotCalled(t, "GetProductByID", "prod_5")
+}
+
+func TestDeleteProduct_ReceptionClosed(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewProductService(mockReceptionRepo, mockProductRepo, mockCapacityRuleRepo, mockPVZRepo, event.NewBus(), discardLogger)
+	userRole := enum.RoleEmployee.String()
+	productID := "0d5d2f7a-3c1e-4b8f-9a6d-2e4f6b8c0a1d"
+	product := &model.Product{ID: productID, ReceptionID: "rec_1"}
+	mockProductRepo.On("GetProductByID", productID).Return(product, nil)
+	mockReceptionRepo.On("GetReceptionByID", "rec_1").Return(&model.Reception{ID: "rec_1", Status: enum.StatusClosed.String()}, nil)
+
+	// Act
+	_, err := service.DeleteProduct(context.Background(), productID, enum.DeletionReasonDuplicate.String(), "user_1", userRole)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrNoOpenReceptionToDelete, err)
+	mockProductRepo.AssertNotCalled(t, "DeleteProductWithReason", mock.Anything)
+}
+
+func TestAddProduct_TypeNotAccepted(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewProductService(mockReceptionRepo, mockProductRepo, mockCapacityRuleRepo, mockPVZRepo, event.NewBus(), discardLogger)
+	product := &model.Product{Type: enum.ProductElectronics.String()}
+	pvzID := "test_pvz_id"
+	userRole := enum.RoleEmployee.String()
+	rules := &model.PVZCapacityRules{PVZID: pvzID, AcceptedTypes: []string{enum.ProductClothes.String()}}
+	mockReceptionRepo.On("GetLastReceptionByPVZID", pvzID).Return(&model.Reception{ID: "rec_1", Status: enum.StatusInProgress.String()}, nil)
+	mockCapacityRuleRepo.On("GetCapacityRules", pvzID).Return(rules, nil)
+	mockProductRepo.On("CreateProductWithinCapacity", mock.Anything, "user_1").Return(map[string]int{}, nil)
+
+	// Act
+	_, err := service.AddProduct(context.Background(), product, pvzID, "user_1", userRole)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrProductTypeNotAccepted, err)
+	mockProductRepo.AssertNotCalled(t, "CreateProduct", mock.Anything)
+}
+
+func TestAddProduct_CapacityFull(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewProductService(mockReceptionRepo, mockProductRepo, mockCapacityRuleRepo, mockPVZRepo, event.NewBus(), discardLogger)
+	product := &model.Product{Type: enum.ProductClothes.String()}
+	pvzID := "test_pvz_id"
+	userRole := enum.RoleEmployee.String()
+	maxProducts := 5
+	rules := &model.PVZCapacityRules{PVZID: pvzID, MaxProducts: &maxProducts}
+	mockReceptionRepo.On("GetLastReceptionByPVZID", pvzID).Return(&model.Reception{ID: "rec_1", Status: enum.StatusInProgress.String()}, nil)
+	mockCapacityRuleRepo.On("GetCapacityRules", pvzID).Return(rules, nil)
+	mockProductRepo.On("CreateProductWithinCapacity", mock.Anything, "user_1").
+		Return(map[string]int{enum.ProductClothes.String(): 3, enum.ProductShoes.String(): 2}, nil)
+
+	// Act
+	_, err := service.AddProduct(context.Background(), product, pvzID, "user_1", userRole)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrReceptionCapacityFull, err)
+}
+
+func TestAddProduct_TypeQuotaFull(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewProductService(mockReceptionRepo, mockProductRepo, mockCapacityRuleRepo, mockPVZRepo, event.NewBus(), discardLogger)
+	product := &model.Product{Type: enum.ProductShoes.String()}
+	pvzID := "test_pvz_id"
+	userRole := enum.RoleEmployee.String()
+	rules := &model.PVZCapacityRules{PVZID: pvzID, TypeQuotas: map[string]int{enum.ProductShoes.String(): 2}}
+	mockReceptionRepo.On("GetLastReceptionByPVZID", pvzID).Return(&model.Reception{ID: "rec_1", Status: enum.StatusInProgress.String()}, nil)
+	mockCapacityRuleRepo.On("GetCapacityRules", pvzID).Return(rules, nil)
+	mockProductRepo.On("CreateProductWithinCapacity", mock.Anything, "user_1").Return(map[string]int{enum.ProductShoes.String(): 2}, nil)
+
+	// Act
+	_, err := service.AddProduct(context.Background(), product, pvzID, "user_1", userRole)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrProductTypeQuotaFull, err)
+}
+
+func TestAddProduct_WithinCapacity(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	mockCapacityRuleRepo := new(repository.MockCapacityRuleRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewProductService(mockReceptionRepo, mockProductRepo, mockCapacityRuleRepo, mockPVZRepo, event.NewBus(), discardLogger)
+	product := &model.Product{Type: enum.ProductShoes.String()}
+	pvzID := "test_pvz_id"
+	userRole := enum.RoleEmployee.String()
+	maxProducts := 5
+	rules := &model.PVZCapacityRules{PVZID: pvzID, MaxProducts: &maxProducts}
+	mockReceptionRepo.On("GetLastReceptionByPVZID", pvzID).Return(&model.Reception{ID: "rec_1", Status: enum.StatusInProgress.String()}, nil)
+	mockCapacityRuleRepo.On("GetCapacityRules", pvzID).Return(rules, nil)
+	mockProductRepo.On("CreateProductWithinCapacity", mock.Anything, "user_1").Return(map[string]int{enum.ProductShoes.String(): 4}, nil)
+	mockPVZRepo.On("GetPVZByID", pvzID).Return(&model.PVZ{ID: pvzID, City: enum.CityMoscow.String()}, nil)
+
+	// Act
+	result, err := service.AddProduct(context.Background(), product, pvzID, "user_1", userRole)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "rec_1", result.ReceptionID)
+	mockProductRepo.AssertNotCalled(t, "CreateProduct", mock.Anything, mock.Anything)
+}
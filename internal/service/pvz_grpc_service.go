@@ -2,8 +2,13 @@ package service
 
 import (
 	"context"
+	"errors"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
 	"github.com/ners1us/order-service/internal/repository"
 	"github.com/ners1us/order-service/pkg/generated/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -18,8 +23,8 @@ func NewPVZGrpcService(pvzRepository repository.PVZRepository) *PVZGrpcService {
 	}
 }
 
-func (pgs *PVZGrpcService) GetPVZList(_ context.Context, _ *proto.GetPVZListRequest) (*proto.GetPVZListResponse, error) {
-	pvzs, err := pgs.pvzRepository.GetAllPVZs()
+func (pgs *PVZGrpcService) GetPVZList(ctx context.Context, _ *proto.GetPVZListRequest) (*proto.GetPVZListResponse, error) {
+	pvzs, err := pgs.pvzRepository.GetAllPVZs(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -29,13 +34,45 @@ func (pgs *PVZGrpcService) GetPVZList(_ context.Context, _ *proto.GetPVZListRequ
 	}
 
 	for _, pvz := range pvzs {
-		protoPVZ := &proto.PVZ{
-			Id:               pvz.ID,
-			RegistrationDate: timestamppb.New(pvz.RegistrationDate),
-			City:             pvz.City,
+		response.Pvzs = append(response.Pvzs, toProtoPVZ(pvz))
+	}
+
+	return response, nil
+}
+
+func (pgs *PVZGrpcService) GetNearbyPVZs(ctx context.Context, req *proto.GetNearbyPVZsRequest) (*proto.GetNearbyPVZsResponse, error) {
+	nearbyPVZs, err := findNearbyPVZs(ctx, pgs.pvzRepository, req.GetLatitude(), req.GetLongitude(), req.GetRadius(), req.GetCity(), int(req.GetLimit()))
+	if err != nil {
+		if errors.Is(err, enum.ErrInvalidCoordinates) || errors.Is(err, enum.ErrInvalidRadius) || errors.Is(err, enum.ErrInvalidCity) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
-		response.Pvzs = append(response.Pvzs, protoPVZ)
+		return nil, err
+	}
+
+	response := &proto.GetNearbyPVZsResponse{
+		Pvzs: make([]*proto.NearbyPVZ, 0, len(nearbyPVZs)),
+	}
+
+	for _, nearbyPVZ := range nearbyPVZs {
+		response.Pvzs = append(response.Pvzs, &proto.NearbyPVZ{
+			Pvz:      toProtoPVZ(nearbyPVZ.PVZ),
+			Distance: nearbyPVZ.Distance,
+		})
 	}
 
 	return response, nil
 }
+
+func toProtoPVZ(pvz model.PVZ) *proto.PVZ {
+	return &proto.PVZ{
+		Id:               pvz.ID,
+		RegistrationDate: timestamppb.New(pvz.RegistrationDate),
+		City:             pvz.City,
+		Address:          pvz.Address,
+		Latitude:         pvz.Latitude,
+		Longitude:        pvz.Longitude,
+		WorkingHours:     pvz.WorkingHours,
+		Phone:            pvz.Phone,
+		Status:           pvz.Status,
+	}
+}
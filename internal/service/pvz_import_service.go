@@ -0,0 +1,245 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
+	"github.com/ners1us/order-service/internal/export"
+	"github.com/ners1us/order-service/internal/metric"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/repository"
+	"github.com/ners1us/order-service/internal/tracing"
+	"io"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type PVZImportService interface {
+	ImportPVZs(ctx context.Context, r io.Reader, dryRun bool, userRole string) (*model.PVZImportReport, error)
+}
+
+const maxImportRows = 10000
+
+var pvzImportColumns = []string{
+	"id", "registration_date", "city", "address", "latitude", "longitude", "working_hours", "phone", "status",
+}
+
+type pvzImportServiceImpl struct {
+	pvzRepo   repository.PVZRepository
+	publisher event.Publisher
+	log       *slog.Logger
+}
+
+func NewPVZImportService(pvzRepo repository.PVZRepository, publisher event.Publisher, log *slog.Logger) PVZImportService {
+	return &pvzImportServiceImpl{pvzRepo, publisher, log}
+}
+
+// ImportPVZs validates every CSV row with the same rules as CreatePVZ and, unless
+// dryRun is set, inserts the valid rows in a single transaction. Rows are
+// reported by their line number in the file.
+func (pis *pvzImportServiceImpl) ImportPVZs(ctx context.Context, r io.Reader, dryRun bool, userRole string) (_ *model.PVZImportReport, err error) {
+	ctx, span := tracing.Start(ctx, "PVZImportService.ImportPVZs")
+	defer tracing.End(span, &err)
+	if userRole != enum.RoleModerator.String() {
+		return &model.PVZImportReport{}, enum.ErrNoModeratorRights
+	}
+	reader, err := newImportReader(r)
+	if err != nil {
+		return &model.PVZImportReport{}, err
+	}
+	header, err := reader.Read()
+	if err != nil {
+		return &model.PVZImportReport{}, enum.ErrInvalidImportFile
+	}
+	columns, err := parseImportHeader(header)
+	if err != nil {
+		return &model.PVZImportReport{}, err
+	}
+
+	report := &model.PVZImportReport{DryRun: dryRun, Rows: make([]model.PVZImportRowResult, 0)}
+	var pvzs []model.PVZ
+	var pvzRows []int
+	seenIDs := make(map[string]bool)
+	now := time.Now()
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		report.TotalRows++
+		if report.TotalRows > maxImportRows {
+			return &model.PVZImportReport{}, enum.ErrImportTooLarge
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			report.Rows = append(report.Rows, model.PVZImportRowResult{Row: parseErr.StartLine, Error: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return &model.PVZImportReport{}, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		pvz, err := parseImportRow(record, columns, now)
+		if err == nil {
+			err = validatePVZ(pvz)
+		}
+		if err == nil && seenIDs[pvz.ID] {
+			err = enum.ErrDuplicatePVZID
+		}
+		if err != nil {
+			report.Rows = append(report.Rows, model.PVZImportRowResult{Row: line, Error: err.Error()})
+			continue
+		}
+		seenIDs[pvz.ID] = true
+		pvzRows = append(pvzRows, len(report.Rows))
+		pvzs = append(pvzs, *pvz)
+		report.Rows = append(report.Rows, model.PVZImportRowResult{Row: line, ID: pvz.ID})
+	}
+
+	pvzs, err = pis.excludeExistingPVZs(ctx, report, pvzs, pvzRows)
+	if err != nil {
+		return &model.PVZImportReport{}, err
+	}
+	report.ValidRows = len(pvzs)
+	report.InvalidRows = report.TotalRows - report.ValidRows
+	if dryRun || len(pvzs) == 0 {
+		return report, nil
+	}
+	if err := pis.pvzRepo.CreatePVZs(ctx, pvzs); err != nil {
+		return &model.PVZImportReport{}, err
+	}
+	report.Imported = len(pvzs)
+	for _, pvz := range pvzs {
+		metric.PVZCreated.WithLabelValues(pvz.City).Inc()
+	}
+	publishPVZsChanged(ctx, pis.log, pis.publisher, report.Imported)
+	return report, nil
+}
+
+func (pis *pvzImportServiceImpl) excludeExistingPVZs(ctx context.Context, report *model.PVZImportReport, pvzs []model.PVZ, pvzRows []int) ([]model.PVZ, error) {
+	if len(pvzs) == 0 {
+		return pvzs, nil
+	}
+	ids := make([]string, len(pvzs))
+	for i, pvz := range pvzs {
+		ids[i] = pvz.ID
+	}
+	existingIDs, err := pis.pvzRepo.GetExistingPVZIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(existingIDs) == 0 {
+		return pvzs, nil
+	}
+	newPVZs := make([]model.PVZ, 0, len(pvzs))
+	for i, pvz := range pvzs {
+		if slices.Contains(existingIDs, pvz.ID) {
+			report.Rows[pvzRows[i]].Error = enum.ErrPVZAlreadyExists.Error()
+			continue
+		}
+		newPVZs = append(newPVZs, pvz)
+	}
+	return newPVZs, nil
+}
+
+// newImportReader skips a UTF-8 BOM and picks ';' as the delimiter when the
+// header uses it, which is what spreadsheet applications export in ru locales.
+func newImportReader(r io.Reader) (*csv.Reader, error) {
+	br := bufio.NewReader(r)
+	if bom, err := br.Peek(len(export.UTF8BOM)); err == nil && bytes.Equal(bom, export.UTF8BOM) {
+		if _, err := br.Discard(len(export.UTF8BOM)); err != nil {
+			return nil, err
+		}
+	}
+	head, err := br.Peek(br.Size())
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, err
+	}
+	headerLine, _, _ := bytes.Cut(head, []byte("\n"))
+
+	reader := csv.NewReader(br)
+	if bytes.Count(headerLine, []byte(";")) > bytes.Count(headerLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+	reader.TrimLeadingSpace = true
+	return reader, nil
+}
+
+func parseImportHeader(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(pvzImportColumns, name) {
+			return nil, enum.ErrInvalidImportFile
+		}
+		if _, ok := columns[name]; ok {
+			return nil, enum.ErrInvalidImportFile
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["city"]; !ok {
+		return nil, enum.ErrInvalidImportFile
+	}
+	return columns, nil
+}
+
+func parseImportRow(record []string, columns map[string]int, now time.Time) (*model.PVZ, error) {
+	value := func(column string) string {
+		if i, ok := columns[column]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	pvz := &model.PVZ{
+		ID:               uuid.New().String(),
+		RegistrationDate: now,
+		City:             value("city"),
+		Address:          value("address"),
+		WorkingHours:     value("working_hours"),
+		Phone:            value("phone"),
+		Status:           value("status"),
+	}
+	if id := value("id"); id != "" {
+		parsedID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, enum.ErrInvalidPVZID
+		}
+		pvz.ID = parsedID.String()
+	}
+	if registrationDate := value("registration_date"); registrationDate != "" {
+		parsedDate, err := time.Parse(time.RFC3339, registrationDate)
+		if err != nil {
+			return nil, enum.ErrInvalidRegistrationDate
+		}
+		pvz.RegistrationDate = parsedDate
+	}
+	var err error
+	if pvz.Latitude, err = parseImportCoordinate(value("latitude")); err != nil {
+		return nil, err
+	}
+	if pvz.Longitude, err = parseImportCoordinate(value("longitude")); err != nil {
+		return nil, err
+	}
+	return pvz, nil
+}
+
+func parseImportCoordinate(value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	coordinate, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+	if err != nil {
+		return nil, enum.ErrInvalidCoordinates
+	}
+	return &coordinate, nil
+}
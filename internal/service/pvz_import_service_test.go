@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"strings"
+	"testing"
+)
+
+const importCSV = "city,address,latitude,longitude,status\n" +
+	"Москва,ул. Тверская 1,55.757,37.615,\n" +
+	"Новосибирск,ул. Ленина 2,,,\n" +
+	"Казань,ул. Баумана 3,91,49.1,\n" +
+	"Казань,ул. Баумана 4,,,suspended\n"
+
+func TestImportPVZs_Success(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewPVZImportService(mockPVZRepo, event.NewBus(), discardLogger)
+	mockPVZRepo.On("GetExistingPVZIDs", mock.Anything).Return([]string{}, nil)
+	mockPVZRepo.On("CreatePVZs", mock.MatchedBy(func(pvzs []model.PVZ) bool {
+		return len(pvzs) == 2 && pvzs[0].Status == enum.PVZStatusActive.String() && pvzs[1].Status == enum.PVZStatusSuspended.String()
+	})).Return(nil)
+
+	// Act
+	report, err := service.ImportPVZs(context.Background(), strings.NewReader(importCSV), false, enum.RoleModerator.String())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 4, report.TotalRows)
+	assert.Equal(t, 2, report.ValidRows)
+	assert.Equal(t, 2, report.InvalidRows)
+	assert.Equal(t, 2, report.Imported)
+	assert.Equal(t, 3, report.Rows[1].Row)
+	assert.Equal(t, enum.ErrInvalidCity.Error(), report.Rows[1].Error)
+	assert.Equal(t, enum.ErrInvalidCoordinates.Error(), report.Rows[2].Error)
+	assert.NotEmpty(t, report.Rows[3].ID)
+}
+
+func TestImportPVZs_DryRun(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewPVZImportService(mockPVZRepo, event.NewBus(), discardLogger)
+	mockPVZRepo.On("GetExistingPVZIDs", mock.Anything).Return([]string{}, nil)
+
+	// Act
+	report, err := service.ImportPVZs(context.Background(), strings.NewReader(importCSV), true, enum.RoleModerator.String())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 2, report.ValidRows)
+	assert.Zero(t, report.Imported)
+	mockPVZRepo.AssertNotCalled(t, "CreatePVZs", mock.Anything)
+}
+
+func TestImportPVZs_SemicolonWithBOM(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewPVZImportService(mockPVZRepo, event.NewBus(), discardLogger)
+	csv := "\ufeffid;city;latitude;longitude\n" +
+		"3fa85f64-5717-4562-b3fc-2c963f66afa6;Москва;55,757;37,615\n" +
+		"3fa85f64-5717-4562-b3fc-2c963f66afa6;Москва;;\n" +
+		"9b2f0c1e-8a4d-4f7e-9c3b-1d2e3f4a5b6c;Казань;;\n"
+	mockPVZRepo.On("GetExistingPVZIDs", []string{"3fa85f64-5717-4562-b3fc-2c963f66afa6", "9b2f0c1e-8a4d-4f7e-9c3b-1d2e3f4a5b6c"}).
+		Return([]string{"9b2f0c1e-8a4d-4f7e-9c3b-1d2e3f4a5b6c"}, nil)
+	mockPVZRepo.On("CreatePVZs", mock.MatchedBy(func(pvzs []model.PVZ) bool {
+		return len(pvzs) == 1 && *pvzs[0].Latitude == 55.757
+	})).Return(nil)
+
+	// Act
+	report, err := service.ImportPVZs(context.Background(), strings.NewReader(csv), false, enum.RoleModerator.String())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Imported)
+	assert.Equal(t, enum.ErrDuplicatePVZID.Error(), report.Rows[1].Error)
+	assert.Equal(t, enum.ErrPVZAlreadyExists.Error(), report.Rows[2].Error)
+}
+
+func TestImportPVZs_InvalidHeader(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewPVZImportService(mockPVZRepo, event.NewBus(), discardLogger)
+
+	// Act
+	_, err := service.ImportPVZs(context.Background(), strings.NewReader("name,address\nПВЗ,ул. Ленина 1\n"), false, enum.RoleModerator.String())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrInvalidImportFile, err)
+}
+
+func TestImportPVZs_NoModerator(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewPVZImportService(mockPVZRepo, event.NewBus(), discardLogger)
+
+	// Act
+	_, err := service.ImportPVZs(context.Background(), strings.NewReader(importCSV), false, enum.RoleEmployee.String())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrNoModeratorRights, err)
+}
+
+func TestImportPVZs_CreateError(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewPVZImportService(mockPVZRepo, event.NewBus(), discardLogger)
+	mockPVZRepo.On("GetExistingPVZIDs", mock.Anything).Return([]string{}, nil)
+	mockPVZRepo.On("CreatePVZs", mock.Anything).Return(errors.New("insert error"))
+
+	// Act
+	_, err := service.ImportPVZs(context.Background(), strings.NewReader(importCSV), false, enum.RoleModerator.String())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, "insert error", err.Error())
+}
@@ -1,65 +1,87 @@
 package service
 
 import (
+	"context"
 	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
+	"github.com/ners1us/order-service/internal/metric"
 	"github.com/ners1us/order-service/internal/model"
 	"github.com/ners1us/order-service/internal/repository"
+	"github.com/ners1us/order-service/internal/tracing"
+	"log/slog"
 	"time"
 )
 
 type PVZService interface {
-	CreatePVZ(pvz *model.PVZ, userRole string) (*model.PVZ, error)
-	GetPVZList(startDate, endDate time.Time, page, limit int) ([]model.PVZWithReceptions, error)
+	CreatePVZ(ctx context.Context, pvz *model.PVZ, userRole string) (*model.PVZ, error)
+	GetPVZList(ctx context.Context, startDate, endDate time.Time, page, limit int, includeDeleted bool, userRole string) ([]model.PVZWithReceptions, error)
+	GetPVZByID(ctx context.Context, id string) (*model.PVZ, error)
+	UpdatePVZ(ctx context.Context, id string, update *model.PVZUpdate, userRole string) (*model.PVZ, error)
+	DecommissionPVZ(ctx context.Context, id string, userRole string) (*model.PVZ, error)
+	GetNearbyPVZs(ctx context.Context, latitude, longitude, radius float64, city string, limit int) ([]model.NearbyPVZ, error)
 }
 
+const (
+	defaultNearbyRadiusKm = 3.0
+	maxNearbyRadiusKm     = 50.0
+	defaultNearbyLimit    = 10
+	maxNearbyLimit        = 30
+)
+
 type pvzServiceImpl struct {
 	pvzRepo       repository.PVZRepository
 	receptionRepo repository.ReceptionRepository
 	productRepo   repository.ProductRepository
+	publisher     event.Publisher
+	log           *slog.Logger
 }
 
-func NewPVZService(pvzRepo repository.PVZRepository, receptionRepo repository.ReceptionRepository, productRepo repository.ProductRepository) PVZService {
+func NewPVZService(
+	pvzRepo repository.PVZRepository,
+	receptionRepo repository.ReceptionRepository,
+	productRepo repository.ProductRepository,
+	publisher event.Publisher,
+	log *slog.Logger,
+) PVZService {
 	return &pvzServiceImpl{
 		pvzRepo,
 		receptionRepo,
 		productRepo,
+		publisher,
+		log,
 	}
 }
 
-func (ps *pvzServiceImpl) CreatePVZ(pvz *model.PVZ, userRole string) (*model.PVZ, error) {
+func (ps *pvzServiceImpl) CreatePVZ(ctx context.Context, pvz *model.PVZ, userRole string) (_ *model.PVZ, err error) {
+	ctx, span := tracing.Start(ctx, "PVZService.CreatePVZ")
+	defer tracing.End(span, &err)
 	if userRole != enum.RoleModerator.String() {
 		return &model.PVZ{}, enum.ErrNoModeratorRights
 	}
-	if !enum.IsValidCity(enum.City(pvz.City)) {
-		return &model.PVZ{}, enum.ErrInvalidCity
+	if err := validatePVZ(pvz); err != nil {
+		return &model.PVZ{}, err
 	}
-	if err := ps.pvzRepo.CreatePVZ(pvz); err != nil {
+	if err := ps.pvzRepo.CreatePVZ(ctx, pvz); err != nil {
 		return &model.PVZ{}, err
 	}
+	metric.PVZCreated.WithLabelValues(pvz.City).Inc()
+	publishPVZsChanged(ctx, ps.log, ps.publisher, 1)
 	return pvz, nil
 }
 
-func (ps *pvzServiceImpl) GetPVZList(startDate, endDate time.Time, page, limit int) ([]model.PVZWithReceptions, error) {
-	pvzs, err := ps.pvzRepo.GetPVZs(page, limit)
-	if err != nil {
-		return nil, err
-	}
-	pvzIDs := make([]string, len(pvzs))
-	for i, pvz := range pvzs {
-		pvzIDs[i] = pvz.ID
+// GetPVZList leaves deleted products out unless includeDeleted is set, which
+// only moderators may do.
+func (ps *pvzServiceImpl) GetPVZList(ctx context.Context, startDate, endDate time.Time, page, limit int, includeDeleted bool, userRole string) (_ []model.PVZWithReceptions, err error) {
+	ctx, span := tracing.Start(ctx, "PVZService.GetPVZList")
+	defer tracing.End(span, &err)
+	if includeDeleted && userRole != enum.RoleModerator.String() {
+		return nil, enum.ErrNoModeratorRights
 	}
-	receptions, err := ps.receptionRepo.GetReceptionsByPVZIDsAndDate(pvzIDs, startDate, endDate)
-	if err != nil {
-		return nil, err
-	}
-	receptionIDs := make([]string, len(receptions))
-	for i, reception := range receptions {
-		receptionIDs[i] = reception.ID
-	}
-	products, err := ps.productRepo.GetProductsByReceptionIDs(receptionIDs)
+	pvzPage, err := ps.pvzRepo.GetPVZPage(ctx, page, limit, startDate, endDate, includeDeleted)
 	if err != nil {
 		return nil, err
 	}
+	pvzs, receptions, products := pvzPage.PVZs, pvzPage.Receptions, pvzPage.Products
 
 	receptionProducts := make(map[string][]model.Product)
 	for _, product := range products {
@@ -83,3 +105,144 @@ func (ps *pvzServiceImpl) GetPVZList(startDate, endDate time.Time, page, limit i
 	}
 	return result, nil
 }
+
+func (ps *pvzServiceImpl) GetPVZByID(ctx context.Context, id string) (_ *model.PVZ, err error) {
+	ctx, span := tracing.Start(ctx, "PVZService.GetPVZByID")
+	defer tracing.End(span, &err)
+	pvz, err := ps.pvzRepo.GetPVZByID(ctx, id)
+	if err != nil {
+		return &model.PVZ{}, err
+	}
+	if pvz.ID == "" {
+		return &model.PVZ{}, enum.ErrPVZNotFound
+	}
+	return pvz, nil
+}
+
+func (ps *pvzServiceImpl) UpdatePVZ(ctx context.Context, id string, update *model.PVZUpdate, userRole string) (_ *model.PVZ, err error) {
+	ctx, span := tracing.Start(ctx, "PVZService.UpdatePVZ")
+	defer tracing.End(span, &err)
+	if userRole != enum.RoleModerator.String() {
+		return &model.PVZ{}, enum.ErrNoModeratorRights
+	}
+	pvz, err := ps.GetPVZByID(ctx, id)
+	if err != nil {
+		return &model.PVZ{}, err
+	}
+	if pvz.Status == enum.PVZStatusClosed.String() {
+		return &model.PVZ{}, enum.ErrPVZClosed
+	}
+
+	if update.Address != nil {
+		pvz.Address = *update.Address
+	}
+	if update.Latitude != nil {
+		pvz.Latitude = update.Latitude
+	}
+	if update.Longitude != nil {
+		pvz.Longitude = update.Longitude
+	}
+	if update.WorkingHours != nil {
+		pvz.WorkingHours = *update.WorkingHours
+	}
+	if update.Phone != nil {
+		pvz.Phone = *update.Phone
+	}
+	if update.Status != nil {
+		status := enum.PVZStatus(*update.Status)
+		if status != enum.PVZStatusActive && status != enum.PVZStatusSuspended {
+			return &model.PVZ{}, enum.ErrInvalidPVZStatus
+		}
+		pvz.Status = status.String()
+	}
+	if !isValidCoordinates(pvz.Latitude, pvz.Longitude) {
+		return &model.PVZ{}, enum.ErrInvalidCoordinates
+	}
+
+	if err := ps.pvzRepo.UpdatePVZ(ctx, pvz); err != nil {
+		return &model.PVZ{}, err
+	}
+	publishPVZsChanged(ctx, ps.log, ps.publisher, 1)
+	return pvz, nil
+}
+
+func (ps *pvzServiceImpl) DecommissionPVZ(ctx context.Context, id string, userRole string) (_ *model.PVZ, err error) {
+	ctx, span := tracing.Start(ctx, "PVZService.DecommissionPVZ")
+	defer tracing.End(span, &err)
+	if userRole != enum.RoleModerator.String() {
+		return &model.PVZ{}, enum.ErrNoModeratorRights
+	}
+	pvz, err := ps.GetPVZByID(ctx, id)
+	if err != nil {
+		return &model.PVZ{}, err
+	}
+	if pvz.Status == enum.PVZStatusClosed.String() {
+		return &model.PVZ{}, enum.ErrPVZClosed
+	}
+	lastReception, err := ps.receptionRepo.GetLastReceptionByPVZID(ctx, id)
+	if err != nil {
+		return &model.PVZ{}, err
+	}
+	if lastReception.Status == enum.StatusInProgress.String() {
+		return &model.PVZ{}, enum.ErrOpenReception
+	}
+	if err := ps.pvzRepo.UpdatePVZStatus(ctx, id, enum.PVZStatusClosed.String()); err != nil {
+		return &model.PVZ{}, err
+	}
+	pvz.Status = enum.PVZStatusClosed.String()
+	publishPVZsChanged(ctx, ps.log, ps.publisher, 1)
+	return pvz, nil
+}
+
+func (ps *pvzServiceImpl) GetNearbyPVZs(ctx context.Context, latitude, longitude, radius float64, city string, limit int) (_ []model.NearbyPVZ, err error) {
+	ctx, span := tracing.Start(ctx, "PVZService.GetNearbyPVZs")
+	defer tracing.End(span, &err)
+	return findNearbyPVZs(ctx, ps.pvzRepo, latitude, longitude, radius, city, limit)
+}
+
+func findNearbyPVZs(ctx context.Context, pvzRepo repository.PVZRepository, latitude, longitude, radius float64, city string, limit int) ([]model.NearbyPVZ, error) {
+	if !isValidCoordinates(&latitude, &longitude) {
+		return nil, enum.ErrInvalidCoordinates
+	}
+	if radius == 0 {
+		radius = defaultNearbyRadiusKm
+	}
+	if radius < 0 || radius > maxNearbyRadiusKm {
+		return nil, enum.ErrInvalidRadius
+	}
+	if city != "" && !enum.IsValidCity(enum.City(city)) {
+		return nil, enum.ErrInvalidCity
+	}
+	if limit < 1 {
+		limit = defaultNearbyLimit
+	}
+	limit = min(limit, maxNearbyLimit)
+	return pvzRepo.GetNearbyPVZs(ctx, latitude, longitude, radius, city, limit)
+}
+
+// validatePVZ checks a new PVZ and defaults its status to active.
+func validatePVZ(pvz *model.PVZ) error {
+	if !enum.IsValidCity(enum.City(pvz.City)) {
+		return enum.ErrInvalidCity
+	}
+	if pvz.Status == "" {
+		pvz.Status = enum.PVZStatusActive.String()
+	}
+	if !enum.IsValidPVZStatus(enum.PVZStatus(pvz.Status)) {
+		return enum.ErrInvalidPVZStatus
+	}
+	if !isValidCoordinates(pvz.Latitude, pvz.Longitude) {
+		return enum.ErrInvalidCoordinates
+	}
+	return nil
+}
+
+func isValidCoordinates(latitude, longitude *float64) bool {
+	if latitude == nil && longitude == nil {
+		return true
+	}
+	if latitude == nil || longitude == nil {
+		return false
+	}
+	return *latitude >= -90 && *latitude <= 90 && *longitude >= -180 && *longitude <= 180
+}
@@ -1,11 +1,19 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
 	"github.com/ners1us/order-service/internal/model"
 	"github.com/ners1us/order-service/internal/repository"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"testing"
 	"time"
 )
@@ -15,29 +23,52 @@ func TestCreatePVZ_InvalidRole(t *testing.T) {
 	mockPVZRepo := new(repository.MockPVZRepository)
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockProductRepo := new(repository.MockProductRepository)
-	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger)
 	pvz := new(model.PVZ)
 	userRole := enum.RoleEmployee.String()
 
 	// Act
-	_, err := service.CreatePVZ(pvz, userRole)
+	_, err := service.CreatePVZ(context.Background(), pvz, userRole)
 
 	// Assert
 	assert.Error(t, err)
 	assert.Equal(t, enum.ErrNoModeratorRights, err)
 }
 
+func TestCreatePVZ_RepoErrorRecordedOnSpan(t *testing.T) {
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previousProvider) })
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewPVZService(mockPVZRepo, new(repository.MockReceptionRepository), new(repository.MockProductRepository), event.NewBus(), discardLogger)
+	pvz := &model.PVZ{City: enum.CityMoscow.String()}
+	mockPVZRepo.On("CreatePVZ", pvz).Return(errors.New("db error"))
+
+	// Act
+	_, err := service.CreatePVZ(context.Background(), pvz, enum.RoleModerator.String())
+
+	// Assert
+	assert.Error(t, err)
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "PVZService.CreatePVZ", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "db error", spans[0].Status().Description)
+}
+
 func TestCreatePVZ_InvalidCity(t *testing.T) {
 	// Arrange
 	mockPVZRepo := new(repository.MockPVZRepository)
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockProductRepo := new(repository.MockProductRepository)
-	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger)
 	pvz := &model.PVZ{City: "InvalidCity"}
 	userRole := enum.RoleModerator.String()
 
 	// Act
-	_, err := service.CreatePVZ(pvz, userRole)
+	_, err := service.CreatePVZ(context.Background(), pvz, userRole)
 
 	// Assert
 	assert.Error(t, err)
@@ -49,25 +80,47 @@ func TestCreatePVZ_ValidCitySPb(t *testing.T) {
 	mockPVZRepo := new(repository.MockPVZRepository)
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockProductRepo := new(repository.MockProductRepository)
-	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger)
 	pvz := &model.PVZ{City: enum.CitySaintPetersburg.String()}
 	userRole := enum.RoleModerator.String()
 	mockPVZRepo.On("CreatePVZ", pvz).Return(nil)
 
 	// Act
-	result, err := service.CreatePVZ(pvz, userRole)
+	result, err := service.CreatePVZ(context.Background(), pvz, userRole)
 
 	// Assert
 	assert.NoError(t, err)
 	assert.Equal(t, pvz, result)
 }
 
+func TestCreatePVZ_PublishesPVZsChanged(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	mockPublisher := new(event.MockPublisher)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, mockPublisher, discardLogger)
+	pvz := &model.PVZ{City: enum.CityMoscow.String()}
+	userRole := enum.RoleModerator.String()
+	mockPVZRepo.On("CreatePVZ", pvz).Return(nil)
+	mockPublisher.On("Publish", mock.MatchedBy(func(e *model.Event) bool {
+		return e.Type == enum.EventPVZsChanged.String() && e.Payload == 1
+	})).Return(nil)
+
+	// Act
+	_, err := service.CreatePVZ(context.Background(), pvz, userRole)
+
+	// Assert
+	assert.NoError(t, err)
+	mockPublisher.AssertExpectations(t)
+}
+
 func TestGetPVZList_Success(t *testing.T) {
 	// Arrange
 	mockPVZRepo := new(repository.MockPVZRepository)
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockProductRepo := new(repository.MockProductRepository)
-	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger)
 
 	page, limit := 1, 10
 	startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
@@ -77,25 +130,22 @@ func TestGetPVZList_Success(t *testing.T) {
 		{ID: "pvz_1", City: enum.CityMoscow.String()},
 		{ID: "pvz_2", City: enum.CitySaintPetersburg.String()},
 	}
-	pvzIDs := []string{"pvz_1", "pvz_2"}
 
 	receptions := []model.Reception{
 		{ID: "rec_1", PVZID: "pvz_1", Status: enum.StatusClosed.String()},
 		{ID: "rec_2", PVZID: "pvz_2", Status: enum.StatusInProgress.String()},
 	}
-	receptionIDs := []string{"rec_1", "rec_2"}
 
 	products := []model.Product{
 		{ID: "prod_1", ReceptionID: "rec_1", Type: "type_1"},
 		{ID: "prod_2", ReceptionID: "rec_2", Type: "type_2"},
 	}
 
-	mockPVZRepo.On("GetPVZs", page, limit).Return(pvzs, nil)
-	mockReceptionRepo.On("GetReceptionsByPVZIDsAndDate", pvzIDs, startDate, endDate).Return(receptions, nil)
-	mockProductRepo.On("GetProductsByReceptionIDs", receptionIDs).Return(products, nil)
+	mockPVZRepo.On("GetPVZPage", page, limit, startDate, endDate, false).
+		Return(&model.PVZPage{PVZs: pvzs, Receptions: receptions, Products: products}, nil)
 
 	// Act
-	result, err := service.GetPVZList(startDate, endDate, page, limit)
+	result, err := service.GetPVZList(context.Background(), startDate, endDate, page, limit, false, enum.RoleEmployee.String())
 
 	// Assert
 	assert.NoError(t, err)
@@ -117,19 +167,279 @@ func TestGetPVZList_PVZRepoError(t *testing.T) {
 	mockPVZRepo := new(repository.MockPVZRepository)
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockProductRepo := new(repository.MockProductRepository)
-	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger)
 
 	page, limit := 1, 10
 	startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 	endDate := time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)
 
-	mockPVZRepo.On("GetPVZs", page, limit).Return([]model.PVZ{}, errors.New("db error"))
+	mockPVZRepo.On("GetPVZPage", page, limit, startDate, endDate, false).Return(&model.PVZPage{}, errors.New("db error"))
 
 	// Act
-	result, err := service.GetPVZList(startDate, endDate, page, limit)
+	result, err := service.GetPVZList(context.Background(), startDate, endDate, page, limit, false, enum.RoleEmployee.String())
 
 	// Assert
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.Equal(t, "db error", err.Error())
 }
+
+func TestGetPVZList_IncludeDeletedRequiresModerator(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger)
+
+	startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	// Act
+	result, err := service.GetPVZList(context.Background(), startDate, endDate, 1, 10, true, enum.RoleEmployee.String())
+
+	// Assert
+	assert.ErrorIs(t, err, enum.ErrNoModeratorRights)
+	assert.Nil(t, result)
+	mockPVZRepo.AssertNotCalled(t, "GetPVZPage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetPVZList_ModeratorIncludesDeleted(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger)
+
+	startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)
+	deletedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	mockPVZRepo.On("GetPVZPage", 1, 10, startDate, endDate, true).Return(&model.PVZPage{
+		PVZs:       []model.PVZ{{ID: "pvz_1"}},
+		Receptions: []model.Reception{{ID: "rec_1", PVZID: "pvz_1"}},
+		Products: []model.Product{
+			{ID: "prod_1", ReceptionID: "rec_1"},
+			{ID: "prod_2", ReceptionID: "rec_1", DeletedAt: &deletedAt, DeletedBy: "user_1", DeletionReason: enum.DeletionReasonMisScan.String()},
+		},
+	}, nil)
+
+	// Act
+	result, err := service.GetPVZList(context.Background(), startDate, endDate, 1, 10, true, enum.RoleModerator.String())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Len(t, result[0].Receptions[0].Products, 2)
+	assert.Equal(t, &deletedAt, result[0].Receptions[0].Products[1].DeletedAt)
+}
+
+func TestCreatePVZ_DefaultsToActive(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger)
+	pvz := &model.PVZ{City: enum.CityKazan.String()}
+	userRole := enum.RoleModerator.String()
+	mockPVZRepo.On("CreatePVZ", pvz).Return(nil)
+
+	// Act
+	result, err := service.CreatePVZ(context.Background(), pvz, userRole)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, enum.PVZStatusActive.String(), result.Status)
+}
+
+func TestCreatePVZ_InvalidCoordinates(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger)
+	latitude := 95.0
+	pvz := &model.PVZ{City: enum.CityMoscow.String(), Latitude: &latitude}
+	userRole := enum.RoleModerator.String()
+
+	// Act
+	_, err := service.CreatePVZ(context.Background(), pvz, userRole)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrInvalidCoordinates, err)
+}
+
+func TestGetPVZByID_NotFound(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger)
+	mockPVZRepo.On("GetPVZByID", "pvz_1").Return(&model.PVZ{}, nil)
+
+	// Act
+	_, err := service.GetPVZByID(context.Background(), "pvz_1")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrPVZNotFound, err)
+}
+
+func TestUpdatePVZ_Success(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger)
+	pvz := &model.PVZ{ID: "pvz_1", City: enum.CityMoscow.String(), Status: enum.PVZStatusActive.String()}
+	address := "ул. Тверская, 1"
+	status := enum.PVZStatusSuspended.String()
+	update := &model.PVZUpdate{Address: &address, Status: &status}
+	mockPVZRepo.On("GetPVZByID", "pvz_1").Return(pvz, nil)
+	mockPVZRepo.On("UpdatePVZ", pvz).Return(nil)
+
+	// Act
+	result, err := service.UpdatePVZ(context.Background(), "pvz_1", update, enum.RoleModerator.String())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, address, result.Address)
+	assert.Equal(t, enum.PVZStatusSuspended.String(), result.Status)
+}
+
+func TestUpdatePVZ_CannotClose(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger)
+	pvz := &model.PVZ{ID: "pvz_1", Status: enum.PVZStatusActive.String()}
+	status := enum.PVZStatusClosed.String()
+	mockPVZRepo.On("GetPVZByID", "pvz_1").Return(pvz, nil)
+
+	// Act
+	_, err := service.UpdatePVZ(context.Background(), "pvz_1", &model.PVZUpdate{Status: &status}, enum.RoleModerator.String())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrInvalidPVZStatus, err)
+	mockPVZRepo.AssertNotCalled(t, "UpdatePVZ", pvz)
+}
+
+func TestDecommissionPVZ_Success(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger)
+	pvz := &model.PVZ{ID: "pvz_1", Status: enum.PVZStatusActive.String()}
+	mockPVZRepo.On("GetPVZByID", "pvz_1").Return(pvz, nil)
+	mockReceptionRepo.On("GetLastReceptionByPVZID", "pvz_1").Return(&model.Reception{Status: enum.StatusClosed.String()}, nil)
+	mockPVZRepo.On("UpdatePVZStatus", "pvz_1", enum.PVZStatusClosed.String()).Return(nil)
+
+	// Act
+	result, err := service.DecommissionPVZ(context.Background(), "pvz_1", enum.RoleModerator.String())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, enum.PVZStatusClosed.String(), result.Status)
+}
+
+func TestDecommissionPVZ_OpenReception(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger)
+	pvz := &model.PVZ{ID: "pvz_1", Status: enum.PVZStatusActive.String()}
+	mockPVZRepo.On("GetPVZByID", "pvz_1").Return(pvz, nil)
+	mockReceptionRepo.On("GetLastReceptionByPVZID", "pvz_1").Return(&model.Reception{Status: enum.StatusInProgress.String()}, nil)
+
+	// Act
+	_, err := service.DecommissionPVZ(context.Background(), "pvz_1", enum.RoleModerator.String())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrOpenReception, err)
+}
+
+func TestDecommissionPVZ_NoModerator(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger)
+
+	// Act
+	_, err := service.DecommissionPVZ(context.Background(), "pvz_1", enum.RoleEmployee.String())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrNoModeratorRights, err)
+}
+
+func TestGetNearbyPVZs_Defaults(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger)
+	nearby := []model.NearbyPVZ{
+		{PVZ: model.PVZ{ID: "pvz_1"}, Distance: 0.4},
+		{PVZ: model.PVZ{ID: "pvz_2"}, Distance: 2.1},
+	}
+	mockPVZRepo.On("GetNearbyPVZs", 55.75, 37.61, 3.0, "", 10).Return(nearby, nil)
+
+	// Act
+	result, err := service.GetNearbyPVZs(context.Background(), 55.75, 37.61, 0, "", 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, nearby, result)
+}
+
+func TestGetNearbyPVZs_InvalidRadius(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger)
+
+	// Act
+	_, err := service.GetNearbyPVZs(context.Background(), 55.75, 37.61, 500, "", 10)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrInvalidRadius, err)
+}
+
+func TestGetNearbyPVZs_InvalidCity(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger)
+
+	// Act
+	_, err := service.GetNearbyPVZs(context.Background(), 55.75, 37.61, 3, "Новосибирск", 10)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrInvalidCity, err)
+}
+
+func TestGetNearbyPVZs_LimitCappedAtMaximum(t *testing.T) {
+	// Arrange
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewPVZService(mockPVZRepo, mockReceptionRepo, mockProductRepo, event.NewBus(), discardLogger)
+	mockPVZRepo.On("GetNearbyPVZs", 55.75, 37.61, 3.0, "", 30).Return([]model.NearbyPVZ{}, nil)
+
+	// Act
+	_, err := service.GetNearbyPVZs(context.Background(), 55.75, 37.61, 3, "", 100)
+
+	// Assert
+	assert.NoError(t, err)
+	mockPVZRepo.AssertExpectations(t)
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/pkg/generated/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ReceptionGrpcService struct {
+	proto.UnimplementedReceptionServiceServer
+	receptionService ReceptionService
+}
+
+func NewReceptionGrpcService(receptionService ReceptionService) *ReceptionGrpcService {
+	return &ReceptionGrpcService{
+		receptionService: receptionService,
+	}
+}
+
+func (rgs *ReceptionGrpcService) GetReceptionTimeline(ctx context.Context, req *proto.GetReceptionTimelineRequest) (*proto.GetReceptionTimelineResponse, error) {
+	timeline, err := rgs.receptionService.GetReceptionTimeline(ctx, req.GetReceptionId())
+	if err != nil {
+		if errors.Is(err, enum.ErrReceptionNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, err
+	}
+
+	reception := timeline.Reception
+	response := &proto.GetReceptionTimelineResponse{
+		ReceptionId: reception.ID,
+		PvzId:       reception.PVZID,
+		Status:      reception.Status,
+		DateTime:    timestamppb.New(reception.DateTime),
+		Events:      make([]*proto.ReceptionEvent, 0, len(timeline.Events)),
+	}
+	if reception.ClosedAt != nil {
+		response.ClosedAt = timestamppb.New(*reception.ClosedAt)
+	}
+
+	for _, event := range timeline.Events {
+		response.Events = append(response.Events, &proto.ReceptionEvent{
+			Id:          event.ID,
+			Type:        event.Type,
+			Actor:       event.Actor,
+			OccurredAt:  timestamppb.New(event.OccurredAt),
+			ProductId:   event.ProductID,
+			ProductType: event.ProductType,
+			Reason:      event.Reason,
+		})
+	}
+
+	return response, nil
+}
@@ -1,44 +1,74 @@
 package service
 
 import (
+	"context"
 	"github.com/google/uuid"
 	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
+	"github.com/ners1us/order-service/internal/export"
+	"github.com/ners1us/order-service/internal/metric"
 	"github.com/ners1us/order-service/internal/model"
 	"github.com/ners1us/order-service/internal/repository"
+	"github.com/ners1us/order-service/internal/tracing"
+	"io"
+	"log/slog"
 	"time"
 )
 
 type ReceptionService interface {
-	CreateReception(pvzID string, userRole string) (*model.Reception, error)
-	CloseLastReception(pvzID string, userRole string) (*model.Reception, error)
+	CreateReception(ctx context.Context, pvzID string, userID string, userRole string) (*model.Reception, error)
+	CloseLastReception(ctx context.Context, pvzID string, userID string, userRole string) (*model.Reception, error)
+	GetReceptionTimeline(ctx context.Context, receptionID string) (*model.ReceptionTimeline, error)
+	ExportReceptionTimeline(ctx context.Context, receptionID string, format string, w io.Writer) error
+}
+
+var receptionTimelineHeader = []string{
+	"Приемка", "ПВЗ", "Событие", "Дата события", "Кто", "Товар", "Тип товара", "Причина",
 }
 
 type receptionServiceImpl struct {
 	receptionRepo repository.ReceptionRepository
 	pvzRepo       repository.PVZRepository
+	productRepo   repository.ProductRepository
+	publisher     event.Publisher
+	log           *slog.Logger
 }
 
-func NewReceptionService(receptionRepo repository.ReceptionRepository, pvzRepo repository.PVZRepository) ReceptionService {
+func NewReceptionService(
+	receptionRepo repository.ReceptionRepository,
+	pvzRepo repository.PVZRepository,
+	productRepo repository.ProductRepository,
+	publisher event.Publisher,
+	log *slog.Logger,
+) ReceptionService {
 	return &receptionServiceImpl{
 		receptionRepo,
 		pvzRepo,
+		productRepo,
+		publisher,
+		log,
 	}
 }
 
-func (rs *receptionServiceImpl) CreateReception(pvzID string, userRole string) (*model.Reception, error) {
+func (rs *receptionServiceImpl) CreateReception(ctx context.Context, pvzID string, userID string, userRole string) (_ *model.Reception, err error) {
+	ctx, span := tracing.Start(ctx, "ReceptionService.CreateReception")
+	defer tracing.End(span, &err)
 	if userRole != enum.RoleEmployee.String() {
 		return &model.Reception{}, enum.ErrNoEmployeeRights
 	}
 
-	pvz, err := rs.pvzRepo.GetPVZByID(pvzID)
+	pvz, err := rs.pvzRepo.GetPVZByID(ctx, pvzID)
 	if err != nil {
 		return &model.Reception{}, err
 	}
 	if pvz.ID == "" {
 		return &model.Reception{}, enum.ErrPVZNotFound
 	}
+	if pvz.Status == enum.PVZStatusSuspended.String() || pvz.Status == enum.PVZStatusClosed.String() {
+		return &model.Reception{}, enum.ErrPVZNotActive
+	}
 
-	lastReception, err := rs.receptionRepo.GetLastReceptionByPVZID(pvzID)
+	lastReception, err := rs.receptionRepo.GetLastReceptionByPVZID(ctx, pvzID)
 	if err != nil {
 		return &model.Reception{}, err
 	}
@@ -52,26 +82,113 @@ func (rs *receptionServiceImpl) CreateReception(pvzID string, userRole string) (
 		PVZID:    pvzID,
 		Status:   enum.StatusInProgress.String(),
 	}
-	if err := rs.receptionRepo.CreateReception(&reception); err != nil {
+	if err := rs.receptionRepo.CreateReception(ctx, &reception, userID); err != nil {
 		return &model.Reception{}, err
 	}
+	metric.ReceptionsCreated.WithLabelValues(pvz.City).Inc()
+	publishReceptionChange(ctx, rs.log, rs.publisher, enum.EventReceptionChanged, reception.ID, reception.PVZID)
 	return &reception, nil
 }
 
-func (rs *receptionServiceImpl) CloseLastReception(pvzID string, userRole string) (*model.Reception, error) {
+func (rs *receptionServiceImpl) CloseLastReception(ctx context.Context, pvzID string, userID string, userRole string) (_ *model.Reception, err error) {
+	ctx, span := tracing.Start(ctx, "ReceptionService.CloseLastReception")
+	defer tracing.End(span, &err)
 	if userRole != enum.RoleEmployee.String() {
 		return &model.Reception{}, enum.ErrNoEmployeeRights
 	}
-	lastReception, err := rs.receptionRepo.GetLastReceptionByPVZID(pvzID)
+	lastReception, err := rs.receptionRepo.GetLastReceptionByPVZID(ctx, pvzID)
 	if err != nil {
 		return &model.Reception{}, err
 	}
 	if lastReception.Status != enum.StatusInProgress.String() {
 		return &model.Reception{}, enum.ErrNoOpenReceptionToClose
 	}
-	if err := rs.receptionRepo.UpdateReceptionStatus(lastReception.ID, enum.StatusClosed.String()); err != nil {
+	closedAt := time.Now()
+	if err := rs.receptionRepo.CloseReception(ctx, lastReception.ID, closedAt, userID); err != nil {
 		return &model.Reception{}, err
 	}
 	lastReception.Status = enum.StatusClosed.String()
+	lastReception.ClosedAt = &closedAt
+	rs.observeClosedReception(ctx, lastReception)
+	publishReceptionChange(ctx, rs.log, rs.publisher, enum.EventReceptionChanged, lastReception.ID, lastReception.PVZID)
 	return lastReception, nil
 }
+
+// GetReceptionTimeline returns the events of a reception in the order they
+// happened.
+func (rs *receptionServiceImpl) GetReceptionTimeline(ctx context.Context, receptionID string) (_ *model.ReceptionTimeline, err error) {
+	ctx, span := tracing.Start(ctx, "ReceptionService.GetReceptionTimeline")
+	defer tracing.End(span, &err)
+	if _, err := uuid.Parse(receptionID); err != nil {
+		return &model.ReceptionTimeline{}, enum.ErrReceptionNotFound
+	}
+	reception, err := rs.receptionRepo.GetReceptionByID(ctx, receptionID)
+	if err != nil {
+		return &model.ReceptionTimeline{}, err
+	}
+	if reception.ID == "" {
+		return &model.ReceptionTimeline{}, enum.ErrReceptionNotFound
+	}
+	events, err := rs.receptionRepo.GetReceptionEvents(ctx, reception.ID)
+	if err != nil {
+		return &model.ReceptionTimeline{}, err
+	}
+	if events == nil {
+		events = []model.ReceptionEvent{}
+	}
+	return &model.ReceptionTimeline{Reception: *reception, Events: events}, nil
+}
+
+// ExportReceptionTimeline writes the timeline of a reception to w as a table
+// in one of the export formats, one event per row.
+func (rs *receptionServiceImpl) ExportReceptionTimeline(ctx context.Context, receptionID string, format string, w io.Writer) (err error) {
+	ctx, span := tracing.Start(ctx, "ReceptionService.ExportReceptionTimeline")
+	defer tracing.End(span, &err)
+	if !enum.IsValidExportFormat(enum.ExportFormat(format)) {
+		return enum.ErrInvalidExportFormat
+	}
+	timeline, err := rs.GetReceptionTimeline(ctx, receptionID)
+	if err != nil {
+		return err
+	}
+	writer, err := export.NewWriter(enum.ExportFormat(format), w)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+	if err := writer.WriteRow(receptionTimelineHeader); err != nil {
+		return err
+	}
+	for _, event := range timeline.Events {
+		values := []string{
+			event.ReceptionID,
+			timeline.Reception.PVZID,
+			event.Type,
+			event.OccurredAt.Format(exportTimeLayout),
+			event.Actor,
+			event.ProductID,
+			event.ProductType,
+			event.Reason,
+		}
+		if err := writer.WriteRow(values); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+func (rs *receptionServiceImpl) observeClosedReception(ctx context.Context, reception *model.Reception) {
+	city := pvzCity(ctx, rs.pvzRepo, reception.PVZID)
+	observeReceptionClosed(city, closedByEmployee, reception.DateTime, *reception.ClosedAt)
+	counts, err := rs.productRepo.CountProductsByType(ctx, reception.ID)
+	if err != nil {
+		rs.log.WarnContext(ctx, "failed to count products of closed reception",
+			slog.String("reception_id", reception.ID), slog.Any("error", err))
+		return
+	}
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	metric.ProductsPerReception.WithLabelValues(city).Observe(float64(total))
+}
@@ -1,25 +1,31 @@
 package service
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
 	"github.com/ners1us/order-service/internal/model"
 	"github.com/ners1us/order-service/internal/repository"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestCreateReception_NoEmployee(t *testing.T) {
 	// Arrange
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockPVZRepo := new(repository.MockPVZRepository)
-	service := NewReceptionService(mockReceptionRepo, mockPVZRepo)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewReceptionService(mockReceptionRepo, mockPVZRepo, mockProductRepo, event.NewBus(), discardLogger)
 	pvzID := "test_pvz_id"
 	userRole := enum.RoleModerator.String()
 
 	// Act
-	_, err := service.CreateReception(pvzID, userRole)
+	_, err := service.CreateReception(context.Background(), pvzID, "user_1", userRole)
 
 	// Assert
 	assert.Error(t, err)
@@ -30,32 +36,37 @@ func TestCloseLastReception_Success(t *testing.T) {
 	// Arrange
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockPVZRepo := new(repository.MockPVZRepository)
-	service := NewReceptionService(mockReceptionRepo, mockPVZRepo)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewReceptionService(mockReceptionRepo, mockPVZRepo, mockProductRepo, event.NewBus(), discardLogger)
 	pvzID := "test_pvz_id"
 	userRole := enum.RoleEmployee.String()
-	lastReception := &model.Reception{ID: "rec_1", Status: enum.StatusInProgress.String()}
+	lastReception := &model.Reception{ID: "rec_1", PVZID: pvzID, Status: enum.StatusInProgress.String()}
 	mockReceptionRepo.On("GetLastReceptionByPVZID", pvzID).Return(lastReception, nil)
-	mockReceptionRepo.On("UpdateReceptionStatus", "rec_1", enum.StatusClosed.String()).Return(nil)
+	mockReceptionRepo.On("CloseReception", "rec_1", mock.AnythingOfType("time.Time"), "user_1").Return(nil)
+	mockPVZRepo.On("GetPVZByID", pvzID).Return(&model.PVZ{ID: pvzID, City: enum.CityKazan.String()}, nil)
+	mockProductRepo.On("CountProductsByType", "rec_1").Return(map[string]int{"электроника": 2, "одежда": 1}, nil)
 
 	// Act
-	result, err := service.CloseLastReception(pvzID, userRole)
+	result, err := service.CloseLastReception(context.Background(), pvzID, "user_1", userRole)
 
 	// Assert
 	assert.NoError(t, err)
 	assert.Equal(t, enum.StatusClosed.String(), result.Status)
+	assert.NotNil(t, result.ClosedAt)
 }
 
 func TestCreateReception_PVZNotFound(t *testing.T) {
 	// Arrange
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockPVZRepo := new(repository.MockPVZRepository)
-	service := NewReceptionService(mockReceptionRepo, mockPVZRepo)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewReceptionService(mockReceptionRepo, mockPVZRepo, mockProductRepo, event.NewBus(), discardLogger)
 	pvzID := "test_pvz_id"
 	userRole := enum.RoleEmployee.String()
 	mockPVZRepo.On("GetPVZByID", pvzID).Return(&model.PVZ{}, nil)
 
 	// Act
-	_, err := service.CreateReception(pvzID, userRole)
+	_, err := service.CreateReception(context.Background(), pvzID, "user_1", userRole)
 
 	// Assert
 	assert.Error(t, err)
@@ -66,16 +77,17 @@ func TestCreateReception_RepoError(t *testing.T) {
 	// Arrange
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockPVZRepo := new(repository.MockPVZRepository)
-	service := NewReceptionService(mockReceptionRepo, mockPVZRepo)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewReceptionService(mockReceptionRepo, mockPVZRepo, mockProductRepo, event.NewBus(), discardLogger)
 	pvzID := "test_pvz_id"
 	userRole := enum.RoleEmployee.String()
 	pvz := &model.PVZ{ID: "test_pvz_id"}
 	mockPVZRepo.On("GetPVZByID", pvzID).Return(pvz, nil)
 	mockReceptionRepo.On("GetLastReceptionByPVZID", pvzID).Return(&model.Reception{Status: enum.StatusClosed.String()}, nil)
-	mockReceptionRepo.On("CreateReception", mock.Anything).Return(errors.New("create error"))
+	mockReceptionRepo.On("CreateReception", mock.Anything, "user_1").Return(errors.New("create error"))
 
 	// Act
-	_, err := service.CreateReception(pvzID, userRole)
+	_, err := service.CreateReception(context.Background(), pvzID, "user_1", userRole)
 
 	// Assert
 	assert.Error(t, err)
@@ -86,15 +98,16 @@ func TestCloseLastReception_UpdateError(t *testing.T) {
 	// Arrange
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockPVZRepo := new(repository.MockPVZRepository)
-	service := NewReceptionService(mockReceptionRepo, mockPVZRepo)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewReceptionService(mockReceptionRepo, mockPVZRepo, mockProductRepo, event.NewBus(), discardLogger)
 	pvzID := "test_pvz_id"
 	userRole := enum.RoleEmployee.String()
 	lastReception := &model.Reception{ID: "rec_1", Status: enum.StatusInProgress.String()}
 	mockReceptionRepo.On("GetLastReceptionByPVZID", pvzID).Return(lastReception, nil)
-	mockReceptionRepo.On("UpdateReceptionStatus", "rec_1", enum.StatusClosed.String()).Return(errors.New("update error"))
+	mockReceptionRepo.On("CloseReception", "rec_1", mock.AnythingOfType("time.Time"), "user_1").Return(errors.New("update error"))
 
 	// Act
-	_, err := service.CloseLastReception(pvzID, userRole)
+	_, err := service.CloseLastReception(context.Background(), pvzID, "user_1", userRole)
 
 	// Assert
 	assert.Error(t, err)
@@ -105,7 +118,8 @@ func TestCreateReception_GetLastReceptionError(t *testing.T) {
 	// Arrange
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockPVZRepo := new(repository.MockPVZRepository)
-	service := NewReceptionService(mockReceptionRepo, mockPVZRepo)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewReceptionService(mockReceptionRepo, mockPVZRepo, mockProductRepo, event.NewBus(), discardLogger)
 
 	pvzID := "test_pvz_id"
 	userRole := enum.RoleEmployee.String()
@@ -114,7 +128,7 @@ func TestCreateReception_GetLastReceptionError(t *testing.T) {
 	mockReceptionRepo.On("GetLastReceptionByPVZID", pvzID).Return(&model.Reception{}, errors.New("reception error"))
 
 	// Act
-	result, err := service.CloseLastReception(pvzID, userRole)
+	result, err := service.CloseLastReception(context.Background(), pvzID, "user_1", userRole)
 
 	// Assert
 	assert.Error(t, err)
@@ -126,7 +140,8 @@ func TestCreateReception_GetPVZError(t *testing.T) {
 	// Arrange
 	mockReceptionRepo := new(repository.MockReceptionRepository)
 	mockPVZRepo := new(repository.MockPVZRepository)
-	service := NewReceptionService(mockReceptionRepo, mockPVZRepo)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewReceptionService(mockReceptionRepo, mockPVZRepo, mockProductRepo, event.NewBus(), discardLogger)
 
 	pvzID := "test_pvz_id"
 	userRole := enum.RoleEmployee.String()
@@ -134,10 +149,91 @@ func TestCreateReception_GetPVZError(t *testing.T) {
 	mockPVZRepo.On("GetPVZByID", pvzID).Return(&model.PVZ{}, errors.New("PVZ error"))
 
 	// Act
-	result, err := service.CreateReception(pvzID, userRole)
+	result, err := service.CreateReception(context.Background(), pvzID, "user_1", userRole)
 
 	// Assert
 	assert.Error(t, err)
 	assert.Equal(t, "PVZ error", err.Error())
 	assert.Empty(t, result.ID)
 }
+
+func TestCreateReception_PVZSuspended(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	mockProductRepo := new(repository.MockProductRepository)
+	service := NewReceptionService(mockReceptionRepo, mockPVZRepo, mockProductRepo, event.NewBus(), discardLogger)
+	pvzID := "test_pvz_id"
+	userRole := enum.RoleEmployee.String()
+	mockPVZRepo.On("GetPVZByID", pvzID).Return(&model.PVZ{ID: pvzID, Status: enum.PVZStatusSuspended.String()}, nil)
+
+	// Act
+	_, err := service.CreateReception(context.Background(), pvzID, "user_1", userRole)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrPVZNotActive, err)
+	mockReceptionRepo.AssertNotCalled(t, "GetLastReceptionByPVZID", pvzID)
+}
+
+func TestGetReceptionTimeline_NotFound(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	service := NewReceptionService(mockReceptionRepo, new(repository.MockPVZRepository), new(repository.MockProductRepository), event.NewBus(), discardLogger)
+	receptionID := "5b0c1f0e-7f3a-4a52-9d8e-1c2b3a4d5e6f"
+	mockReceptionRepo.On("GetReceptionByID", receptionID).Return(&model.Reception{}, nil)
+
+	// Act
+	_, err := service.GetReceptionTimeline(context.Background(), receptionID)
+	_, errMalformed := service.GetReceptionTimeline(context.Background(), "rec_1")
+
+	// Assert
+	assert.Equal(t, enum.ErrReceptionNotFound, err)
+	assert.Equal(t, enum.ErrReceptionNotFound, errMalformed)
+	mockReceptionRepo.AssertNotCalled(t, "GetReceptionEvents", receptionID)
+}
+
+func TestExportReceptionTimeline_CSV(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	service := NewReceptionService(mockReceptionRepo, new(repository.MockPVZRepository), new(repository.MockProductRepository), event.NewBus(), discardLogger)
+	receptionID := "5b0c1f0e-7f3a-4a52-9d8e-1c2b3a4d5e6f"
+	openedAt := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	mockReceptionRepo.On("GetReceptionByID", receptionID).
+		Return(&model.Reception{ID: receptionID, PVZID: "pvz_1", DateTime: openedAt, Status: enum.StatusInProgress.String()}, nil)
+	mockReceptionRepo.On("GetReceptionEvents", receptionID).Return([]model.ReceptionEvent{
+		{ID: 1, ReceptionID: receptionID, Type: enum.ReceptionEventOpened.String(), Actor: "user_1", OccurredAt: openedAt},
+		{
+			ID: 3, ReceptionID: receptionID, Type: enum.ReceptionEventProductDeleted.String(), Actor: "user_2",
+			OccurredAt: openedAt.Add(time.Minute), ProductID: "prod_1", ProductType: enum.ProductShoes.String(),
+			Reason: enum.DeletionReasonMisScan.String(),
+		},
+	}, nil)
+	var buf bytes.Buffer
+
+	// Act
+	err := service.ExportReceptionTimeline(context.Background(), receptionID, enum.ExportFormatCSV.String(), &buf)
+
+	// Assert
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "\ufeffПриемка;ПВЗ;Событие;"))
+	assert.Equal(t, receptionID+";pvz_1;reception_opened;2025-01-10 12:00:00;user_1;;;", lines[1])
+	assert.Equal(t, receptionID+";pvz_1;product_deleted;2025-01-10 12:01:00;user_2;prod_1;обувь;mis_scan", lines[2])
+}
+
+func TestExportReceptionTimeline_InvalidFormat(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	service := NewReceptionService(mockReceptionRepo, new(repository.MockPVZRepository), new(repository.MockProductRepository), event.NewBus(), discardLogger)
+	var buf bytes.Buffer
+
+	// Act
+	err := service.ExportReceptionTimeline(context.Background(), "5b0c1f0e-7f3a-4a52-9d8e-1c2b3a4d5e6f", "pdf", &buf)
+
+	// Assert
+	assert.Equal(t, enum.ErrInvalidExportFormat, err)
+	assert.Zero(t, buf.Len())
+	mockReceptionRepo.AssertNotCalled(t, "GetReceptionByID", mock.Anything)
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/repository"
+	"github.com/ners1us/order-service/internal/tracing"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+type ReopenRequestService interface {
+	RequestReopen(ctx context.Context, receptionID string, reason string, userID string, userRole string) (*model.ReopenRequest, error)
+	GetReopenRequests(ctx context.Context, status string, userRole string) ([]model.ReopenRequest, error)
+	GetReceptionReopenHistory(ctx context.Context, receptionID string) ([]model.ReopenRequest, error)
+	ApproveReopen(ctx context.Context, requestID string, comment string, userID string, userRole string) (*model.ReopenRequest, error)
+	RejectReopen(ctx context.Context, requestID string, comment string, userID string, userRole string) (*model.ReopenRequest, error)
+}
+
+type reopenRequestServiceImpl struct {
+	reopenRequestRepo repository.ReopenRequestRepository
+	receptionRepo     repository.ReceptionRepository
+	pvzRepo           repository.PVZRepository
+	publisher         event.Publisher
+	log               *slog.Logger
+}
+
+func NewReopenRequestService(
+	reopenRequestRepo repository.ReopenRequestRepository,
+	receptionRepo repository.ReceptionRepository,
+	pvzRepo repository.PVZRepository,
+	publisher event.Publisher,
+	log *slog.Logger,
+) ReopenRequestService {
+	return &reopenRequestServiceImpl{
+		reopenRequestRepo,
+		receptionRepo,
+		pvzRepo,
+		publisher,
+		log,
+	}
+}
+
+func (rrs *reopenRequestServiceImpl) RequestReopen(ctx context.Context, receptionID string, reason string, userID string, userRole string) (_ *model.ReopenRequest, err error) {
+	ctx, span := tracing.Start(ctx, "ReopenRequestService.RequestReopen")
+	defer tracing.End(span, &err)
+	if userRole != enum.RoleEmployee.String() {
+		return &model.ReopenRequest{}, enum.ErrNoEmployeeRights
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return &model.ReopenRequest{}, enum.ErrEmptyReopenReason
+	}
+	reception, err := rrs.receptionRepo.GetReceptionByID(ctx, receptionID)
+	if err != nil {
+		return &model.ReopenRequest{}, err
+	}
+	if reception.ID == "" {
+		return &model.ReopenRequest{}, enum.ErrReceptionNotFound
+	}
+	if reception.Status != enum.StatusClosed.String() {
+		return &model.ReopenRequest{}, enum.ErrReceptionNotClosed
+	}
+
+	request := model.ReopenRequest{
+		ID:          uuid.New().String(),
+		ReceptionID: reception.ID,
+		Reason:      reason,
+		RequestedBy: userID,
+		RequestedAt: time.Now(),
+		Status:      enum.ReopenRequestPending.String(),
+	}
+	if err := rrs.reopenRequestRepo.CreateReopenRequest(ctx, &request, enum.StatusReopenRequested.String()); err != nil {
+		return &model.ReopenRequest{}, err
+	}
+	publishReceptionChange(ctx, rrs.log, rrs.publisher, enum.EventReceptionChanged, reception.ID, reception.PVZID)
+	return &request, nil
+}
+
+func (rrs *reopenRequestServiceImpl) GetReopenRequests(ctx context.Context, status string, userRole string) (_ []model.ReopenRequest, err error) {
+	ctx, span := tracing.Start(ctx, "ReopenRequestService.GetReopenRequests")
+	defer tracing.End(span, &err)
+	if userRole != enum.RoleModerator.String() {
+		return nil, enum.ErrNoModeratorRights
+	}
+	if status == "" {
+		status = enum.ReopenRequestPending.String()
+	}
+	if !enum.IsValidReopenRequestStatus(enum.ReopenRequestStatus(status)) {
+		return nil, enum.ErrInvalidReopenStatus
+	}
+	return rrs.reopenRequestRepo.GetReopenRequestsByStatus(ctx, status)
+}
+
+func (rrs *reopenRequestServiceImpl) GetReceptionReopenHistory(ctx context.Context, receptionID string) (_ []model.ReopenRequest, err error) {
+	ctx, span := tracing.Start(ctx, "ReopenRequestService.GetReceptionReopenHistory")
+	defer tracing.End(span, &err)
+	if _, err := uuid.Parse(receptionID); err != nil {
+		return nil, enum.ErrReceptionNotFound
+	}
+	reception, err := rrs.receptionRepo.GetReceptionByID(ctx, receptionID)
+	if err != nil {
+		return nil, err
+	}
+	if reception.ID == "" {
+		return nil, enum.ErrReceptionNotFound
+	}
+	return rrs.reopenRequestRepo.GetReopenRequestsByReceptionID(ctx, receptionID)
+}
+
+func (rrs *reopenRequestServiceImpl) ApproveReopen(ctx context.Context, requestID string, comment string, userID string, userRole string) (_ *model.ReopenRequest, err error) {
+	ctx, span := tracing.Start(ctx, "ReopenRequestService.ApproveReopen")
+	defer tracing.End(span, &err)
+	request, reception, err := rrs.getPendingRequest(ctx, requestID, userRole)
+	if err != nil {
+		return &model.ReopenRequest{}, err
+	}
+
+	pvz, err := rrs.pvzRepo.GetPVZByID(ctx, reception.PVZID)
+	if err != nil {
+		return &model.ReopenRequest{}, err
+	}
+	if pvz.Status == enum.PVZStatusSuspended.String() || pvz.Status == enum.PVZStatusClosed.String() {
+		return &model.ReopenRequest{}, enum.ErrPVZNotActive
+	}
+	currentReception, err := rrs.receptionRepo.GetLastReceptionByPVZID(ctx, reception.PVZID)
+	if err != nil {
+		return &model.ReopenRequest{}, err
+	}
+	if currentReception.Status == enum.StatusInProgress.String() {
+		return &model.ReopenRequest{}, enum.ErrOpenReception
+	}
+
+	return rrs.resolve(ctx, request, reception, enum.ReopenRequestApproved, enum.StatusInProgress, comment, userID)
+}
+
+func (rrs *reopenRequestServiceImpl) RejectReopen(ctx context.Context, requestID string, comment string, userID string, userRole string) (_ *model.ReopenRequest, err error) {
+	ctx, span := tracing.Start(ctx, "ReopenRequestService.RejectReopen")
+	defer tracing.End(span, &err)
+	request, reception, err := rrs.getPendingRequest(ctx, requestID, userRole)
+	if err != nil {
+		return &model.ReopenRequest{}, err
+	}
+	return rrs.resolve(ctx, request, reception, enum.ReopenRequestRejected, enum.StatusClosed, comment, userID)
+}
+
+func (rrs *reopenRequestServiceImpl) getPendingRequest(ctx context.Context, requestID string, userRole string) (*model.ReopenRequest, *model.Reception, error) {
+	if userRole != enum.RoleModerator.String() {
+		return nil, nil, enum.ErrNoModeratorRights
+	}
+	request, err := rrs.reopenRequestRepo.GetReopenRequestByID(ctx, requestID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if request.ID == "" {
+		return nil, nil, enum.ErrReopenRequestNotFound
+	}
+	if request.Status != enum.ReopenRequestPending.String() {
+		return nil, nil, enum.ErrReopenRequestResolved
+	}
+	reception, err := rrs.receptionRepo.GetReceptionByID(ctx, request.ReceptionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if reception.Status != enum.StatusReopenRequested.String() {
+		return nil, nil, enum.ErrReopenRequestResolved
+	}
+	return request, reception, nil
+}
+
+func (rrs *reopenRequestServiceImpl) resolve(
+	ctx context.Context,
+	request *model.ReopenRequest,
+	reception *model.Reception,
+	status enum.ReopenRequestStatus,
+	receptionStatus enum.Status,
+	comment string,
+	userID string,
+) (*model.ReopenRequest, error) {
+	reviewedAt := time.Now()
+	request.Status = status.String()
+	request.ReviewedBy = userID
+	request.ReviewComment = strings.TrimSpace(comment)
+	request.ReviewedAt = &reviewedAt
+	if err := rrs.reopenRequestRepo.ResolveReopenRequest(ctx, request, receptionStatus.String()); err != nil {
+		return &model.ReopenRequest{}, err
+	}
+	publishReceptionChange(ctx, rrs.log, rrs.publisher, enum.EventReceptionChanged, reception.ID, reception.PVZID)
+	return request, nil
+}
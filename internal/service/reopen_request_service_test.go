@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestRequestReopen_Success(t *testing.T) {
+	// Arrange
+	mockReopenRepo := new(repository.MockReopenRequestRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewReopenRequestService(mockReopenRepo, mockReceptionRepo, mockPVZRepo, event.NewBus(), discardLogger)
+	reception := &model.Reception{ID: "rec_1", PVZID: "pvz_1", Status: enum.StatusClosed.String()}
+	mockReceptionRepo.On("GetReceptionByID", "rec_1").Return(reception, nil)
+	mockReopenRepo.On("CreateReopenRequest", mock.Anything, enum.StatusReopenRequested.String()).Return(nil)
+
+	// Act
+	result, err := service.RequestReopen(context.Background(), "rec_1", " missed 3 items ", "user_1", enum.RoleEmployee.String())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.ID)
+	assert.Equal(t, "missed 3 items", result.Reason)
+	assert.Equal(t, enum.ReopenRequestPending.String(), result.Status)
+	assert.Equal(t, "user_1", result.RequestedBy)
+}
+
+func TestRequestReopen_EmptyReason(t *testing.T) {
+	// Arrange
+	mockReopenRepo := new(repository.MockReopenRequestRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewReopenRequestService(mockReopenRepo, mockReceptionRepo, mockPVZRepo, event.NewBus(), discardLogger)
+
+	// Act
+	_, err := service.RequestReopen(context.Background(), "rec_1", "  ", "user_1", enum.RoleEmployee.String())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrEmptyReopenReason, err)
+}
+
+func TestRequestReopen_NotClosed(t *testing.T) {
+	// Arrange
+	mockReopenRepo := new(repository.MockReopenRequestRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewReopenRequestService(mockReopenRepo, mockReceptionRepo, mockPVZRepo, event.NewBus(), discardLogger)
+	reception := &model.Reception{ID: "rec_1", Status: enum.StatusReopenRequested.String()}
+	mockReceptionRepo.On("GetReceptionByID", "rec_1").Return(reception, nil)
+
+	// Act
+	_, err := service.RequestReopen(context.Background(), "rec_1", "reason", "user_1", enum.RoleEmployee.String())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrReceptionNotClosed, err)
+}
+
+func TestGetReopenRequests_DefaultsToPending(t *testing.T) {
+	// Arrange
+	mockReopenRepo := new(repository.MockReopenRequestRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewReopenRequestService(mockReopenRepo, mockReceptionRepo, mockPVZRepo, event.NewBus(), discardLogger)
+	requests := []model.ReopenRequest{{ID: "req_1", Status: enum.ReopenRequestPending.String()}}
+	mockReopenRepo.On("GetReopenRequestsByStatus", enum.ReopenRequestPending.String()).Return(requests, nil)
+
+	// Act
+	result, err := service.GetReopenRequests(context.Background(), "", enum.RoleModerator.String())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, requests, result)
+}
+
+func TestGetReceptionReopenHistory_NotFound(t *testing.T) {
+	// Arrange
+	mockReopenRepo := new(repository.MockReopenRequestRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewReopenRequestService(mockReopenRepo, mockReceptionRepo, mockPVZRepo, event.NewBus(), discardLogger)
+	receptionID := "5b0c1f0e-7f3a-4a52-9d8e-1c2b3a4d5e6f"
+	mockReceptionRepo.On("GetReceptionByID", receptionID).Return(&model.Reception{}, nil)
+
+	// Act
+	_, err := service.GetReceptionReopenHistory(context.Background(), receptionID)
+	_, errMalformed := service.GetReceptionReopenHistory(context.Background(), "rec_1")
+
+	// Assert
+	assert.Equal(t, enum.ErrReceptionNotFound, err)
+	assert.Equal(t, enum.ErrReceptionNotFound, errMalformed)
+	mockReceptionRepo.AssertNotCalled(t, "GetReceptionByID", "rec_1")
+	mockReopenRepo.AssertNotCalled(t, "GetReopenRequestsByReceptionID", mock.Anything)
+}
+
+func TestApproveReopen_Success(t *testing.T) {
+	// Arrange
+	mockReopenRepo := new(repository.MockReopenRequestRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewReopenRequestService(mockReopenRepo, mockReceptionRepo, mockPVZRepo, event.NewBus(), discardLogger)
+	request := &model.ReopenRequest{ID: "req_1", ReceptionID: "rec_1", Status: enum.ReopenRequestPending.String()}
+	reception := &model.Reception{ID: "rec_1", PVZID: "pvz_1", Status: enum.StatusReopenRequested.String()}
+	mockReopenRepo.On("GetReopenRequestByID", "req_1").Return(request, nil)
+	mockReceptionRepo.On("GetReceptionByID", "rec_1").Return(reception, nil)
+	mockPVZRepo.On("GetPVZByID", "pvz_1").Return(&model.PVZ{ID: "pvz_1", Status: enum.PVZStatusActive.String()}, nil)
+	mockReceptionRepo.On("GetLastReceptionByPVZID", "pvz_1").Return(&model.Reception{ID: "rec_2", Status: enum.StatusClosed.String()}, nil)
+	mockReopenRepo.On("ResolveReopenRequest", request, enum.StatusInProgress.String()).Return(nil)
+
+	// Act
+	result, err := service.ApproveReopen(context.Background(), "req_1", "ok", "moderator_1", enum.RoleModerator.String())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, enum.ReopenRequestApproved.String(), result.Status)
+	assert.Equal(t, "moderator_1", result.ReviewedBy)
+	assert.Equal(t, "ok", result.ReviewComment)
+	assert.NotNil(t, result.ReviewedAt)
+}
+
+func TestApproveReopen_OtherReceptionOpen(t *testing.T) {
+	// Arrange
+	mockReopenRepo := new(repository.MockReopenRequestRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewReopenRequestService(mockReopenRepo, mockReceptionRepo, mockPVZRepo, event.NewBus(), discardLogger)
+	request := &model.ReopenRequest{ID: "req_1", ReceptionID: "rec_1", Status: enum.ReopenRequestPending.String()}
+	reception := &model.Reception{ID: "rec_1", PVZID: "pvz_1", Status: enum.StatusReopenRequested.String()}
+	mockReopenRepo.On("GetReopenRequestByID", "req_1").Return(request, nil)
+	mockReceptionRepo.On("GetReceptionByID", "rec_1").Return(reception, nil)
+	mockPVZRepo.On("GetPVZByID", "pvz_1").Return(&model.PVZ{ID: "pvz_1", Status: enum.PVZStatusActive.String()}, nil)
+	mockReceptionRepo.On("GetLastReceptionByPVZID", "pvz_1").Return(&model.Reception{ID: "rec_2", Status: enum.StatusInProgress.String()}, nil)
+
+	// Act
+	_, err := service.ApproveReopen(context.Background(), "req_1", "", "moderator_1", enum.RoleModerator.String())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrOpenReception, err)
+	mockReopenRepo.AssertNotCalled(t, "ResolveReopenRequest", mock.Anything, mock.Anything)
+}
+
+func TestRejectReopen_AlreadyResolved(t *testing.T) {
+	// Arrange
+	mockReopenRepo := new(repository.MockReopenRequestRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewReopenRequestService(mockReopenRepo, mockReceptionRepo, mockPVZRepo, event.NewBus(), discardLogger)
+	request := &model.ReopenRequest{ID: "req_1", ReceptionID: "rec_1", Status: enum.ReopenRequestApproved.String()}
+	mockReopenRepo.On("GetReopenRequestByID", "req_1").Return(request, nil)
+
+	// Act
+	_, err := service.RejectReopen(context.Background(), "req_1", "", "moderator_1", enum.RoleModerator.String())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrReopenRequestResolved, err)
+}
+
+func TestRejectReopen_RepoError(t *testing.T) {
+	// Arrange
+	mockReopenRepo := new(repository.MockReopenRequestRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewReopenRequestService(mockReopenRepo, mockReceptionRepo, mockPVZRepo, event.NewBus(), discardLogger)
+	request := &model.ReopenRequest{ID: "req_1", ReceptionID: "rec_1", Status: enum.ReopenRequestPending.String()}
+	reception := &model.Reception{ID: "rec_1", Status: enum.StatusReopenRequested.String()}
+	mockReopenRepo.On("GetReopenRequestByID", "req_1").Return(request, nil)
+	mockReceptionRepo.On("GetReceptionByID", "rec_1").Return(reception, nil)
+	mockReopenRepo.On("ResolveReopenRequest", request, enum.StatusClosed.String()).Return(errors.New("resolve error"))
+
+	// Act
+	_, err := service.RejectReopen(context.Background(), "req_1", "", "moderator_1", enum.RoleModerator.String())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, "resolve error", err.Error())
+}
+
+func TestApproveReopen_NoModerator(t *testing.T) {
+	// Arrange
+	mockReopenRepo := new(repository.MockReopenRequestRepository)
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockPVZRepo := new(repository.MockPVZRepository)
+	service := NewReopenRequestService(mockReopenRepo, mockReceptionRepo, mockPVZRepo, event.NewBus(), discardLogger)
+
+	// Act
+	_, err := service.ApproveReopen(context.Background(), "req_1", "", "user_1", enum.RoleEmployee.String())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrNoModeratorRights, err)
+}
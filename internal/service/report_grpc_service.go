@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/pkg/generated/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ReportGrpcService struct {
+	proto.UnimplementedReportServiceServer
+	reportService ReportService
+}
+
+func NewReportGrpcService(reportService ReportService) *ReportGrpcService {
+	return &ReportGrpcService{
+		reportService: reportService,
+	}
+}
+
+func (rgs *ReportGrpcService) GetReport(ctx context.Context, req *proto.GetReportRequest) (*proto.GetReportResponse, error) {
+	filter := model.ReportFilter{
+		Period:  req.GetPeriod(),
+		GroupBy: req.GetGroupBy(),
+	}
+	if req.GetStartDate() != nil {
+		filter.StartDate = req.GetStartDate().AsTime()
+	}
+	if req.GetEndDate() != nil {
+		filter.EndDate = req.GetEndDate().AsTime()
+	}
+
+	// The gRPC API has no users, and its reports never include deleted products.
+	report, err := rgs.reportService.GetReport(ctx, &filter, "")
+	if err != nil {
+		if errors.Is(err, enum.ErrInvalidReportPeriod) || errors.Is(err, enum.ErrInvalidReportGroup) || errors.Is(err, enum.ErrInvalidDateRange) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+
+	response := &proto.GetReportResponse{
+		Period:    report.Period,
+		GroupBy:   report.GroupBy,
+		StartDate: timestamppb.New(report.StartDate),
+		EndDate:   timestamppb.New(report.EndDate),
+		Rows:      make([]*proto.ReportRow, 0, len(report.Rows)),
+	}
+
+	for _, row := range report.Rows {
+		productsByType := make(map[string]int64, len(row.ProductsByType))
+		for productType, count := range row.ProductsByType {
+			productsByType[productType] = int64(count)
+		}
+		response.Rows = append(response.Rows, &proto.ReportRow{
+			PeriodStart:                 timestamppb.New(row.PeriodStart),
+			Group:                       row.Group,
+			ReceptionsOpened:            int64(row.ReceptionsOpened),
+			ReceptionsClosed:            int64(row.ReceptionsClosed),
+			ProductsByType:              productsByType,
+			TotalProducts:               int64(row.TotalProducts),
+			AvgReceptionDurationSeconds: row.AvgReceptionDurationSeconds,
+			AvgProductsPerReception:     row.AvgProductsPerReception,
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/repository"
+	"github.com/ners1us/order-service/internal/tracing"
+	"time"
+)
+
+type ReportService interface {
+	GetReport(ctx context.Context, filter *model.ReportFilter, userRole string) (*model.Report, error)
+}
+
+const defaultReportRange = 30 * 24 * time.Hour
+
+type reportServiceImpl struct {
+	reportRepo repository.ReportRepository
+}
+
+func NewReportService(reportRepo repository.ReportRepository) ReportService {
+	return &reportServiceImpl{reportRepo}
+}
+
+// GetReport leaves deleted products out unless filter.IncludeDeleted is set,
+// which only moderators may do.
+func (rs *reportServiceImpl) GetReport(ctx context.Context, filter *model.ReportFilter, userRole string) (_ *model.Report, err error) {
+	ctx, span := tracing.Start(ctx, "ReportService.GetReport")
+	defer tracing.End(span, &err)
+	if filter.IncludeDeleted && userRole != enum.RoleModerator.String() {
+		return &model.Report{}, enum.ErrNoModeratorRights
+	}
+	if filter.Period == "" {
+		filter.Period = enum.ReportPeriodDay.String()
+	}
+	if filter.GroupBy == "" {
+		filter.GroupBy = enum.ReportGroupPVZ.String()
+	}
+	if filter.EndDate.IsZero() {
+		filter.EndDate = time.Now()
+	}
+	if filter.StartDate.IsZero() {
+		filter.StartDate = filter.EndDate.Add(-defaultReportRange)
+	}
+	if !enum.IsValidReportPeriod(enum.ReportPeriod(filter.Period)) {
+		return &model.Report{}, enum.ErrInvalidReportPeriod
+	}
+	if !enum.IsValidReportGroup(enum.ReportGroup(filter.GroupBy)) {
+		return &model.Report{}, enum.ErrInvalidReportGroup
+	}
+	if !filter.StartDate.Before(filter.EndDate) {
+		return &model.Report{}, enum.ErrInvalidDateRange
+	}
+	rows, err := rs.reportRepo.GetReport(ctx, filter)
+	if err != nil {
+		return &model.Report{}, err
+	}
+	return &model.Report{ReportFilter: *filter, Rows: rows}, nil
+}
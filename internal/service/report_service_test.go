@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestGetReport_Success(t *testing.T) {
+	// Arrange
+	mockReportRepo := new(repository.MockReportRepository)
+	service := NewReportService(mockReportRepo)
+	startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	filter := &model.ReportFilter{Period: enum.ReportPeriodWeek.String(), GroupBy: enum.ReportGroupCity.String(), StartDate: startDate, EndDate: endDate}
+	rows := []model.ReportRow{{PeriodStart: startDate, Group: enum.CityMoscow.String(), ReceptionsOpened: 2, TotalProducts: 7}}
+	mockReportRepo.On("GetReport", filter).Return(rows, nil)
+
+	// Act
+	result, err := service.GetReport(context.Background(), filter, enum.RoleEmployee.String())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, enum.ReportPeriodWeek.String(), result.Period)
+	assert.Equal(t, rows, result.Rows)
+}
+
+func TestGetReport_Defaults(t *testing.T) {
+	// Arrange
+	mockReportRepo := new(repository.MockReportRepository)
+	service := NewReportService(mockReportRepo)
+	filter := &model.ReportFilter{}
+	mockReportRepo.On("GetReport", filter).Return([]model.ReportRow{}, nil)
+
+	// Act
+	result, err := service.GetReport(context.Background(), filter, enum.RoleEmployee.String())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, enum.ReportPeriodDay.String(), result.Period)
+	assert.Equal(t, enum.ReportGroupPVZ.String(), result.GroupBy)
+	assert.Equal(t, defaultReportRange, result.EndDate.Sub(result.StartDate))
+}
+
+func TestGetReport_InvalidPeriod(t *testing.T) {
+	// Arrange
+	mockReportRepo := new(repository.MockReportRepository)
+	service := NewReportService(mockReportRepo)
+
+	// Act
+	_, err := service.GetReport(context.Background(), &model.ReportFilter{Period: "year"}, enum.RoleEmployee.String())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrInvalidReportPeriod, err)
+	mockReportRepo.AssertNotCalled(t, "GetReport", mock.Anything)
+}
+
+func TestGetReport_InvalidDateRange(t *testing.T) {
+	// Arrange
+	mockReportRepo := new(repository.MockReportRepository)
+	service := NewReportService(mockReportRepo)
+	startDate := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	_, err := service.GetReport(context.Background(), &model.ReportFilter{StartDate: startDate, EndDate: endDate}, enum.RoleEmployee.String())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, enum.ErrInvalidDateRange, err)
+}
+
+func TestGetReport_RepoError(t *testing.T) {
+	// Arrange
+	mockReportRepo := new(repository.MockReportRepository)
+	service := NewReportService(mockReportRepo)
+	mockReportRepo.On("GetReport", mock.Anything).Return([]model.ReportRow{}, errors.New("report error"))
+
+	// Act
+	_, err := service.GetReport(context.Background(), &model.ReportFilter{}, enum.RoleEmployee.String())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, "report error", err.Error())
+}
+
+func TestGetReport_IncludeDeletedRequiresModerator(t *testing.T) {
+	cases := map[string]struct {
+		role        string
+		expectedErr error
+	}{
+		"Moderator": {role: enum.RoleModerator.String()},
+		"Employee":  {role: enum.RoleEmployee.String(), expectedErr: enum.ErrNoModeratorRights},
+		"NoRole":    {role: "", expectedErr: enum.ErrNoModeratorRights},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			mockReportRepo := new(repository.MockReportRepository)
+			service := NewReportService(mockReportRepo)
+			mockReportRepo.On("GetReport", mock.Anything).Return([]model.ReportRow{}, nil)
+
+			// Act
+			_, err := service.GetReport(context.Background(), &model.ReportFilter{IncludeDeleted: true}, tc.role)
+
+			// Assert
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				mockReportRepo.AssertNotCalled(t, "GetReport", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
+	"github.com/ners1us/order-service/internal/metric"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/repository"
+	"github.com/ners1us/order-service/internal/tracing"
+	"log/slog"
+	"time"
+)
+
+type StaleReceptionService interface {
+	ProcessStaleReceptions(ctx context.Context) (int, error)
+}
+
+type StaleReceptionPolicy struct {
+	Threshold      time.Duration
+	CityThresholds map[string]time.Duration
+	Action         enum.EscalationAction
+}
+
+func (srp StaleReceptionPolicy) thresholdFor(city string) time.Duration {
+	if threshold, ok := srp.CityThresholds[city]; ok {
+		return threshold
+	}
+	return srp.Threshold
+}
+
+type staleReceptionServiceImpl struct {
+	receptionRepo  repository.ReceptionRepository
+	escalationRepo repository.EscalationRepository
+	publisher      event.Publisher
+	policy         StaleReceptionPolicy
+	log            *slog.Logger
+	now            func() time.Time
+}
+
+func NewStaleReceptionService(
+	receptionRepo repository.ReceptionRepository,
+	escalationRepo repository.EscalationRepository,
+	publisher event.Publisher,
+	policy StaleReceptionPolicy,
+	log *slog.Logger,
+) StaleReceptionService {
+	return &staleReceptionServiceImpl{
+		receptionRepo:  receptionRepo,
+		escalationRepo: escalationRepo,
+		publisher:      publisher,
+		policy:         policy,
+		log:            log,
+		now:            time.Now,
+	}
+}
+
+func (srs *staleReceptionServiceImpl) ProcessStaleReceptions(ctx context.Context) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "StaleReceptionService.ProcessStaleReceptions")
+	defer tracing.End(span, &err)
+	openReceptions, err := srs.receptionRepo.GetOpenReceptions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := srs.now()
+	escalated := 0
+	for _, openReception := range openReceptions {
+		age := now.Sub(openReception.OpenedAt)
+		if age < srs.policy.thresholdFor(openReception.City) || isEscalated(openReception) {
+			continue
+		}
+
+		escalation := model.ReceptionEscalation{
+			ID:          uuid.New().String(),
+			ReceptionID: openReception.Reception.ID,
+			PVZID:       openReception.Reception.PVZID,
+			City:        openReception.City,
+			OpenedAt:    openReception.OpenedAt,
+			AgeSeconds:  int64(age.Seconds()),
+			Action:      srs.policy.Action.String(),
+			CreatedAt:   now,
+		}
+		if err := srs.escalationRepo.CreateEscalation(ctx, &escalation); err != nil {
+			// The reception was closed after it was read, so there is nothing left to escalate.
+			if errors.Is(err, enum.ErrNoOpenReceptionToClose) {
+				continue
+			}
+			return escalated, err
+		}
+		escalated++
+		metric.ReceptionsStale.WithLabelValues(escalation.City, escalation.Action).Inc()
+		if srs.policy.Action == enum.EscalationActionClose {
+			observeReceptionClosed(openReception.City, closedByScheduler, openReception.OpenedAt, now)
+		}
+
+		escalationEvent := model.Event{
+			Type:       enum.EventReceptionEscalated.String(),
+			OccurredAt: now,
+			Payload:    escalation,
+		}
+		if err := srs.publisher.Publish(&escalationEvent); err != nil {
+			srs.log.ErrorContext(ctx, "failed to publish escalation",
+				slog.String("reception_id", escalation.ReceptionID), slog.Any("error", err))
+		}
+	}
+	return escalated, nil
+}
+
+func isEscalated(openReception model.OpenReception) bool {
+	return openReception.EscalatedAt != nil && !openReception.EscalatedAt.Before(openReception.OpenedAt)
+}
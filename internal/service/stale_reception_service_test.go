@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ners1us/order-service/internal/enum"
+	"github.com/ners1us/order-service/internal/event"
+	"github.com/ners1us/order-service/internal/model"
+	"github.com/ners1us/order-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func newTestStaleReceptionService(
+	mockReceptionRepo *repository.MockReceptionRepository,
+	mockEscalationRepo *repository.MockEscalationRepository,
+	mockPublisher *event.MockPublisher,
+	action enum.EscalationAction,
+	now time.Time,
+) StaleReceptionService {
+	service := NewStaleReceptionService(mockReceptionRepo, mockEscalationRepo, mockPublisher, StaleReceptionPolicy{
+		Threshold:      12 * time.Hour,
+		CityThresholds: map[string]time.Duration{enum.CityKazan.String(): 2 * time.Hour},
+		Action:         action,
+	}, discardLogger)
+	service.(*staleReceptionServiceImpl).now = func() time.Time { return now }
+	return service
+}
+
+func TestProcessStaleReceptions_PerCityThreshold(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockEscalationRepo := new(repository.MockEscalationRepository)
+	mockPublisher := new(event.MockPublisher)
+	now := time.Date(2025, 4, 10, 9, 0, 0, 0, time.UTC)
+	service := newTestStaleReceptionService(mockReceptionRepo, mockEscalationRepo, mockPublisher, enum.EscalationActionClose, now)
+	openReceptions := []model.OpenReception{
+		{Reception: model.Reception{ID: "rec_1", PVZID: "pvz_1"}, City: enum.CityKazan.String(), OpenedAt: now.Add(-3 * time.Hour)},
+		{Reception: model.Reception{ID: "rec_2", PVZID: "pvz_2"}, City: enum.CityMoscow.String(), OpenedAt: now.Add(-3 * time.Hour)},
+	}
+	mockReceptionRepo.On("GetOpenReceptions").Return(openReceptions, nil)
+	mockEscalationRepo.On("CreateEscalation", mock.MatchedBy(func(escalation *model.ReceptionEscalation) bool {
+		return escalation.ReceptionID == "rec_1" && escalation.Action == enum.EscalationActionClose.String() &&
+			escalation.AgeSeconds == int64((3*time.Hour).Seconds())
+	})).Return(nil)
+	mockPublisher.On("Publish", mock.MatchedBy(func(e *model.Event) bool {
+		return e.Type == enum.EventReceptionEscalated.String()
+	})).Return(nil)
+
+	// Act
+	escalated, err := service.ProcessStaleReceptions(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, escalated)
+	mockEscalationRepo.AssertNumberOfCalls(t, "CreateEscalation", 1)
+	mockPublisher.AssertNumberOfCalls(t, "Publish", 1)
+}
+
+func TestProcessStaleReceptions_AlreadyEscalated(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockEscalationRepo := new(repository.MockEscalationRepository)
+	mockPublisher := new(event.MockPublisher)
+	now := time.Date(2025, 4, 10, 9, 0, 0, 0, time.UTC)
+	service := newTestStaleReceptionService(mockReceptionRepo, mockEscalationRepo, mockPublisher, enum.EscalationActionFlag, now)
+	escalatedAt := now.Add(-time.Hour)
+	openReceptions := []model.OpenReception{
+		{Reception: model.Reception{ID: "rec_1"}, City: enum.CityMoscow.String(), OpenedAt: now.Add(-20 * time.Hour), EscalatedAt: &escalatedAt},
+	}
+	mockReceptionRepo.On("GetOpenReceptions").Return(openReceptions, nil)
+
+	// Act
+	escalated, err := service.ProcessStaleReceptions(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, escalated)
+	mockEscalationRepo.AssertNotCalled(t, "CreateEscalation", mock.Anything)
+}
+
+func TestProcessStaleReceptions_PublishErrorIgnored(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockEscalationRepo := new(repository.MockEscalationRepository)
+	mockPublisher := new(event.MockPublisher)
+	now := time.Date(2025, 4, 10, 9, 0, 0, 0, time.UTC)
+	service := newTestStaleReceptionService(mockReceptionRepo, mockEscalationRepo, mockPublisher, enum.EscalationActionFlag, now)
+	openReceptions := []model.OpenReception{
+		{Reception: model.Reception{ID: "rec_1"}, City: enum.CityMoscow.String(), OpenedAt: now.Add(-13 * time.Hour)},
+	}
+	mockReceptionRepo.On("GetOpenReceptions").Return(openReceptions, nil)
+	mockEscalationRepo.On("CreateEscalation", mock.Anything).Return(nil)
+	mockPublisher.On("Publish", mock.Anything).Return(errors.New("publish error"))
+
+	// Act
+	escalated, err := service.ProcessStaleReceptions(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, escalated)
+}
+
+func TestProcessStaleReceptions_RepoError(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockEscalationRepo := new(repository.MockEscalationRepository)
+	mockPublisher := new(event.MockPublisher)
+	service := newTestStaleReceptionService(mockReceptionRepo, mockEscalationRepo, mockPublisher, enum.EscalationActionFlag, time.Now())
+	mockReceptionRepo.On("GetOpenReceptions").Return([]model.OpenReception{}, errors.New("db error"))
+
+	// Act
+	_, err := service.ProcessStaleReceptions(context.Background())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, "db error", err.Error())
+}
+
+func TestProcessStaleReceptions_ClosedConcurrently(t *testing.T) {
+	// Arrange
+	mockReceptionRepo := new(repository.MockReceptionRepository)
+	mockEscalationRepo := new(repository.MockEscalationRepository)
+	mockPublisher := new(event.MockPublisher)
+	now := time.Date(2025, 4, 10, 9, 0, 0, 0, time.UTC)
+	service := newTestStaleReceptionService(mockReceptionRepo, mockEscalationRepo, mockPublisher, enum.EscalationActionClose, now)
+	openReceptions := []model.OpenReception{
+		{Reception: model.Reception{ID: "rec_1"}, City: enum.CityMoscow.String(), OpenedAt: now.Add(-13 * time.Hour)},
+	}
+	mockReceptionRepo.On("GetOpenReceptions").Return(openReceptions, nil)
+	mockEscalationRepo.On("CreateEscalation", mock.Anything).Return(enum.ErrNoOpenReceptionToClose)
+
+	// Act
+	escalated, err := service.ProcessStaleReceptions(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, escalated)
+	mockPublisher.AssertNotCalled(t, "Publish", mock.Anything)
+}
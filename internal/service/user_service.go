@@ -1,17 +1,20 @@
 package service
 
 import (
+	"context"
 	"github.com/google/uuid"
 	"github.com/ners1us/order-service/internal/enum"
 	"github.com/ners1us/order-service/internal/model"
 	"github.com/ners1us/order-service/internal/repository"
+	"github.com/ners1us/order-service/internal/tracing"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type UserService interface {
-	Register(user *model.User) (*model.User, error)
-	Login(email, password string) (string, error)
-	DummyLogin(role string) (string, error)
+	Register(ctx context.Context, user *model.User) (*model.User, error)
+	CreateUser(ctx context.Context, email, password, role string) (*model.User, error)
+	Login(ctx context.Context, email, password string) (string, error)
+	DummyLogin(ctx context.Context, role string) (string, error)
 }
 
 type userServiceImpl struct {
@@ -26,22 +29,59 @@ func NewUserService(userRepo repository.UserRepository, jwtService JWTService) U
 	}
 }
 
-func (us *userServiceImpl) Register(user *model.User) (*model.User, error) {
+// Register creates an employee account for a self-service sign up. An empty
+// role defaults to employee; moderators are only created through CreateUser.
+func (us *userServiceImpl) Register(ctx context.Context, user *model.User) (_ *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.Register")
+	defer tracing.End(span, &err)
+	if user.Role == "" {
+		user.Role = enum.RoleEmployee.String()
+	}
+	if user.Role != enum.RoleEmployee.String() {
+		return &model.User{}, enum.ErrRegistrationRole
+	}
+	return us.createUser(ctx, user)
+}
+
+func (us *userServiceImpl) createUser(ctx context.Context, user *model.User) (*model.User, error) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
 	if err != nil {
 		return &model.User{}, err
 	}
 	user.Password = string(hashedPassword)
 	user.ID = uuid.New().String()
-	err = us.userRepo.CreateUser(user)
+	err = us.userRepo.CreateUser(ctx, user)
 	if err != nil {
 		return &model.User{}, err
 	}
 	return user, nil
 }
 
-func (us *userServiceImpl) Login(email, password string) (string, error) {
-	user, err := us.userRepo.GetUserByEmail(email)
+// CreateUser registers a user with a validated role and a unique email. It is
+// used by operators to bootstrap accounts, e.g. the first moderator.
+func (us *userServiceImpl) CreateUser(ctx context.Context, email, password, role string) (_ *model.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.CreateUser")
+	defer tracing.End(span, &err)
+	if email == "" || password == "" {
+		return &model.User{}, enum.ErrEmptyCredentials
+	}
+	if !enum.IsValidRole(enum.Role(role)) {
+		return &model.User{}, enum.ErrInvalidRole
+	}
+	existingUser, err := us.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		return &model.User{}, err
+	}
+	if existingUser.ID != "" {
+		return &model.User{}, enum.ErrUserAlreadyExists
+	}
+	return us.createUser(ctx, &model.User{Email: email, Password: password, Role: role})
+}
+
+func (us *userServiceImpl) Login(ctx context.Context, email, password string) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.Login")
+	defer tracing.End(span, &err)
+	user, err := us.userRepo.GetUserByEmail(ctx, email)
 	if err != nil {
 		return "", err
 	}
@@ -54,7 +94,9 @@ func (us *userServiceImpl) Login(email, password string) (string, error) {
 	return us.jwtService.GenerateToken(user.ID, user.Role)
 }
 
-func (us *userServiceImpl) DummyLogin(role string) (string, error) {
+func (us *userServiceImpl) DummyLogin(ctx context.Context, role string) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.DummyLogin")
+	defer tracing.End(span, &err)
 	if !enum.IsValidRole(enum.Role(role)) {
 		return "", enum.ErrInvalidRole
 	}
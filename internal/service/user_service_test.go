@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"github.com/ners1us/order-service/internal/enum"
 	"github.com/ners1us/order-service/internal/model"
 	"github.com/ners1us/order-service/internal/repository"
@@ -8,18 +9,19 @@ import (
 	"github.com/stretchr/testify/mock"
 	"golang.org/x/crypto/bcrypt"
 	"testing"
+	"time"
 )
 
 func TestDummyLogin_InvalidRole(t *testing.T) {
 	// Arrange
 	secretKey := "secret_for_testing"
-	jwtService := NewJWTService(secretKey)
+	jwtService := NewJWTService(secretKey, time.Hour)
 	mockUserRepo := new(repository.MockUserRepository)
 	service := NewUserService(mockUserRepo, jwtService)
 	role := "programmer"
 
 	// Act
-	_, err := service.DummyLogin(role)
+	_, err := service.DummyLogin(context.Background(), role)
 
 	// Assert
 	assert.Error(t, err)
@@ -29,13 +31,13 @@ func TestDummyLogin_InvalidRole(t *testing.T) {
 func TestDummyLogin_Moderator(t *testing.T) {
 	// Arrange
 	secretKey := "secret_for_testing"
-	jwtService := NewJWTService(secretKey)
+	jwtService := NewJWTService(secretKey, time.Hour)
 	mockUserRepo := new(repository.MockUserRepository)
 	service := NewUserService(mockUserRepo, jwtService)
 	role := enum.RoleModerator.String()
 
 	// Act
-	token, err := service.DummyLogin(role)
+	token, err := service.DummyLogin(context.Background(), role)
 
 	// Assert
 	assert.NoError(t, err)
@@ -45,14 +47,14 @@ func TestDummyLogin_Moderator(t *testing.T) {
 func TestRegister_Success(t *testing.T) {
 	// Arrange
 	secretKey := "secret_for_testing"
-	jwtService := NewJWTService(secretKey)
+	jwtService := NewJWTService(secretKey, time.Hour)
 	mockUserRepo := new(repository.MockUserRepository)
 	service := NewUserService(mockUserRepo, jwtService)
 	user := &model.User{Email: "tonyStark@example.com", Password: "password"}
 	mockUserRepo.On("CreateUser", mock.Anything).Return(nil)
 
 	// Act
-	result, err := service.Register(user)
+	result, err := service.Register(context.Background(), user)
 
 	// Assert
 	assert.NoError(t, err)
@@ -60,10 +62,107 @@ func TestRegister_Success(t *testing.T) {
 	assert.NotEqual(t, "password", result.Password)
 }
 
+func TestRegister_Roles(t *testing.T) {
+	cases := map[string]struct {
+		role         string
+		expectedRole string
+		expectedErr  error
+	}{
+		"EmptyDefaultsToEmployee": {
+			expectedRole: enum.RoleEmployee.String(),
+		},
+		"Employee": {
+			role:         enum.RoleEmployee.String(),
+			expectedRole: enum.RoleEmployee.String(),
+		},
+		"ModeratorRejected": {
+			role:        enum.RoleModerator.String(),
+			expectedErr: enum.ErrRegistrationRole,
+		},
+		"UnknownRoleRejected": {
+			role:        "admin",
+			expectedErr: enum.ErrRegistrationRole,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			mockUserRepo := new(repository.MockUserRepository)
+			service := NewUserService(mockUserRepo, NewJWTService("secret_for_testing", time.Hour))
+			user := &model.User{Email: "peterParker@example.com", Password: "password", Role: tc.role}
+			mockUserRepo.On("CreateUser", mock.Anything).Return(nil)
+
+			// Act
+			result, err := service.Register(context.Background(), user)
+
+			// Assert
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				mockUserRepo.AssertNotCalled(t, "CreateUser", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedRole, result.Role)
+		})
+	}
+}
+
+func TestCreateUser_Success(t *testing.T) {
+	// Arrange
+	secretKey := "secret_for_testing"
+	jwtService := NewJWTService(secretKey, time.Hour)
+	mockUserRepo := new(repository.MockUserRepository)
+	service := NewUserService(mockUserRepo, jwtService)
+	email := "moderator@example.com"
+	mockUserRepo.On("GetUserByEmail", email).Return(&model.User{}, nil)
+	mockUserRepo.On("CreateUser", mock.Anything).Return(nil)
+
+	// Act
+	result, err := service.CreateUser(context.Background(), email, "password", enum.RoleModerator.String())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.ID)
+	assert.Equal(t, enum.RoleModerator.String(), result.Role)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestCreateUser_AlreadyExists(t *testing.T) {
+	// Arrange
+	secretKey := "secret_for_testing"
+	jwtService := NewJWTService(secretKey, time.Hour)
+	mockUserRepo := new(repository.MockUserRepository)
+	service := NewUserService(mockUserRepo, jwtService)
+	email := "moderator@example.com"
+	mockUserRepo.On("GetUserByEmail", email).Return(&model.User{ID: "user1", Email: email}, nil)
+
+	// Act
+	_, err := service.CreateUser(context.Background(), email, "password", enum.RoleModerator.String())
+
+	// Assert
+	assert.Equal(t, enum.ErrUserAlreadyExists, err)
+	mockUserRepo.AssertNotCalled(t, "CreateUser", mock.Anything)
+}
+
+func TestCreateUser_InvalidRole(t *testing.T) {
+	// Arrange
+	secretKey := "secret_for_testing"
+	jwtService := NewJWTService(secretKey, time.Hour)
+	mockUserRepo := new(repository.MockUserRepository)
+	service := NewUserService(mockUserRepo, jwtService)
+
+	// Act
+	_, err := service.CreateUser(context.Background(), "admin@example.com", "password", "admin")
+
+	// Assert
+	assert.Equal(t, enum.ErrInvalidRole, err)
+}
+
 func TestLogin_WrongPassword(t *testing.T) {
 	// Arrange
 	secretKey := "secret_for_testing"
-	jwtService := NewJWTService(secretKey)
+	jwtService := NewJWTService(secretKey, time.Hour)
 	mockUserRepo := new(repository.MockUserRepository)
 	service := NewUserService(mockUserRepo, jwtService)
 	email := "coolmail@example.com"
@@ -73,7 +172,7 @@ func TestLogin_WrongPassword(t *testing.T) {
 	mockUserRepo.On("GetUserByEmail", email).Return(user, nil)
 
 	// Act
-	_, err := service.Login(email, password)
+	_, err := service.Login(context.Background(), email, password)
 
 	// Assert
 	assert.Error(t, err)
@@ -83,7 +182,7 @@ func TestLogin_WrongPassword(t *testing.T) {
 func TestLogin_UserNotFound(t *testing.T) {
 	// Arrange
 	secretKey := "secret_for_testing"
-	jwtService := NewJWTService(secretKey)
+	jwtService := NewJWTService(secretKey, time.Hour)
 	mockUserRepo := new(repository.MockUserRepository)
 	service := NewUserService(mockUserRepo, jwtService)
 	email := "linuxuser@mail.com"
@@ -91,7 +190,7 @@ func TestLogin_UserNotFound(t *testing.T) {
 	mockUserRepo.On("GetUserByEmail", email).Return(&model.User{}, nil)
 
 	// Act
-	_, err := service.Login(email, password)
+	_, err := service.Login(context.Background(), email, password)
 
 	// Assert
 	assert.Error(t, err)
@@ -0,0 +1,42 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"testing"
+)
+
+func TestEnd(t *testing.T) {
+	cases := map[string]struct {
+		err            error
+		expectedStatus codes.Code
+		expectedEvents int
+	}{
+		"Success": {err: nil, expectedStatus: codes.Unset, expectedEvents: 0},
+		"Error":   {err: errors.New("db error"), expectedStatus: codes.Error, expectedEvents: 1},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+			_, span := provider.Tracer("test").Start(context.Background(), "operation")
+			err := tc.err
+
+			// Act
+			End(span, &err)
+
+			// Assert
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tc.expectedStatus, spans[0].Status().Code)
+			assert.Len(t, spans[0].Events(), tc.expectedEvents)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+ALTER TABLE pvzs
+    DROP COLUMN status,
+    DROP COLUMN phone,
+    DROP COLUMN working_hours,
+    DROP COLUMN longitude,
+    DROP COLUMN latitude,
+    DROP COLUMN address;
@@ -0,0 +1,7 @@
+ALTER TABLE pvzs
+    ADD COLUMN address       TEXT             NOT NULL DEFAULT '',
+    ADD COLUMN latitude      DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    ADD COLUMN longitude     DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    ADD COLUMN working_hours TEXT             NOT NULL DEFAULT '',
+    ADD COLUMN phone         TEXT             NOT NULL DEFAULT '',
+    ADD COLUMN status        TEXT             NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'suspended', 'closed'));
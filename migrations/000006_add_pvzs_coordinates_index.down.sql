@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_pvzs_active_coordinates;
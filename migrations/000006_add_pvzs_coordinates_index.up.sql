@@ -0,0 +1 @@
+CREATE INDEX idx_pvzs_active_coordinates ON pvzs (latitude, longitude) WHERE status = 'active';
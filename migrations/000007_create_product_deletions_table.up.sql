@@ -0,0 +1,13 @@
+CREATE TABLE product_deletions
+(
+    id                UUID PRIMARY KEY,
+    product_id        UUID      NOT NULL,
+    reception_id      UUID      NOT NULL REFERENCES receptions (id),
+    product_type      TEXT      NOT NULL,
+    product_date_time TIMESTAMP NOT NULL,
+    reason            TEXT      NOT NULL CHECK (reason IN ('mis_scan', 'duplicate', 'damaged', 'wrong_reception', 'other')),
+    deleted_by        TEXT      NOT NULL,
+    deleted_at        TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_product_deletions_reception_id ON product_deletions (reception_id);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS reception_reopen_requests;
+
+DROP INDEX IF EXISTS idx_receptions_one_in_progress_per_pvz;
+
+UPDATE receptions SET status = 'closed' WHERE status = 'reopen_requested';
+
+ALTER TABLE receptions
+    DROP CONSTRAINT receptions_status_check,
+    ADD CONSTRAINT receptions_status_check CHECK (status IN ('in_progress', 'closed'));
@@ -0,0 +1,22 @@
+ALTER TABLE receptions
+    DROP CONSTRAINT receptions_status_check,
+    ADD CONSTRAINT receptions_status_check CHECK (status IN ('in_progress', 'closed', 'reopen_requested'));
+
+CREATE UNIQUE INDEX idx_receptions_one_in_progress_per_pvz ON receptions (pvz_id) WHERE status = 'in_progress';
+
+CREATE TABLE reception_reopen_requests
+(
+    id             UUID PRIMARY KEY,
+    reception_id   UUID      NOT NULL REFERENCES receptions (id),
+    reason         TEXT      NOT NULL,
+    requested_by   TEXT      NOT NULL,
+    requested_at   TIMESTAMP NOT NULL,
+    status         TEXT      NOT NULL CHECK (status IN ('pending', 'approved', 'rejected')),
+    reviewed_by    TEXT      NOT NULL DEFAULT '',
+    review_comment TEXT      NOT NULL DEFAULT '',
+    reviewed_at    TIMESTAMP
+);
+
+CREATE INDEX idx_reception_reopen_requests_reception_id ON reception_reopen_requests (reception_id, requested_at);
+CREATE INDEX idx_reception_reopen_requests_status ON reception_reopen_requests (status, requested_at);
+CREATE UNIQUE INDEX idx_reception_reopen_requests_one_pending ON reception_reopen_requests (reception_id) WHERE status = 'pending';
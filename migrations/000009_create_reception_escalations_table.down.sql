@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_receptions_status;
+DROP TABLE IF EXISTS reception_escalations;
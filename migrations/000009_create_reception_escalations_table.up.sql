@@ -0,0 +1,14 @@
+CREATE TABLE reception_escalations
+(
+    id           UUID PRIMARY KEY,
+    reception_id UUID      NOT NULL REFERENCES receptions (id),
+    pvz_id       UUID      NOT NULL REFERENCES pvzs (id),
+    city         TEXT      NOT NULL,
+    opened_at    TIMESTAMP NOT NULL,
+    age_seconds  BIGINT    NOT NULL,
+    action       TEXT      NOT NULL CHECK (action IN ('flag', 'close')),
+    created_at   TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_reception_escalations_reception_id ON reception_escalations (reception_id, created_at);
+CREATE INDEX idx_receptions_status ON receptions (status) WHERE status = 'in_progress';
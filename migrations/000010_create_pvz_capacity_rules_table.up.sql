@@ -0,0 +1,7 @@
+CREATE TABLE pvz_capacity_rules
+(
+    pvz_id         UUID PRIMARY KEY REFERENCES pvzs (id),
+    max_products   INTEGER CHECK (max_products > 0),
+    accepted_types TEXT[],
+    type_quotas    JSONB NOT NULL DEFAULT '{}'
+);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_products_date_time;
+DROP INDEX IF EXISTS idx_receptions_closed_at;
+DROP INDEX IF EXISTS idx_receptions_date_time;
+
+ALTER TABLE receptions
+    DROP COLUMN IF EXISTS closed_at;
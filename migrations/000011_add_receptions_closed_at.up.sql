@@ -0,0 +1,6 @@
+ALTER TABLE receptions
+    ADD COLUMN closed_at TIMESTAMP;
+
+CREATE INDEX idx_receptions_date_time ON receptions (date_time);
+CREATE INDEX idx_receptions_closed_at ON receptions (closed_at) WHERE closed_at IS NOT NULL;
+CREATE INDEX idx_products_date_time ON products (date_time);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_products_reception_id_active;
+
+DELETE FROM products WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS deletion_reason,
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,13 @@
+ALTER TABLE products
+    ADD COLUMN deleted_at      TIMESTAMP,
+    ADD COLUMN deleted_by      TEXT,
+    ADD COLUMN deletion_reason TEXT CHECK (deletion_reason IN ('mis_scan', 'duplicate', 'damaged', 'wrong_reception', 'other', 'last_product'));
+
+-- Products deleted with a reason before soft delete are restored from their
+-- deletion records, so that the history of their receptions is complete.
+INSERT INTO products (id, date_time, type, reception_id, deleted_at, deleted_by, deletion_reason)
+SELECT product_id, product_date_time, product_type, reception_id, deleted_at, deleted_by, reason
+FROM product_deletions
+ON CONFLICT (id) DO NOTHING;
+
+CREATE INDEX idx_products_reception_id_active ON products (reception_id, date_time) WHERE deleted_at IS NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_reception_events_reception_id;
+DROP TABLE IF EXISTS reception_events;
@@ -0,0 +1,47 @@
+CREATE TABLE reception_events
+(
+    id           BIGSERIAL PRIMARY KEY,
+    reception_id UUID      NOT NULL REFERENCES receptions (id),
+    type         TEXT      NOT NULL CHECK (type IN ('reception_opened', 'product_added', 'product_deleted',
+                                                    'reception_closed', 'reception_reopened')),
+    actor        TEXT,
+    occurred_at  TIMESTAMP NOT NULL,
+    product_id   UUID,
+    product_type TEXT,
+    reason       TEXT
+);
+
+CREATE INDEX idx_reception_events_reception_id ON reception_events (reception_id, occurred_at, id);
+
+-- Events before this migration are rebuilt from the current rows. Who opened
+-- a reception or added a product was not recorded, so those actors stay
+-- unknown, and a close undone by an approved reopen request left no trace.
+INSERT INTO reception_events (reception_id, type, actor, occurred_at, product_id, product_type, reason)
+SELECT reception_id, type, actor, occurred_at, product_id, product_type, reason
+FROM (SELECT r.id AS reception_id, 'reception_opened' AS type, NULL AS actor, r.date_time AS occurred_at,
+             NULL::UUID AS product_id, NULL AS product_type, NULL AS reason, 0 AS step
+      FROM receptions r
+      UNION ALL
+      SELECT p.reception_id, 'product_added', NULL, p.date_time, p.id, p.type, NULL, 1
+      FROM products p
+      UNION ALL
+      SELECT p.reception_id, 'product_deleted', p.deleted_by, p.deleted_at, p.id, p.type, p.deletion_reason, 2
+      FROM products p
+      WHERE p.deleted_at IS NOT NULL
+      UNION ALL
+      SELECT rrr.reception_id, 'reception_reopened', rrr.reviewed_by, rrr.reviewed_at, NULL, NULL, rrr.reason, 3
+      FROM reception_reopen_requests rrr
+      WHERE rrr.status = 'approved'
+      UNION ALL
+      SELECT r.id, 'reception_closed',
+             CASE
+                 WHEN EXISTS (SELECT 1
+                              FROM reception_escalations e
+                              WHERE e.reception_id = r.id
+                                AND e.action = 'close'
+                                AND e.created_at = r.closed_at) THEN 'scheduler'
+                 END,
+             r.closed_at, NULL, NULL, NULL, 4
+      FROM receptions r
+      WHERE r.closed_at IS NOT NULL) AS history
+ORDER BY occurred_at, step;
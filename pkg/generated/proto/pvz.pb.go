@@ -25,8 +25,9 @@ const (
 type ReceptionStatus int32
 
 const (
-	ReceptionStatus_RECEPTION_STATUS_IN_PROGRESS ReceptionStatus = 0
-	ReceptionStatus_RECEPTION_STATUS_CLOSED      ReceptionStatus = 1
+	ReceptionStatus_RECEPTION_STATUS_IN_PROGRESS      ReceptionStatus = 0
+	ReceptionStatus_RECEPTION_STATUS_CLOSED           ReceptionStatus = 1
+	ReceptionStatus_RECEPTION_STATUS_REOPEN_REQUESTED ReceptionStatus = 2
 )
 
 // Enum value maps for ReceptionStatus.
@@ -34,10 +35,12 @@ var (
 	ReceptionStatus_name = map[int32]string{
 		0: "RECEPTION_STATUS_IN_PROGRESS",
 		1: "RECEPTION_STATUS_CLOSED",
+		2: "RECEPTION_STATUS_REOPEN_REQUESTED",
 	}
 	ReceptionStatus_value = map[string]int32{
-		"RECEPTION_STATUS_IN_PROGRESS": 0,
-		"RECEPTION_STATUS_CLOSED":      1,
+		"RECEPTION_STATUS_IN_PROGRESS":      0,
+		"RECEPTION_STATUS_CLOSED":           1,
+		"RECEPTION_STATUS_REOPEN_REQUESTED": 2,
 	}
 )
 
@@ -73,6 +76,12 @@ type PVZ struct {
 	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	RegistrationDate *timestamp.Timestamp   `protobuf:"bytes,2,opt,name=registration_date,json=registrationDate,proto3" json:"registration_date,omitempty"`
 	City             string                 `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
+	Address          string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Latitude         *float64               `protobuf:"fixed64,5,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	Longitude        *float64               `protobuf:"fixed64,6,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
+	WorkingHours     string                 `protobuf:"bytes,7,opt,name=working_hours,json=workingHours,proto3" json:"working_hours,omitempty"`
+	Phone            string                 `protobuf:"bytes,8,opt,name=phone,proto3" json:"phone,omitempty"`
+	Status           string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -128,6 +137,48 @@ func (x *PVZ) GetCity() string {
 	return ""
 }
 
+func (x *PVZ) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PVZ) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *PVZ) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+func (x *PVZ) GetWorkingHours() string {
+	if x != nil {
+		return x.WorkingHours
+	}
+	return ""
+}
+
+func (x *PVZ) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *PVZ) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
 type GetPVZListRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -208,25 +259,219 @@ func (x *GetPVZListResponse) GetPvzs() []*PVZ {
 	return nil
 }
 
+type GetNearbyPVZsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Radius        float64                `protobuf:"fixed64,3,opt,name=radius,proto3" json:"radius,omitempty"`
+	City          string                 `protobuf:"bytes,4,opt,name=city,proto3" json:"city,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNearbyPVZsRequest) Reset() {
+	*x = GetNearbyPVZsRequest{}
+	mi := &file_internal_api_grpc_proto_pvz_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNearbyPVZsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNearbyPVZsRequest) ProtoMessage() {}
+
+func (x *GetNearbyPVZsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_grpc_proto_pvz_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNearbyPVZsRequest.ProtoReflect.Descriptor instead.
+func (*GetNearbyPVZsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_api_grpc_proto_pvz_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetNearbyPVZsRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *GetNearbyPVZsRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *GetNearbyPVZsRequest) GetRadius() float64 {
+	if x != nil {
+		return x.Radius
+	}
+	return 0
+}
+
+func (x *GetNearbyPVZsRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *GetNearbyPVZsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type NearbyPVZ struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pvz           *PVZ                   `protobuf:"bytes,1,opt,name=pvz,proto3" json:"pvz,omitempty"`
+	Distance      float64                `protobuf:"fixed64,2,opt,name=distance,proto3" json:"distance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NearbyPVZ) Reset() {
+	*x = NearbyPVZ{}
+	mi := &file_internal_api_grpc_proto_pvz_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearbyPVZ) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearbyPVZ) ProtoMessage() {}
+
+func (x *NearbyPVZ) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_grpc_proto_pvz_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearbyPVZ.ProtoReflect.Descriptor instead.
+func (*NearbyPVZ) Descriptor() ([]byte, []int) {
+	return file_internal_api_grpc_proto_pvz_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *NearbyPVZ) GetPvz() *PVZ {
+	if x != nil {
+		return x.Pvz
+	}
+	return nil
+}
+
+func (x *NearbyPVZ) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+type GetNearbyPVZsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pvzs          []*NearbyPVZ           `protobuf:"bytes,1,rep,name=pvzs,proto3" json:"pvzs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNearbyPVZsResponse) Reset() {
+	*x = GetNearbyPVZsResponse{}
+	mi := &file_internal_api_grpc_proto_pvz_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNearbyPVZsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNearbyPVZsResponse) ProtoMessage() {}
+
+func (x *GetNearbyPVZsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_grpc_proto_pvz_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNearbyPVZsResponse.ProtoReflect.Descriptor instead.
+func (*GetNearbyPVZsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_api_grpc_proto_pvz_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetNearbyPVZsResponse) GetPvzs() []*NearbyPVZ {
+	if x != nil {
+		return x.Pvzs
+	}
+	return nil
+}
+
 var File_internal_api_grpc_proto_pvz_proto protoreflect.FileDescriptor
 
 const file_internal_api_grpc_proto_pvz_proto_rawDesc = "" +
 	"\n" +
-	"!internal/api/grpc/proto/pvz.proto\x12\x06pvz.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"r\n" +
+	"!internal/api/grpc/proto/pvz.proto\x12\x06pvz.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbe\x02\n" +
 	"\x03PVZ\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12G\n" +
 	"\x11registration_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x10registrationDate\x12\x12\n" +
-	"\x04city\x18\x03 \x01(\tR\x04city\"\x13\n" +
+	"\x04city\x18\x03 \x01(\tR\x04city\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12\x1f\n" +
+	"\blatitude\x18\x05 \x01(\x01H\x00R\blatitude\x88\x01\x01\x12!\n" +
+	"\tlongitude\x18\x06 \x01(\x01H\x01R\tlongitude\x88\x01\x01\x12#\n" +
+	"\rworking_hours\x18\a \x01(\tR\fworkingHours\x12\x14\n" +
+	"\x05phone\x18\b \x01(\tR\x05phone\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06statusB\v\n" +
+	"\t_latitudeB\f\n" +
+	"\n" +
+	"_longitude\"\x13\n" +
 	"\x11GetPVZListRequest\"5\n" +
 	"\x12GetPVZListResponse\x12\x1f\n" +
-	"\x04pvzs\x18\x01 \x03(\v2\v.pvz.v1.PVZR\x04pvzs*P\n" +
+	"\x04pvzs\x18\x01 \x03(\v2\v.pvz.v1.PVZR\x04pvzs\"\x92\x01\n" +
+	"\x14GetNearbyPVZsRequest\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12\x16\n" +
+	"\x06radius\x18\x03 \x01(\x01R\x06radius\x12\x12\n" +
+	"\x04city\x18\x04 \x01(\tR\x04city\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"F\n" +
+	"\tNearbyPVZ\x12\x1d\n" +
+	"\x03pvz\x18\x01 \x01(\v2\v.pvz.v1.PVZR\x03pvz\x12\x1a\n" +
+	"\bdistance\x18\x02 \x01(\x01R\bdistance\">\n" +
+	"\x15GetNearbyPVZsResponse\x12%\n" +
+	"\x04pvzs\x18\x01 \x03(\v2\x11.pvz.v1.NearbyPVZR\x04pvzs*w\n" +
 	"\x0fReceptionStatus\x12 \n" +
 	"\x1cRECEPTION_STATUS_IN_PROGRESS\x10\x00\x12\x1b\n" +
-	"\x17RECEPTION_STATUS_CLOSED\x10\x012Q\n" +
+	"\x17RECEPTION_STATUS_CLOSED\x10\x01\x12%\n" +
+	"!RECEPTION_STATUS_REOPEN_REQUESTED\x10\x022\x9f\x01\n" +
 	"\n" +
 	"PVZService\x12C\n" +
 	"\n" +
-	"GetPVZList\x12\x19.pvz.v1.GetPVZListRequest\x1a\x1a.pvz.v1.GetPVZListResponseB<Z:github.com/ners1us/order-service/pkg/generated/proto;protob\x06proto3"
+	"GetPVZList\x12\x19.pvz.v1.GetPVZListRequest\x1a\x1a.pvz.v1.GetPVZListResponse\x12L\n" +
+	"\rGetNearbyPVZs\x12\x1c.pvz.v1.GetNearbyPVZsRequest\x1a\x1d.pvz.v1.GetNearbyPVZsResponseB<Z:github.com/ners1us/order-service/pkg/generated/proto;protob\x06proto3"
 
 var (
 	file_internal_api_grpc_proto_pvz_proto_rawDescOnce sync.Once
@@ -241,24 +486,31 @@ func file_internal_api_grpc_proto_pvz_proto_rawDescGZIP() []byte {
 }
 
 var file_internal_api_grpc_proto_pvz_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_internal_api_grpc_proto_pvz_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_internal_api_grpc_proto_pvz_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_internal_api_grpc_proto_pvz_proto_goTypes = []any{
-	(ReceptionStatus)(0),        // 0: pvz.v1.ReceptionStatus
-	(*PVZ)(nil),                 // 1: pvz.v1.PVZ
-	(*GetPVZListRequest)(nil),   // 2: pvz.v1.GetPVZListRequest
-	(*GetPVZListResponse)(nil),  // 3: pvz.v1.GetPVZListResponse
-	(*timestamp.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	(ReceptionStatus)(0),          // 0: pvz.v1.ReceptionStatus
+	(*PVZ)(nil),                   // 1: pvz.v1.PVZ
+	(*GetPVZListRequest)(nil),     // 2: pvz.v1.GetPVZListRequest
+	(*GetPVZListResponse)(nil),    // 3: pvz.v1.GetPVZListResponse
+	(*GetNearbyPVZsRequest)(nil),  // 4: pvz.v1.GetNearbyPVZsRequest
+	(*NearbyPVZ)(nil),             // 5: pvz.v1.NearbyPVZ
+	(*GetNearbyPVZsResponse)(nil), // 6: pvz.v1.GetNearbyPVZsResponse
+	(*timestamp.Timestamp)(nil),   // 7: google.protobuf.Timestamp
 }
 var file_internal_api_grpc_proto_pvz_proto_depIdxs = []int32{
-	4, // 0: pvz.v1.PVZ.registration_date:type_name -> google.protobuf.Timestamp
+	7, // 0: pvz.v1.PVZ.registration_date:type_name -> google.protobuf.Timestamp
 	1, // 1: pvz.v1.GetPVZListResponse.pvzs:type_name -> pvz.v1.PVZ
-	2, // 2: pvz.v1.PVZService.GetPVZList:input_type -> pvz.v1.GetPVZListRequest
-	3, // 3: pvz.v1.PVZService.GetPVZList:output_type -> pvz.v1.GetPVZListResponse
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	1, // 2: pvz.v1.NearbyPVZ.pvz:type_name -> pvz.v1.PVZ
+	5, // 3: pvz.v1.GetNearbyPVZsResponse.pvzs:type_name -> pvz.v1.NearbyPVZ
+	2, // 4: pvz.v1.PVZService.GetPVZList:input_type -> pvz.v1.GetPVZListRequest
+	4, // 5: pvz.v1.PVZService.GetNearbyPVZs:input_type -> pvz.v1.GetNearbyPVZsRequest
+	3, // 6: pvz.v1.PVZService.GetPVZList:output_type -> pvz.v1.GetPVZListResponse
+	6, // 7: pvz.v1.PVZService.GetNearbyPVZs:output_type -> pvz.v1.GetNearbyPVZsResponse
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_internal_api_grpc_proto_pvz_proto_init() }
@@ -266,13 +518,14 @@ func file_internal_api_grpc_proto_pvz_proto_init() {
 	if File_internal_api_grpc_proto_pvz_proto != nil {
 		return
 	}
+	file_internal_api_grpc_proto_pvz_proto_msgTypes[0].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_api_grpc_proto_pvz_proto_rawDesc), len(file_internal_api_grpc_proto_pvz_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   3,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
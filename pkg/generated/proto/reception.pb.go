@@ -0,0 +1,327 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.12.4
+// source: internal/api/grpc/proto/reception.proto
+
+package proto
+
+import (
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetReceptionTimelineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReceptionId   string                 `protobuf:"bytes,1,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReceptionTimelineRequest) Reset() {
+	*x = GetReceptionTimelineRequest{}
+	mi := &file_internal_api_grpc_proto_reception_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReceptionTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReceptionTimelineRequest) ProtoMessage() {}
+
+func (x *GetReceptionTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_grpc_proto_reception_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReceptionTimelineRequest.ProtoReflect.Descriptor instead.
+func (*GetReceptionTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_internal_api_grpc_proto_reception_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetReceptionTimelineRequest) GetReceptionId() string {
+	if x != nil {
+		return x.ReceptionId
+	}
+	return ""
+}
+
+type ReceptionEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	OccurredAt    *timestamp.Timestamp   `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	ProductId     string                 `protobuf:"bytes,5,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductType   string                 `protobuf:"bytes,6,opt,name=product_type,json=productType,proto3" json:"product_type,omitempty"`
+	Reason        string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceptionEvent) Reset() {
+	*x = ReceptionEvent{}
+	mi := &file_internal_api_grpc_proto_reception_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceptionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceptionEvent) ProtoMessage() {}
+
+func (x *ReceptionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_grpc_proto_reception_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceptionEvent.ProtoReflect.Descriptor instead.
+func (*ReceptionEvent) Descriptor() ([]byte, []int) {
+	return file_internal_api_grpc_proto_reception_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReceptionEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ReceptionEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ReceptionEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ReceptionEvent) GetOccurredAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *ReceptionEvent) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReceptionEvent) GetProductType() string {
+	if x != nil {
+		return x.ProductType
+	}
+	return ""
+}
+
+func (x *ReceptionEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GetReceptionTimelineResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReceptionId   string                 `protobuf:"bytes,1,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`
+	PvzId         string                 `protobuf:"bytes,2,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	DateTime      *timestamp.Timestamp   `protobuf:"bytes,4,opt,name=date_time,json=dateTime,proto3" json:"date_time,omitempty"`
+	ClosedAt      *timestamp.Timestamp   `protobuf:"bytes,5,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	Events        []*ReceptionEvent      `protobuf:"bytes,6,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReceptionTimelineResponse) Reset() {
+	*x = GetReceptionTimelineResponse{}
+	mi := &file_internal_api_grpc_proto_reception_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReceptionTimelineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReceptionTimelineResponse) ProtoMessage() {}
+
+func (x *GetReceptionTimelineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_grpc_proto_reception_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReceptionTimelineResponse.ProtoReflect.Descriptor instead.
+func (*GetReceptionTimelineResponse) Descriptor() ([]byte, []int) {
+	return file_internal_api_grpc_proto_reception_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetReceptionTimelineResponse) GetReceptionId() string {
+	if x != nil {
+		return x.ReceptionId
+	}
+	return ""
+}
+
+func (x *GetReceptionTimelineResponse) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *GetReceptionTimelineResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetReceptionTimelineResponse) GetDateTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.DateTime
+	}
+	return nil
+}
+
+func (x *GetReceptionTimelineResponse) GetClosedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return nil
+}
+
+func (x *GetReceptionTimelineResponse) GetEvents() []*ReceptionEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+var File_internal_api_grpc_proto_reception_proto protoreflect.FileDescriptor
+
+const file_internal_api_grpc_proto_reception_proto_rawDesc = "" +
+	"\n" +
+	"'internal/api/grpc/proto/reception.proto\x12\x06pvz.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"@\n" +
+	"\x1bGetReceptionTimelineRequest\x12!\n" +
+	"\freception_id\x18\x01 \x01(\tR\vreceptionId\"\xe1\x01\n" +
+	"\x0eReceptionEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x05 \x01(\tR\tproductId\x12!\n" +
+	"\fproduct_type\x18\x06 \x01(\tR\vproductType\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\"\x92\x02\n" +
+	"\x1cGetReceptionTimelineResponse\x12!\n" +
+	"\freception_id\x18\x01 \x01(\tR\vreceptionId\x12\x15\n" +
+	"\x06pvz_id\x18\x02 \x01(\tR\x05pvzId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x127\n" +
+	"\tdate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bdateTime\x127\n" +
+	"\tclosed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\x12.\n" +
+	"\x06events\x18\x06 \x03(\v2\x16.pvz.v1.ReceptionEventR\x06events2u\n" +
+	"\x10ReceptionService\x12a\n" +
+	"\x14GetReceptionTimeline\x12#.pvz.v1.GetReceptionTimelineRequest\x1a$.pvz.v1.GetReceptionTimelineResponseB<Z:github.com/ners1us/order-service/pkg/generated/proto;protob\x06proto3"
+
+var (
+	file_internal_api_grpc_proto_reception_proto_rawDescOnce sync.Once
+	file_internal_api_grpc_proto_reception_proto_rawDescData []byte
+)
+
+func file_internal_api_grpc_proto_reception_proto_rawDescGZIP() []byte {
+	file_internal_api_grpc_proto_reception_proto_rawDescOnce.Do(func() {
+		file_internal_api_grpc_proto_reception_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_internal_api_grpc_proto_reception_proto_rawDesc), len(file_internal_api_grpc_proto_reception_proto_rawDesc)))
+	})
+	return file_internal_api_grpc_proto_reception_proto_rawDescData
+}
+
+var file_internal_api_grpc_proto_reception_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_internal_api_grpc_proto_reception_proto_goTypes = []any{
+	(*GetReceptionTimelineRequest)(nil),  // 0: pvz.v1.GetReceptionTimelineRequest
+	(*ReceptionEvent)(nil),               // 1: pvz.v1.ReceptionEvent
+	(*GetReceptionTimelineResponse)(nil), // 2: pvz.v1.GetReceptionTimelineResponse
+	(*timestamp.Timestamp)(nil),          // 3: google.protobuf.Timestamp
+}
+var file_internal_api_grpc_proto_reception_proto_depIdxs = []int32{
+	3, // 0: pvz.v1.ReceptionEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	3, // 1: pvz.v1.GetReceptionTimelineResponse.date_time:type_name -> google.protobuf.Timestamp
+	3, // 2: pvz.v1.GetReceptionTimelineResponse.closed_at:type_name -> google.protobuf.Timestamp
+	1, // 3: pvz.v1.GetReceptionTimelineResponse.events:type_name -> pvz.v1.ReceptionEvent
+	0, // 4: pvz.v1.ReceptionService.GetReceptionTimeline:input_type -> pvz.v1.GetReceptionTimelineRequest
+	2, // 5: pvz.v1.ReceptionService.GetReceptionTimeline:output_type -> pvz.v1.GetReceptionTimelineResponse
+	5, // [5:6] is the sub-list for method output_type
+	4, // [4:5] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_internal_api_grpc_proto_reception_proto_init() }
+func file_internal_api_grpc_proto_reception_proto_init() {
+	if File_internal_api_grpc_proto_reception_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_api_grpc_proto_reception_proto_rawDesc), len(file_internal_api_grpc_proto_reception_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internal_api_grpc_proto_reception_proto_goTypes,
+		DependencyIndexes: file_internal_api_grpc_proto_reception_proto_depIdxs,
+		MessageInfos:      file_internal_api_grpc_proto_reception_proto_msgTypes,
+	}.Build()
+	File_internal_api_grpc_proto_reception_proto = out.File
+	file_internal_api_grpc_proto_reception_proto_goTypes = nil
+	file_internal_api_grpc_proto_reception_proto_depIdxs = nil
+}
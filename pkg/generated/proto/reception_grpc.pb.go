@@ -0,0 +1,121 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.12.4
+// source: internal/api/grpc/proto/reception.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ReceptionService_GetReceptionTimeline_FullMethodName = "/pvz.v1.ReceptionService/GetReceptionTimeline"
+)
+
+// ReceptionServiceClient is the client API for ReceptionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReceptionServiceClient interface {
+	GetReceptionTimeline(ctx context.Context, in *GetReceptionTimelineRequest, opts ...grpc.CallOption) (*GetReceptionTimelineResponse, error)
+}
+
+type receptionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReceptionServiceClient(cc grpc.ClientConnInterface) ReceptionServiceClient {
+	return &receptionServiceClient{cc}
+}
+
+func (c *receptionServiceClient) GetReceptionTimeline(ctx context.Context, in *GetReceptionTimelineRequest, opts ...grpc.CallOption) (*GetReceptionTimelineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReceptionTimelineResponse)
+	err := c.cc.Invoke(ctx, ReceptionService_GetReceptionTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReceptionServiceServer is the server API for ReceptionService service.
+// All implementations must embed UnimplementedReceptionServiceServer
+// for forward compatibility.
+type ReceptionServiceServer interface {
+	GetReceptionTimeline(context.Context, *GetReceptionTimelineRequest) (*GetReceptionTimelineResponse, error)
+	mustEmbedUnimplementedReceptionServiceServer()
+}
+
+// UnimplementedReceptionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReceptionServiceServer struct{}
+
+func (UnimplementedReceptionServiceServer) GetReceptionTimeline(context.Context, *GetReceptionTimelineRequest) (*GetReceptionTimelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReceptionTimeline not implemented")
+}
+func (UnimplementedReceptionServiceServer) mustEmbedUnimplementedReceptionServiceServer() {}
+func (UnimplementedReceptionServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeReceptionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReceptionServiceServer will
+// result in compilation errors.
+type UnsafeReceptionServiceServer interface {
+	mustEmbedUnimplementedReceptionServiceServer()
+}
+
+func RegisterReceptionServiceServer(s grpc.ServiceRegistrar, srv ReceptionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedReceptionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ReceptionService_ServiceDesc, srv)
+}
+
+func _ReceptionService_GetReceptionTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReceptionTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReceptionServiceServer).GetReceptionTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReceptionService_GetReceptionTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReceptionServiceServer).GetReceptionTimeline(ctx, req.(*GetReceptionTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReceptionService_ServiceDesc is the grpc.ServiceDesc for ReceptionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReceptionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pvz.v1.ReceptionService",
+	HandlerType: (*ReceptionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetReceptionTimeline",
+			Handler:    _ReceptionService_GetReceptionTimeline_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/api/grpc/proto/reception.proto",
+}
@@ -0,0 +1,363 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.12.4
+// source: internal/api/grpc/proto/report.proto
+
+package proto
+
+import (
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Period        string                 `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`
+	GroupBy       string                 `protobuf:"bytes,2,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	StartDate     *timestamp.Timestamp   `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       *timestamp.Timestamp   `protobuf:"bytes,4,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReportRequest) Reset() {
+	*x = GetReportRequest{}
+	mi := &file_internal_api_grpc_proto_report_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReportRequest) ProtoMessage() {}
+
+func (x *GetReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_grpc_proto_report_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReportRequest.ProtoReflect.Descriptor instead.
+func (*GetReportRequest) Descriptor() ([]byte, []int) {
+	return file_internal_api_grpc_proto_report_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetReportRequest) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *GetReportRequest) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
+func (x *GetReportRequest) GetStartDate() *timestamp.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *GetReportRequest) GetEndDate() *timestamp.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+type ReportRow struct {
+	state                       protoimpl.MessageState `protogen:"open.v1"`
+	PeriodStart                 *timestamp.Timestamp   `protobuf:"bytes,1,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	Group                       string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	ReceptionsOpened            int64                  `protobuf:"varint,3,opt,name=receptions_opened,json=receptionsOpened,proto3" json:"receptions_opened,omitempty"`
+	ReceptionsClosed            int64                  `protobuf:"varint,4,opt,name=receptions_closed,json=receptionsClosed,proto3" json:"receptions_closed,omitempty"`
+	ProductsByType              map[string]int64       `protobuf:"bytes,5,rep,name=products_by_type,json=productsByType,proto3" json:"products_by_type,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	TotalProducts               int64                  `protobuf:"varint,6,opt,name=total_products,json=totalProducts,proto3" json:"total_products,omitempty"`
+	AvgReceptionDurationSeconds *float64               `protobuf:"fixed64,7,opt,name=avg_reception_duration_seconds,json=avgReceptionDurationSeconds,proto3,oneof" json:"avg_reception_duration_seconds,omitempty"`
+	AvgProductsPerReception     float64                `protobuf:"fixed64,8,opt,name=avg_products_per_reception,json=avgProductsPerReception,proto3" json:"avg_products_per_reception,omitempty"`
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *ReportRow) Reset() {
+	*x = ReportRow{}
+	mi := &file_internal_api_grpc_proto_report_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportRow) ProtoMessage() {}
+
+func (x *ReportRow) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_grpc_proto_report_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportRow.ProtoReflect.Descriptor instead.
+func (*ReportRow) Descriptor() ([]byte, []int) {
+	return file_internal_api_grpc_proto_report_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReportRow) GetPeriodStart() *timestamp.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *ReportRow) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *ReportRow) GetReceptionsOpened() int64 {
+	if x != nil {
+		return x.ReceptionsOpened
+	}
+	return 0
+}
+
+func (x *ReportRow) GetReceptionsClosed() int64 {
+	if x != nil {
+		return x.ReceptionsClosed
+	}
+	return 0
+}
+
+func (x *ReportRow) GetProductsByType() map[string]int64 {
+	if x != nil {
+		return x.ProductsByType
+	}
+	return nil
+}
+
+func (x *ReportRow) GetTotalProducts() int64 {
+	if x != nil {
+		return x.TotalProducts
+	}
+	return 0
+}
+
+func (x *ReportRow) GetAvgReceptionDurationSeconds() float64 {
+	if x != nil && x.AvgReceptionDurationSeconds != nil {
+		return *x.AvgReceptionDurationSeconds
+	}
+	return 0
+}
+
+func (x *ReportRow) GetAvgProductsPerReception() float64 {
+	if x != nil {
+		return x.AvgProductsPerReception
+	}
+	return 0
+}
+
+type GetReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Period        string                 `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`
+	GroupBy       string                 `protobuf:"bytes,2,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	StartDate     *timestamp.Timestamp   `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       *timestamp.Timestamp   `protobuf:"bytes,4,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	Rows          []*ReportRow           `protobuf:"bytes,5,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReportResponse) Reset() {
+	*x = GetReportResponse{}
+	mi := &file_internal_api_grpc_proto_report_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReportResponse) ProtoMessage() {}
+
+func (x *GetReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_grpc_proto_report_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReportResponse.ProtoReflect.Descriptor instead.
+func (*GetReportResponse) Descriptor() ([]byte, []int) {
+	return file_internal_api_grpc_proto_report_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetReportResponse) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *GetReportResponse) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
+func (x *GetReportResponse) GetStartDate() *timestamp.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *GetReportResponse) GetEndDate() *timestamp.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *GetReportResponse) GetRows() []*ReportRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+var File_internal_api_grpc_proto_report_proto protoreflect.FileDescriptor
+
+const file_internal_api_grpc_proto_report_proto_rawDesc = "" +
+	"\n" +
+	"$internal/api/grpc/proto/report.proto\x12\x06pvz.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb7\x01\n" +
+	"\x10GetReportRequest\x12\x16\n" +
+	"\x06period\x18\x01 \x01(\tR\x06period\x12\x19\n" +
+	"\bgroup_by\x18\x02 \x01(\tR\agroupBy\x129\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"\x9f\x04\n" +
+	"\tReportRow\x12=\n" +
+	"\fperiod_start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vperiodStart\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12+\n" +
+	"\x11receptions_opened\x18\x03 \x01(\x03R\x10receptionsOpened\x12+\n" +
+	"\x11receptions_closed\x18\x04 \x01(\x03R\x10receptionsClosed\x12O\n" +
+	"\x10products_by_type\x18\x05 \x03(\v2%.pvz.v1.ReportRow.ProductsByTypeEntryR\x0eproductsByType\x12%\n" +
+	"\x0etotal_products\x18\x06 \x01(\x03R\rtotalProducts\x12H\n" +
+	"\x1eavg_reception_duration_seconds\x18\a \x01(\x01H\x00R\x1bavgReceptionDurationSeconds\x88\x01\x01\x12;\n" +
+	"\x1aavg_products_per_reception\x18\b \x01(\x01R\x17avgProductsPerReception\x1aA\n" +
+	"\x13ProductsByTypeEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01B!\n" +
+	"\x1f_avg_reception_duration_seconds\"\xdf\x01\n" +
+	"\x11GetReportResponse\x12\x16\n" +
+	"\x06period\x18\x01 \x01(\tR\x06period\x12\x19\n" +
+	"\bgroup_by\x18\x02 \x01(\tR\agroupBy\x129\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12%\n" +
+	"\x04rows\x18\x05 \x03(\v2\x11.pvz.v1.ReportRowR\x04rows2Q\n" +
+	"\rReportService\x12@\n" +
+	"\tGetReport\x12\x18.pvz.v1.GetReportRequest\x1a\x19.pvz.v1.GetReportResponseB<Z:github.com/ners1us/order-service/pkg/generated/proto;protob\x06proto3"
+
+var (
+	file_internal_api_grpc_proto_report_proto_rawDescOnce sync.Once
+	file_internal_api_grpc_proto_report_proto_rawDescData []byte
+)
+
+func file_internal_api_grpc_proto_report_proto_rawDescGZIP() []byte {
+	file_internal_api_grpc_proto_report_proto_rawDescOnce.Do(func() {
+		file_internal_api_grpc_proto_report_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_internal_api_grpc_proto_report_proto_rawDesc), len(file_internal_api_grpc_proto_report_proto_rawDesc)))
+	})
+	return file_internal_api_grpc_proto_report_proto_rawDescData
+}
+
+var file_internal_api_grpc_proto_report_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_internal_api_grpc_proto_report_proto_goTypes = []any{
+	(*GetReportRequest)(nil),    // 0: pvz.v1.GetReportRequest
+	(*ReportRow)(nil),           // 1: pvz.v1.ReportRow
+	(*GetReportResponse)(nil),   // 2: pvz.v1.GetReportResponse
+	nil,                         // 3: pvz.v1.ReportRow.ProductsByTypeEntry
+	(*timestamp.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_internal_api_grpc_proto_report_proto_depIdxs = []int32{
+	4, // 0: pvz.v1.GetReportRequest.start_date:type_name -> google.protobuf.Timestamp
+	4, // 1: pvz.v1.GetReportRequest.end_date:type_name -> google.protobuf.Timestamp
+	4, // 2: pvz.v1.ReportRow.period_start:type_name -> google.protobuf.Timestamp
+	3, // 3: pvz.v1.ReportRow.products_by_type:type_name -> pvz.v1.ReportRow.ProductsByTypeEntry
+	4, // 4: pvz.v1.GetReportResponse.start_date:type_name -> google.protobuf.Timestamp
+	4, // 5: pvz.v1.GetReportResponse.end_date:type_name -> google.protobuf.Timestamp
+	1, // 6: pvz.v1.GetReportResponse.rows:type_name -> pvz.v1.ReportRow
+	0, // 7: pvz.v1.ReportService.GetReport:input_type -> pvz.v1.GetReportRequest
+	2, // 8: pvz.v1.ReportService.GetReport:output_type -> pvz.v1.GetReportResponse
+	8, // [8:9] is the sub-list for method output_type
+	7, // [7:8] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_internal_api_grpc_proto_report_proto_init() }
+func file_internal_api_grpc_proto_report_proto_init() {
+	if File_internal_api_grpc_proto_report_proto != nil {
+		return
+	}
+	file_internal_api_grpc_proto_report_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_api_grpc_proto_report_proto_rawDesc), len(file_internal_api_grpc_proto_report_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internal_api_grpc_proto_report_proto_goTypes,
+		DependencyIndexes: file_internal_api_grpc_proto_report_proto_depIdxs,
+		MessageInfos:      file_internal_api_grpc_proto_report_proto_msgTypes,
+	}.Build()
+	File_internal_api_grpc_proto_report_proto = out.File
+	file_internal_api_grpc_proto_report_proto_goTypes = nil
+	file_internal_api_grpc_proto_report_proto_depIdxs = nil
+}